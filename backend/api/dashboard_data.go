@@ -1,15 +1,19 @@
 package api
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"sort"
-	"strconv"
 	"time"
 
 	"github.com/GeneralTask/task-manager/backend/logging"
 
 	"github.com/GeneralTask/task-manager/backend/constants"
 	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/jobs"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -57,14 +61,20 @@ type DashboardData struct {
 }
 
 type DashboardPoint struct {
-	X int `json:"x"`
-	Y int `json:"y"`
+	X   int       `json:"x"`
+	Y   int       `json:"y"`
+	Day time.Time `json:"-"`
+}
+
+type dashboardPointKey struct {
+	SubjectID primitive.ObjectID
+	DataID    primitive.ObjectID
+	Day       int64
 }
 
 const DEFAULT_LOOKBACK_DAYS = 14
 const NUM_DAYS_IN_WEEK = 7
 const NUM_DAYS_IN_WEEKEND = 2
-const NUM_HOURS_IN_DAY = 24
 const NUM_WEEKDAYS = NUM_DAYS_IN_WEEK - NUM_DAYS_IN_WEEKEND
 
 const ICON_TEAM = "team"
@@ -76,6 +86,10 @@ const COLOR_PINK = "pink"
 const COLOR_BLUE = "blue"
 const COLOR_GRAY = "gray"
 
+const USER_DAILY_ESTIMATED = "Daily estimated time (You)"
+const USER_WEEKLY_ESTIMATED = "Weekly average estimated time (You)"
+const USER_DAILY_TRACKED = "Daily tracked time (You)"
+const USER_WEEKLY_TRACKED = "Weekly average tracked time (You)"
 const TEAM_DAILY_AVERAGE = "Daily average (Your team)"
 const TEAM_WEEKLY_AVERAGE = "Weekly average (Your team)"
 const TEAM_MEMBER_DAILY_AVERAGE = "Daily average (Team member)"
@@ -85,11 +99,13 @@ const INDUSTRY_WEEKLY_AVERAGE = "Weekly average (Industry)"
 
 const GRAPH_NAME_GITHUB_PR = "Code review response time"
 const GRAPH_NAME_FOCUS_TIME = "Hours per day in big blocks"
+const GRAPH_NAME_TIME_TRACKING = "Estimated vs. tracked minutes on completed tasks"
 
 var GraphIDTeamPR = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
 var GraphIDIndividualPR = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2}
 var GraphIDTeamFocusTime = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3}
 var GraphIDIndividualFocusTime = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4}
+var GraphIDUserTimeTracking = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 2}
 
 var DataIDPRChartIndustryAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 5}
 var DataIDPRChartTeamAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 6}
@@ -97,32 +113,62 @@ var DataIDPRChartUserAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 var DataIDFocusTimeIndustryAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 8}
 var DataIDFocusTimeTeamAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 9}
 var DataIDFocusTimeUserAverage = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0}
+var DataIDTimeTrackingEstimated = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 3}
+var DataIDTimeTrackingTracked = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 4}
 
 var SubjectIDTeam = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1}
+var SubjectIDUser = primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 5}
 
 func (api *API) DashboardData(c *gin.Context) {
-	logger := logging.GetSentryLogger()
 	userID := getUserIDFromContext(c)
-	dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
-	if err != nil || dashboardTeam == nil {
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
 		Handle500(c)
 		return
 	}
-	dashboardTeamMembers, err := database.GetDashboardTeamMembers(api.DB, dashboardTeam.ID)
-	if err != nil || dashboardTeamMembers == nil {
-		Handle500(c)
+	location := jobs.GetDashboardLocation(user)
+	intervals, err := api.getDashboardIntervalsFromParams(c, location)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
 		return
 	}
-	// this lookback calculation is approximate for now, will refine as needed
-	now := api.GetCurrentTime()
-	lookbackDays := DEFAULT_LOOKBACK_DAYS + int(now.Weekday())
-	dashboardDataPoints, err := database.GetDashboardDataPoints(api.DB, dashboardTeam.ID, now, lookbackDays)
-	if err != nil || dashboardDataPoints == nil {
+	dashboardResult, err := api.getDashboardResult(userID, intervals, location)
+	if err != nil {
 		Handle500(c)
 		return
 	}
+	c.JSON(200, dashboardResult)
+}
 
-	intervals := api.getWeekdayIntervalsFromLookbackDays(lookbackDays)
+func (api *API) getDashboardResult(userID primitive.ObjectID, intervals []DashboardInterval, location *time.Location) (*DashboardResult, error) {
+	logger := logging.GetSentryLogger()
+	dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	dashboardTeamMembers, err := database.GetDashboardTeamMembers(api.DB, dashboardTeam.ID)
+	if err != nil {
+		return nil, err
+	}
+	// the data points can be dated in another timezone, so the range is widened by a day on each side
+	dashboardDataPoints, err := database.GetDashboardDataPoints(
+		api.DB,
+		dashboardTeam.ID,
+		intervals[0].DatetimeStart.AddDate(0, 0, -1),
+		intervals[len(intervals)-1].DatetimeEnd.AddDate(0, 0, 1),
+	)
+	if err != nil {
+		return nil, err
+	}
+	timeTrackingDataPoints, err := api.getTimeTrackingDataPoints(userID, intervals, location)
+	if err != nil {
+		return nil, err
+	}
+	*dashboardDataPoints = append(*dashboardDataPoints, timeTrackingDataPoints...)
+	// when the job has saved several data points for the same day, the most recent one is used
+	sort.SliceStable(*dashboardDataPoints, func(i, j int) bool {
+		return (*dashboardDataPoints)[i].CreatedAt < (*dashboardDataPoints)[j].CreatedAt
+	})
 
 	subjects := []DashboardSubject{{
 		ID:        SubjectIDTeam,
@@ -139,17 +185,24 @@ func (api *API) DashboardData(c *gin.Context) {
 			GraphIDs: []primitive.ObjectID{GraphIDIndividualFocusTime, GraphIDIndividualPR},
 		})
 	}
+	subjects = append(subjects, DashboardSubject{
+		ID:       SubjectIDUser,
+		Name:     "You",
+		Icon:     ICON_USER,
+		GraphIDs: []primitive.ObjectID{GraphIDUserTimeTracking},
+	})
 
 	data := make(map[primitive.ObjectID]map[primitive.ObjectID]map[primitive.ObjectID]DashboardData)
+	dayToPointIndex := make(map[dashboardPointKey]int)
 	for _, dataPoint := range *dashboardDataPoints {
 		subjectID := SubjectIDTeam
 		if dataPoint.IndividualID != primitive.NilObjectID {
 			subjectID = dataPoint.IndividualID
 		}
+		day := getDashboardDataPointDay(dataPoint, location)
 		intervalID := primitive.NilObjectID
 		for _, interval := range intervals {
-			// add a minute before to catch things right at midnight
-			if (dataPoint.Date.Time().Equal(interval.DatetimeStart) || dataPoint.Date.Time().After(interval.DatetimeStart)) && dataPoint.Date.Time().Before(interval.DatetimeEnd) {
+			if !day.Before(interval.DatetimeStart) && day.Before(interval.DatetimeEnd) {
 				intervalID = interval.ID
 			}
 		}
@@ -178,6 +231,10 @@ func (api *API) DashboardData(c *gin.Context) {
 			} else {
 				dataID = DataIDFocusTimeUserAverage
 			}
+		} else if dataPoint.GraphType == constants.DashboardGraphTypeEstimatedTime {
+			dataID = DataIDTimeTrackingEstimated
+		} else if dataPoint.GraphType == constants.DashboardGraphTypeTrackedTime {
+			dataID = DataIDTimeTrackingTracked
 		} else {
 			logger.Error().Msgf("invalid data point graph type value: '%s'", dataPoint.GraphType)
 			continue
@@ -192,13 +249,18 @@ func (api *API) DashboardData(c *gin.Context) {
 			data[subjectID][intervalID][dataID] = DashboardData{}
 		}
 		dashboardData := data[subjectID][intervalID][dataID]
-		dashboardData.Points = append(dashboardData.Points, DashboardPoint{
-			X: int(dataPoint.Date.Time().Unix()),
-			Y: dataPoint.Value,
-		})
-		sort.Slice(dashboardData.Points, func(i, j int) bool {
-			return dashboardData.Points[i].X < dashboardData.Points[j].X
-		})
+		point := DashboardPoint{
+			X:   int(dataPoint.Date.Time().Unix()),
+			Y:   dataPoint.Value,
+			Day: day,
+		}
+		pointKey := dashboardPointKey{SubjectID: subjectID, DataID: dataID, Day: day.Unix()}
+		if index, exists := dayToPointIndex[pointKey]; exists {
+			dashboardData.Points[index] = point
+		} else {
+			dayToPointIndex[pointKey] = len(dashboardData.Points)
+			dashboardData.Points = append(dashboardData.Points, point)
+		}
 		data[subjectID][intervalID][dataID] = dashboardData
 	}
 
@@ -208,6 +270,9 @@ func (api *API) DashboardData(c *gin.Context) {
 				total := 0
 				dataSeries := data[subjectID][intervalID][dataID]
 				points := dataSeries.Points
+				sort.Slice(points, func(i, j int) bool {
+					return points[i].X < points[j].X
+				})
 				if len(points) == 0 {
 					logger.Error().Msgf("zero data points found: subjectID:'%s', intervalID:'%s', dataID:'%s'", subjectID.Hex(), intervalID.Hex(), dataID.Hex())
 					continue
@@ -221,39 +286,151 @@ func (api *API) DashboardData(c *gin.Context) {
 		}
 	}
 
-	c.JSON(200, DashboardResult{
+	return &DashboardResult{
 		Intervals: intervals,
 		Subjects:  subjects,
 		Graphs:    getGraphs(),
 		Data:      data,
-	})
+	}, nil
+}
+
+// reads the optional 'start_date' and 'end_date' (inclusive, in the user's timezone) and 'interval' params, the range
+// defaults to the last few weeks and is widened to whole weeks or months
+func (api *API) getDashboardIntervalsFromParams(c *gin.Context, location *time.Location) ([]DashboardInterval, error) {
+	intervalType := c.DefaultQuery("interval", constants.DashboardIntervalWeekday)
+	if intervalType != constants.DashboardIntervalWeekday && intervalType != constants.DashboardIntervalWeek && intervalType != constants.DashboardIntervalMonth {
+		return nil, errors.New("'interval' must be weekday, week or month")
+	}
+	now := api.GetCurrentTime().In(location)
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location).AddDate(0, 0, 1)
+	if c.Query("end_date") != "" {
+		endDate, err := time.ParseInLocation(constants.YEAR_MONTH_DAY_FORMAT, c.Query("end_date"), location)
+		if err != nil {
+			return nil, errors.New("'end_date' is not a valid date")
+		}
+		end = endDate.AddDate(0, 0, 1)
+	}
+	start := getStartOfWeek(end.AddDate(0, 0, -1)).AddDate(0, 0, -DEFAULT_LOOKBACK_DAYS)
+	if c.Query("start_date") != "" {
+		startDate, err := time.ParseInLocation(constants.YEAR_MONTH_DAY_FORMAT, c.Query("start_date"), location)
+		if err != nil {
+			return nil, errors.New("'start_date' is not a valid date")
+		}
+		start = startDate
+	}
+	if !start.Before(end) {
+		return nil, errors.New("'start_date' must not be after 'end_date'")
+	}
+	if start.AddDate(0, 0, constants.DASHBOARD_MAX_RANGE_DAYS).Before(end) {
+		return nil, fmt.Errorf("the date range can be at most %d days", constants.DASHBOARD_MAX_RANGE_DAYS)
+	}
+	return getDashboardIntervals(start, end, intervalType, location), nil
 }
 
-func (api *API) getWeekdayIntervalsFromLookbackDays(lookbackDays int) []DashboardInterval {
-	now := api.GetCurrentTime()
-	numIntervals := lookbackDays/NUM_DAYS_IN_WEEK + 1
+// splits the days from start up to end into weeks or calendar months, weekday intervals end on the Friday of each week
+func getDashboardIntervals(start time.Time, end time.Time, intervalType string, location *time.Location) []DashboardInterval {
+	intervalStart := getStartOfWeek(start)
+	if intervalType == constants.DashboardIntervalMonth {
+		intervalStart = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, location)
+	}
 	intervals := []DashboardInterval{}
-	for index := 0; index < numIntervals; index++ {
-		numDaysBackForMonday := NUM_DAYS_IN_WEEK*(numIntervals-index-1) + int(now.Weekday()) - 1
-		monday := now.Add(-time.Hour * NUM_HOURS_IN_DAY * time.Duration(numDaysBackForMonday))
-		mondayStartOfDay := primitive.NewDateTimeFromTime(time.Date(monday.Year(), monday.Month(), monday.Day(), constants.UTC_OFFSET, 0, 0, 0, time.UTC)).Time()
-
-		numDaysBackForSaturday := numDaysBackForMonday - NUM_WEEKDAYS
-		saturday := now.Add(-time.Hour * NUM_HOURS_IN_DAY * time.Duration(numDaysBackForSaturday))
-		saturdayStartOfDay := primitive.NewDateTimeFromTime(time.Date(saturday.Year(), saturday.Month(), saturday.Day(), constants.UTC_OFFSET, 0, 0, 0, time.UTC)).Time()
-		isDefault := index+1 == numIntervals
+	for index := 0; intervalStart.Before(end); index++ {
+		nextIntervalStart := intervalStart.AddDate(0, 0, NUM_DAYS_IN_WEEK)
+		if intervalType == constants.DashboardIntervalMonth {
+			nextIntervalStart = intervalStart.AddDate(0, 1, 0)
+		}
+		intervalEnd := nextIntervalStart
+		if intervalType == constants.DashboardIntervalWeekday {
+			intervalEnd = intervalStart.AddDate(0, 0, NUM_WEEKDAYS)
+		}
 		intervals = append(intervals, DashboardInterval{
-			ID:            primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, []byte(strconv.Itoa(index))[0]},
-			DateStart:     mondayStartOfDay.Format("2006-01-02"),
-			DateEnd:       saturdayStartOfDay.Add(-24 * time.Hour).Format("2006-01-02"), // frontend would like interval to end Friday
-			DatetimeStart: mondayStartOfDay,
-			DatetimeEnd:   saturdayStartOfDay,
-			IsDefault:     isDefault,
+			ID:            getDashboardIntervalID(index),
+			DateStart:     intervalStart.Format(constants.YEAR_MONTH_DAY_FORMAT),
+			DateEnd:       intervalEnd.AddDate(0, 0, -1).Format(constants.YEAR_MONTH_DAY_FORMAT),
+			DatetimeStart: intervalStart,
+			DatetimeEnd:   intervalEnd,
 		})
+		intervalStart = nextIntervalStart
 	}
+	intervals[len(intervals)-1].IsDefault = true
 	return intervals
 }
 
+// returns midnight on the Monday of the week
+func getStartOfWeek(day time.Time) time.Time {
+	monday := day.AddDate(0, 0, -((int(day.Weekday()) + NUM_DAYS_IN_WEEK - 1) % NUM_DAYS_IN_WEEK))
+	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, day.Location())
+}
+
+// interval ids only depend on their position, so the first ten match the ids used before ranges were configurable
+func getDashboardIntervalID(index int) primitive.ObjectID {
+	intervalID := primitive.ObjectID{}
+	binary.BigEndian.PutUint32(intervalID[8:], uint32('0'+index))
+	return intervalID
+}
+
+// data points are dated at midnight where they were computed: in the team owner's timezone for the team data, and in
+// the default timezone for the industry data. They are placed on the same calendar day in the user's timezone.
+func getDashboardDataPointDay(dataPoint database.DashboardDataPoint, location *time.Location) time.Time {
+	// legacy data points are placed on their UTC day. This is also right for points dated at midnight in a UTC-8
+	// timezone, which are the only others stored at the same time of day.
+	utcDate := dataPoint.Date.Time().UTC()
+	if utcDate.Equal(time.Date(utcDate.Year(), utcDate.Month(), utcDate.Day(), constants.DASHBOARD_LEGACY_DATA_POINT_UTC_HOUR, 0, 0, 0, time.UTC)) {
+		return time.Date(utcDate.Year(), utcDate.Month(), utcDate.Day(), 0, 0, 0, 0, location)
+	}
+	dataPointLocation := location
+	if dataPoint.TeamID == primitive.NilObjectID && dataPoint.IndividualID == primitive.NilObjectID {
+		dataPointLocation = jobs.GetDashboardDefaultLocation()
+	}
+	date := dataPoint.Date.Time().In(dataPointLocation)
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+}
+
+// compares the time allocated to tasks with the time tracked on them, grouped by the day the tasks were completed
+func (api *API) getTimeTrackingDataPoints(userID primitive.ObjectID, intervals []DashboardInterval, location *time.Location) ([]database.DashboardDataPoint, error) {
+	if len(intervals) == 0 {
+		return []database.DashboardDataPoint{}, nil
+	}
+	tasks, err := database.GetTasks(api.DB, userID, &[]bson.M{
+		{"is_completed": true},
+		{"time_tracked": bson.M{"$gt": 0}},
+		{"completed_at": bson.M{"$gte": primitive.NewDateTimeFromTime(intervals[0].DatetimeStart)}},
+		{"completed_at": bson.M{"$lt": primitive.NewDateTimeFromTime(intervals[len(intervals)-1].DatetimeEnd)}},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	estimatedMinutesByDay := make(map[time.Time]int)
+	trackedMinutesByDay := make(map[time.Time]int)
+	for _, task := range *tasks {
+		// days line up with the intervals
+		completedAt := task.CompletedAt.Time().In(location)
+		day := time.Date(completedAt.Year(), completedAt.Month(), completedAt.Day(), 0, 0, 0, 0, location)
+		if task.TimeAllocation != nil {
+			estimatedMinutesByDay[day] += int(time.Duration(*task.TimeAllocation).Minutes())
+		}
+		trackedMinutesByDay[day] += int(time.Duration(*task.TimeTracked).Minutes())
+	}
+	dataPoints := []database.DashboardDataPoint{}
+	for day, trackedMinutes := range trackedMinutesByDay {
+		dataPoints = append(dataPoints,
+			database.DashboardDataPoint{
+				IndividualID: SubjectIDUser,
+				GraphType:    constants.DashboardGraphTypeEstimatedTime,
+				Value:        estimatedMinutesByDay[day],
+				Date:         primitive.NewDateTimeFromTime(day),
+			},
+			database.DashboardDataPoint{
+				IndividualID: SubjectIDUser,
+				GraphType:    constants.DashboardGraphTypeTrackedTime,
+				Value:        trackedMinutes,
+				Date:         primitive.NewDateTimeFromTime(day),
+			},
+		)
+	}
+	return dataPoints, nil
+}
+
 func getGraphs() map[primitive.ObjectID]DashboardGraph {
 	graphs := make(map[primitive.ObjectID]DashboardGraph)
 	graphs[GraphIDTeamPR] = DashboardGraph{
@@ -330,5 +507,23 @@ func getGraphs() map[primitive.ObjectID]DashboardGraph {
 			},
 		},
 	}
+	graphs[GraphIDUserTimeTracking] = DashboardGraph{
+		Name: GRAPH_NAME_TIME_TRACKING,
+		Icon: ICON_USER,
+		Lines: []DashboardLine{
+			{
+				Name:           USER_DAILY_TRACKED,
+				Color:          COLOR_BLUE,
+				AggregatedName: USER_WEEKLY_TRACKED,
+				DataID:         DataIDTimeTrackingTracked,
+			},
+			{
+				Name:           USER_DAILY_ESTIMATED,
+				Color:          COLOR_GRAY,
+				AggregatedName: USER_WEEKLY_ESTIMATED,
+				DataID:         DataIDTimeTrackingEstimated,
+			},
+		},
+	}
 	return graphs
 }
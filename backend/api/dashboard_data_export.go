@@ -0,0 +1,171 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/jobs"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DashboardExportResult struct {
+	DateStart string                   `json:"date_start"`
+	DateEnd   string                   `json:"date_end"`
+	Interval  string                   `json:"interval"`
+	Timezone  string                   `json:"timezone"`
+	Subjects  []DashboardExportSubject `json:"subjects"`
+}
+
+type DashboardExportSubject struct {
+	ID     primitive.ObjectID      `json:"id"`
+	Name   string                  `json:"name"`
+	Series []DashboardExportSeries `json:"series"`
+}
+
+type DashboardExportSeries struct {
+	Graph     string                    `json:"graph"`
+	Name      string                    `json:"name"`
+	DataID    primitive.ObjectID        `json:"data_id"`
+	Intervals []DashboardExportInterval `json:"intervals"`
+}
+
+type DashboardExportInterval struct {
+	DateStart       string                 `json:"date_start"`
+	DateEnd         string                 `json:"date_end"`
+	AggregatedValue int                    `json:"aggregated_value"`
+	Points          []DashboardExportPoint `json:"points"`
+}
+
+type DashboardExportPoint struct {
+	Date  string `json:"date"`
+	Value int    `json:"value"`
+}
+
+// DashboardDataExport exports every series of every subject on the dashboard, with the same params as DashboardData
+func (api *API) DashboardDataExport(c *gin.Context) {
+	format := c.DefaultQuery("format", constants.DashboardExportFormatJSON)
+	if format != constants.DashboardExportFormatJSON && format != constants.DashboardExportFormatCSV {
+		c.JSON(400, gin.H{"detail": "'format' must be json or csv"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	location := jobs.GetDashboardLocation(user)
+	intervals, err := api.getDashboardIntervalsFromParams(c, location)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	dashboardResult, err := api.getDashboardResult(userID, intervals, location)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	exportResult := getDashboardExportResult(dashboardResult)
+	exportResult.Interval = c.DefaultQuery("interval", constants.DashboardIntervalWeekday)
+	exportResult.Timezone = location.String()
+
+	if format == constants.DashboardExportFormatCSV {
+		body, err := dashboardExportResultToCSV(exportResult)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to write dashboard csv")
+			Handle500(c)
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=dashboard.csv")
+		c.Data(200, "text/csv; charset=utf-8", body)
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=dashboard.json")
+	c.JSON(200, exportResult)
+}
+
+// lines which show another subject's data are left out, as that data is exported with its own subject
+func getDashboardExportResult(dashboardResult *DashboardResult) DashboardExportResult {
+	intervals := dashboardResult.Intervals
+	exportResult := DashboardExportResult{
+		DateStart: intervals[0].DateStart,
+		DateEnd:   intervals[len(intervals)-1].DateEnd,
+		Subjects:  []DashboardExportSubject{},
+	}
+	graphs := dashboardResult.Graphs
+	for _, subject := range dashboardResult.Subjects {
+		exportSubject := DashboardExportSubject{
+			ID:     subject.ID,
+			Name:   subject.Name,
+			Series: []DashboardExportSeries{},
+		}
+		for _, graphID := range subject.GraphIDs {
+			for _, line := range graphs[graphID].Lines {
+				if line.SubjectID != nil {
+					continue
+				}
+				series := DashboardExportSeries{
+					Graph:     graphs[graphID].Name,
+					Name:      line.Name,
+					DataID:    line.DataID,
+					Intervals: []DashboardExportInterval{},
+				}
+				for _, interval := range intervals {
+					dashboardData, exists := dashboardResult.Data[subject.ID][interval.ID][line.DataID]
+					if !exists {
+						continue
+					}
+					exportInterval := DashboardExportInterval{
+						DateStart:       interval.DateStart,
+						DateEnd:         interval.DateEnd,
+						AggregatedValue: dashboardData.AggregatedValue,
+						Points:          []DashboardExportPoint{},
+					}
+					for _, point := range dashboardData.Points {
+						exportInterval.Points = append(exportInterval.Points, DashboardExportPoint{
+							Date:  point.Day.Format(constants.YEAR_MONTH_DAY_FORMAT),
+							Value: point.Y,
+						})
+					}
+					series.Intervals = append(series.Intervals, exportInterval)
+				}
+				exportSubject.Series = append(exportSubject.Series, series)
+			}
+		}
+		exportResult.Subjects = append(exportResult.Subjects, exportSubject)
+	}
+	return exportResult
+}
+
+func dashboardExportResultToCSV(exportResult DashboardExportResult) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	rows := [][]string{{"subject_id", "subject_name", "graph", "series", "interval_start", "interval_end", "date", "value"}}
+	for _, subject := range exportResult.Subjects {
+		for _, series := range subject.Series {
+			for _, interval := range series.Intervals {
+				for _, point := range interval.Points {
+					rows = append(rows, []string{
+						subject.ID.Hex(),
+						subject.Name,
+						series.Graph,
+						series.Name,
+						interval.DateStart,
+						interval.DateEnd,
+						point.Date,
+						strconv.Itoa(point.Value),
+					})
+				}
+			}
+		}
+	}
+	err := writer.WriteAll(rows)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
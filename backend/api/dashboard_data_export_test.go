@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetDashboardExportResult(t *testing.T) {
+	intervals := getDashboardIntervals(time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC), time.Date(2023, time.January, 9, 0, 0, 0, 0, time.UTC), constants.DashboardIntervalWeek, time.UTC)
+	teamMemberID := primitive.NewObjectID()
+	dashboardResult := &DashboardResult{
+		Intervals: intervals,
+		Subjects: []DashboardSubject{
+			{ID: SubjectIDTeam, Name: "Your Team", GraphIDs: []primitive.ObjectID{GraphIDTeamPR}},
+			{ID: teamMemberID, Name: "Jane, Doe", GraphIDs: []primitive.ObjectID{GraphIDIndividualPR}},
+		},
+		Graphs: getGraphs(),
+		Data: map[primitive.ObjectID]map[primitive.ObjectID]map[primitive.ObjectID]DashboardData{
+			SubjectIDTeam: {intervals[0].ID: {DataIDPRChartTeamAverage: {
+				AggregatedValue: 20,
+				Points: []DashboardPoint{
+					{Y: 10, Day: time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC)},
+					{Y: 30, Day: time.Date(2023, time.January, 3, 0, 0, 0, 0, time.UTC)},
+				},
+			}}},
+			teamMemberID: {intervals[0].ID: {DataIDPRChartUserAverage: {
+				AggregatedValue: 45,
+				Points:          []DashboardPoint{{Y: 45, Day: time.Date(2023, time.January, 4, 0, 0, 0, 0, time.UTC)}},
+			}}},
+		},
+	}
+
+	exportResult := getDashboardExportResult(dashboardResult)
+	assert.Equal(t, "2023-01-02", exportResult.DateStart)
+	assert.Equal(t, "2023-01-08", exportResult.DateEnd)
+	assert.Equal(t, 2, len(exportResult.Subjects))
+	// every series of the subject is exported, even without data
+	teamSeries := exportResult.Subjects[0].Series
+	assert.Equal(t, 2, len(teamSeries))
+	assert.Equal(t, TEAM_DAILY_AVERAGE, teamSeries[0].Name)
+	assert.Equal(t, []DashboardExportInterval{{
+		DateStart:       "2023-01-02",
+		DateEnd:         "2023-01-08",
+		AggregatedValue: 20,
+		Points:          []DashboardExportPoint{{Date: "2023-01-02", Value: 10}, {Date: "2023-01-03", Value: 30}},
+	}}, teamSeries[0].Intervals)
+	assert.Equal(t, INDUSTRY_DAILY_AVERAGE, teamSeries[1].Name)
+	assert.Equal(t, []DashboardExportInterval{}, teamSeries[1].Intervals)
+	// the team average shown on the team member's graph is only exported with the team
+	assert.Equal(t, 1, len(exportResult.Subjects[1].Series))
+
+	body, err := dashboardExportResultToCSV(exportResult)
+	assert.NoError(t, err)
+	assert.Equal(t, `subject_id,subject_name,graph,series,interval_start,interval_end,date,value
+000000000000000000000101,Your Team,Code review response time,Daily average (Your team),2023-01-02,2023-01-08,2023-01-02,10
+000000000000000000000101,Your Team,Code review response time,Daily average (Your team),2023-01-02,2023-01-08,2023-01-03,30
+`+teamMemberID.Hex()+`,"Jane, Doe",Code review response time,Daily average (Team member),2023-01-02,2023-01-08,2023-01-04,45
+`, string(body))
+}
+
+func TestDashboardDataExport(t *testing.T) {
+	authToken := login("test_dashboard_data_export@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	testTime := time.Date(2023, time.January, 4, 20, 0, 0, 0, time.UTC)
+	api.OverrideTime = &testTime
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	team, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+	assert.NoError(t, err)
+	_, err = database.GetDashboardDataPointCollection(api.DB).InsertOne(context.Background(), database.DashboardDataPoint{
+		TeamID:    team.ID,
+		GraphType: constants.DashboardGraphTypePRResponseTime,
+		Value:     16,
+		Date:      primitive.NewDateTimeFromTime(time.Date(2023, time.January, 3, 8, 0, 0, 0, time.UTC)),
+	})
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, "GET", "/dashboard/data/export/", nil)
+	NoBusinessAccessTest(t, "GET", "/dashboard/data/export/", api, authToken)
+	EnableBusinessAccess(t, api, userID)
+	t.Run("InvalidFormat", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/dashboard/data/export/?format=xlsx", nil, http.StatusBadRequest, api)
+	})
+	t.Run("SuccessJSON", func(t *testing.T) {
+		var exportResult DashboardExportResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "GET", "/dashboard/data/export/?start_date=2023-01-01&end_date=2023-01-31&interval=month", nil, http.StatusOK, api), &exportResult))
+		assert.Equal(t, "2023-01-01", exportResult.DateStart)
+		assert.Equal(t, "2023-01-31", exportResult.DateEnd)
+		assert.Equal(t, constants.DashboardIntervalMonth, exportResult.Interval)
+		assert.Equal(t, "America/Los_Angeles", exportResult.Timezone)
+		assert.Equal(t, []DashboardExportPoint{{Date: "2023-01-03", Value: 16}}, exportResult.Subjects[0].Series[2].Intervals[0].Points)
+	})
+	t.Run("SuccessCSV", func(t *testing.T) {
+		body := string(ServeRequest(t, authToken, "GET", "/dashboard/data/export/?format=csv", nil, http.StatusOK, api))
+		assert.True(t, strings.HasPrefix(body, "subject_id,subject_name,graph,series,interval_start,interval_end,date,value\n"))
+		assert.Contains(t, body, "Your Team,Code review response time,Daily average (Your team),2023-01-02,2023-01-06,2023-01-03,16")
+	})
+}
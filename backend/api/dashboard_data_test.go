@@ -131,6 +131,37 @@ func TestDashboardData(t *testing.T) {
 		Date:      primitive.NewDateTimeFromTime(time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)),
 	})
 	assert.NoError(t, err)
+
+	// time tracking
+	completed := true
+	timeAllocation := int64(time.Hour)
+	timeTracked := int64(90 * time.Minute)
+	taskCollection := database.GetTaskCollection(api.DB)
+	_, err = taskCollection.InsertOne(context.Background(), database.Task{
+		UserID:         userID,
+		IsCompleted:    &completed,
+		CompletedAt:    primitive.NewDateTimeFromTime(time.Date(2023, time.January, 3, 18, 0, 0, 0, time.UTC)),
+		TimeAllocation: &timeAllocation,
+		TimeTracked:    &timeTracked,
+	})
+	assert.NoError(t, err)
+	// no time tracked
+	_, err = taskCollection.InsertOne(context.Background(), database.Task{
+		UserID:         userID,
+		IsCompleted:    &completed,
+		CompletedAt:    primitive.NewDateTimeFromTime(time.Date(2023, time.January, 3, 18, 0, 0, 0, time.UTC)),
+		TimeAllocation: &timeAllocation,
+	})
+	assert.NoError(t, err)
+	// out of range
+	_, err = taskCollection.InsertOne(context.Background(), database.Task{
+		UserID:         userID,
+		IsCompleted:    &completed,
+		CompletedAt:    primitive.NewDateTimeFromTime(time.Date(2022, time.January, 3, 18, 0, 0, 0, time.UTC)),
+		TimeAllocation: &timeAllocation,
+		TimeTracked:    &timeTracked,
+	})
+	assert.NoError(t, err)
 	UnauthorizedTest(t, "GET", "/dashboard/data/", nil)
 	NoBusinessAccessTest(t, "GET", "/dashboard/data/", api, authToken)
 	EnableBusinessAccess(t, api, userID)
@@ -189,6 +220,15 @@ func TestDashboardData(t *testing.T) {
 				"000000000000000000000002"
 			],
 			"is_default": false
+		},
+		{
+			"id": "000000000000000000000105",
+			"name": "You",
+			"icon": "user",
+			"graph_ids": [
+				"000000000000000000000102"
+			],
+			"is_default": false
 		}
 	],
 	"graphs": {
@@ -271,6 +311,26 @@ func TestDashboardData(t *testing.T) {
 					"subject_id_override": "000000000000000000000101"
 				}
 			]
+		},
+		"000000000000000000000102": {
+			"name": "Estimated vs. tracked minutes on completed tasks",
+			"icon": "user",
+			"lines": [
+				{
+					"name": "Daily tracked time (You)",
+					"color": "blue",
+					"aggregated_name": "Weekly average tracked time (You)",
+					"data_id": "000000000000000000000104",
+					"subject_id_override": null
+				},
+				{
+					"name": "Daily estimated time (You)",
+					"color": "gray",
+					"aggregated_name": "Weekly average estimated time (You)",
+					"data_id": "000000000000000000000103",
+					"subject_id_override": null
+				}
+			]
 		}
 	},
 	"data": {
@@ -306,6 +366,28 @@ func TestDashboardData(t *testing.T) {
 				}
 			}
 		},
+		"000000000000000000000105": {
+			"000000000000000000000032": {
+				"000000000000000000000103": {
+					"aggregated_value": 60,
+					"points": [
+						{
+							"x": 1672732800,
+							"y": 60
+						}
+					]
+				},
+				"000000000000000000000104": {
+					"aggregated_value": 90,
+					"points": [
+						{
+							"x": 1672732800,
+							"y": 90
+						}
+					]
+				}
+			}
+		},
 		"`+teamMember1ID.Hex()+`": {
 			"000000000000000000000031": {
 				"000000000000000000000007": {
@@ -326,6 +408,81 @@ func TestDashboardData(t *testing.T) {
 	}
 }`, prettyRender(dashboardResult, t))
 	})
+	t.Run("InvalidParams", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/dashboard/data/?interval=day", nil, http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "GET", "/dashboard/data/?start_date=2023-13-01", nil, http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "GET", "/dashboard/data/?start_date=2023-01-05&end_date=2023-01-04", nil, http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "GET", "/dashboard/data/?start_date=2020-01-01&end_date=2023-01-04", nil, http.StatusBadRequest, api)
+	})
+	t.Run("SuccessMonthlyRange", func(t *testing.T) {
+		var dashboardResult DashboardResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "GET", "/dashboard/data/?start_date=2022-12-01&end_date=2023-01-04&interval=month", nil, http.StatusOK, api), &dashboardResult))
+		assert.Equal(t, 2, len(dashboardResult.Intervals))
+		assert.Equal(t, "2022-12-01", dashboardResult.Intervals[0].DateStart)
+		assert.Equal(t, "2023-01-31", dashboardResult.Intervals[1].DateEnd)
+		assert.Equal(t, 2, len(dashboardResult.Data[SubjectIDTeam][dashboardResult.Intervals[1].ID][DataIDPRChartIndustryAverage].Points))
+		// the weekend data point is included in monthly intervals, on Saturday in Pacific time
+		assert.Equal(t, 1, len(dashboardResult.Data[SubjectIDTeam][dashboardResult.Intervals[0].ID][DataIDPRChartIndustryAverage].Points))
+	})
+}
+
+func TestGetDashboardIntervals(t *testing.T) {
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	assert.NoError(t, err)
+	getDateRanges := func(intervals []DashboardInterval) [][]string {
+		dateRanges := [][]string{}
+		for _, interval := range intervals {
+			dateRanges = append(dateRanges, []string{interval.DateStart, interval.DateEnd})
+		}
+		return dateRanges
+	}
+	t.Run("Weekday", func(t *testing.T) {
+		intervals := getDashboardIntervals(time.Date(2023, time.January, 4, 0, 0, 0, 0, losAngeles), time.Date(2023, time.January, 12, 0, 0, 0, 0, losAngeles), constants.DashboardIntervalWeekday, losAngeles)
+		assert.Equal(t, [][]string{{"2023-01-02", "2023-01-06"}, {"2023-01-09", "2023-01-13"}}, getDateRanges(intervals))
+		assert.Equal(t, primitive.ObjectID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, '0'}, intervals[0].ID)
+		assert.False(t, intervals[0].IsDefault)
+		assert.True(t, intervals[1].IsDefault)
+		assert.Equal(t, time.Date(2023, time.January, 7, 0, 0, 0, 0, losAngeles), intervals[0].DatetimeEnd)
+	})
+	t.Run("WeekAcrossDaylightSaving", func(t *testing.T) {
+		intervals := getDashboardIntervals(time.Date(2023, time.March, 5, 0, 0, 0, 0, losAngeles), time.Date(2023, time.March, 14, 0, 0, 0, 0, losAngeles), constants.DashboardIntervalWeek, losAngeles)
+		assert.Equal(t, [][]string{{"2023-02-27", "2023-03-05"}, {"2023-03-06", "2023-03-12"}, {"2023-03-13", "2023-03-19"}}, getDateRanges(intervals))
+		// days still start at midnight after the clocks change
+		assert.Equal(t, time.Date(2023, time.March, 13, 0, 0, 0, 0, losAngeles), intervals[2].DatetimeStart)
+		assert.Equal(t, 23*time.Hour+6*24*time.Hour, intervals[1].DatetimeEnd.Sub(intervals[1].DatetimeStart))
+	})
+	t.Run("Month", func(t *testing.T) {
+		intervals := getDashboardIntervals(time.Date(2023, time.January, 15, 0, 0, 0, 0, time.UTC), time.Date(2023, time.April, 1, 0, 0, 0, 0, time.UTC), constants.DashboardIntervalMonth, time.UTC)
+		assert.Equal(t, [][]string{{"2023-01-01", "2023-01-31"}, {"2023-02-01", "2023-02-28"}, {"2023-03-01", "2023-03-31"}}, getDateRanges(intervals))
+	})
+	t.Run("ManyIntervals", func(t *testing.T) {
+		intervals := getDashboardIntervals(time.Date(2022, time.January, 3, 0, 0, 0, 0, time.UTC), time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC), constants.DashboardIntervalWeek, time.UTC)
+		assert.Equal(t, 52, len(intervals))
+		intervalIDs := map[primitive.ObjectID]bool{}
+		for _, interval := range intervals {
+			intervalIDs[interval.ID] = true
+		}
+		assert.Equal(t, 52, len(intervalIDs))
+	})
+}
+
+func TestGetDashboardDataPointDay(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	assert.NoError(t, err)
+	// industry data is dated at midnight Pacific time
+	industryDataPoint := database.DashboardDataPoint{Date: primitive.NewDateTimeFromTime(time.Date(2023, time.April, 15, 7, 0, 0, 0, time.UTC))}
+	assert.Equal(t, time.Date(2023, time.April, 15, 0, 0, 0, 0, tokyo), getDashboardDataPointDay(industryDataPoint, tokyo))
+	// team data is dated at midnight in the team owner's timezone
+	teamDataPoint := database.DashboardDataPoint{TeamID: primitive.NewObjectID(), Date: primitive.NewDateTimeFromTime(time.Date(2023, time.April, 14, 15, 0, 0, 0, time.UTC))}
+	assert.Equal(t, time.Date(2023, time.April, 15, 0, 0, 0, 0, tokyo), getDashboardDataPointDay(teamDataPoint, tokyo))
+	// data points stored before the switch to midnight are dated at 08:00 UTC, and stay on their UTC day west of UTC-8
+	honolulu, err := time.LoadLocation("Pacific/Honolulu")
+	assert.NoError(t, err)
+	legacyDataPoint := database.DashboardDataPoint{TeamID: primitive.NewObjectID(), Date: primitive.NewDateTimeFromTime(time.Date(2023, time.April, 15, 8, 0, 0, 0, time.UTC))}
+	assert.Equal(t, time.Date(2023, time.April, 15, 0, 0, 0, 0, honolulu), getDashboardDataPointDay(legacyDataPoint, honolulu))
+	assert.Equal(t, time.Date(2023, time.April, 15, 0, 0, 0, 0, tokyo), getDashboardDataPointDay(legacyDataPoint, tokyo))
+	legacyIndustryDataPoint := database.DashboardDataPoint{Date: primitive.NewDateTimeFromTime(time.Date(2023, time.April, 15, 8, 0, 0, 0, time.UTC))}
+	assert.Equal(t, time.Date(2023, time.April, 15, 0, 0, 0, 0, honolulu), getDashboardDataPointDay(legacyIndustryDataPoint, honolulu))
 }
 
 func prettyRender(v any, t *testing.T) string {
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/jobs"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DashboardGithubRosterParams struct {
+	AccountID    string `json:"account_id" binding:"required"`
+	Organization string `json:"organization" binding:"required"`
+	TeamSlug     string `json:"team_slug"`
+}
+
+type DashboardGithubRosterResult struct {
+	AccountID    string `json:"account_id"`
+	Organization string `json:"organization"`
+	TeamSlug     string `json:"team_slug,omitempty"`
+	Name         string `json:"name"`
+	IsSelected   bool   `json:"is_selected"`
+}
+
+// lists the GitHub organizations and teams the user's linked accounts can sync the team members from
+func (api *API) DashboardGithubRostersList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	// users who have not created a dashboard team yet have no roster selected
+	dashboardTeam, err := database.GetDashboardTeam(api.DB, userID)
+	if err == mongo.ErrNoDocuments {
+		dashboardTeam = &database.DashboardTeam{}
+	} else if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to get dashboard team")
+		c.JSON(500, gin.H{"detail": "failed to get dashboard team"})
+		return
+	}
+	tokens, err := database.GetExternalTokens(api.DB, userID, external.TASK_SERVICE_ID_GITHUB)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	githubService := external.GithubService{Config: api.ExternalConfig.Github}
+	rosterResults := []DashboardGithubRosterResult{}
+	for _, token := range *tokens {
+		rosters, err := githubService.GetRosters(api.DB, userID, token.AccountID)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to fetch github rosters")
+			continue
+		}
+		for _, roster := range rosters {
+			rosterResults = append(rosterResults, DashboardGithubRosterResult{
+				AccountID:    roster.AccountID,
+				Organization: roster.Organization,
+				TeamSlug:     roster.TeamSlug,
+				Name:         roster.Name,
+				IsSelected:   isSelectedGithubRoster(dashboardTeam, roster),
+			})
+		}
+	}
+	c.JSON(200, rosterResults)
+}
+
+// starts syncing the team members from a GitHub organization or team, and imports the current members
+func (api *API) DashboardGithubRosterModify(c *gin.Context) {
+	var params DashboardGithubRosterParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+	if err != nil || dashboardTeam == nil {
+		api.Logger.Error().Err(err).Msg("failed to get dashboard team")
+		c.JSON(500, gin.H{"detail": "failed to get dashboard team"})
+		return
+	}
+	// public organization members can be listed by anyone, so only rosters the account belongs to are allowed
+	githubService := external.GithubService{Config: api.ExternalConfig.Github}
+	rosters, err := githubService.GetRosters(api.DB, userID, params.AccountID)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "failed to fetch github organizations"})
+		return
+	}
+	isValidRoster := false
+	for _, roster := range rosters {
+		if roster.Organization == params.Organization && roster.TeamSlug == params.TeamSlug {
+			isValidRoster = true
+		}
+	}
+	if !isValidRoster {
+		c.JSON(400, gin.H{"detail": "github organization or team not found"})
+		return
+	}
+	rosterMembers, err := githubService.GetRosterMembers(api.DB, userID, params.AccountID, params.Organization, params.TeamSlug)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "failed to fetch github organization members"})
+		return
+	}
+
+	_, err = database.GetDashboardTeamCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"_id": dashboardTeam.ID},
+		bson.M{"$set": bson.M{
+			"github_roster_account_id":   params.AccountID,
+			"github_roster_organization": params.Organization,
+			"github_roster_team_slug":    params.TeamSlug,
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update dashboard team")
+		Handle500(c)
+		return
+	}
+	// members of the previous roster are flagged as removed unless they are also in the new one
+	err = jobs.SaveGithubRosterDashboardTeamMembers(api.DB, dashboardTeam.ID, rosterMembers, api.GetCurrentTime())
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to save github roster team members")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// stops syncing the team members from GitHub, the imported members are kept as if they were added by hand
+func (api *API) DashboardGithubRosterDelete(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	dashboardTeam, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+	if err != nil || dashboardTeam == nil {
+		api.Logger.Error().Err(err).Msg("failed to get dashboard team")
+		c.JSON(500, gin.H{"detail": "failed to get dashboard team"})
+		return
+	}
+	_, err = database.GetDashboardTeamCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"_id": dashboardTeam.ID},
+		bson.M{"$unset": bson.M{
+			"github_roster_account_id":   "",
+			"github_roster_organization": "",
+			"github_roster_team_slug":    "",
+			"github_roster_synced_at":    "",
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update dashboard team")
+		Handle500(c)
+		return
+	}
+	_, err = database.GetDashboardTeamMemberCollection(api.DB).UpdateMany(
+		context.Background(),
+		bson.M{"team_id": dashboardTeam.ID},
+		bson.M{"$unset": bson.M{"is_github_roster_member": ""}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update dashboard team members")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func isSelectedGithubRoster(dashboardTeam *database.DashboardTeam, roster external.GithubRoster) bool {
+	return dashboardTeam.GithubRosterAccountID == roster.AccountID &&
+		dashboardTeam.GithubRosterOrganization == roster.Organization &&
+		dashboardTeam.GithubRosterTeamSlug == roster.TeamSlug
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestDashboardGithubRoster(t *testing.T) {
+	authToken := login("test_dashboard_github_roster@generaltask.com", "")
+	memberEmail := createRandomGTEmail()
+	login(memberEmail, "Jane")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	_, err := database.GetExternalTokenCollection(api.DB).InsertOne(context.Background(), &database.ExternalAPIToken{
+		AccountID: "octocat",
+		ServiceID: external.TASK_SERVICE_ID_GITHUB,
+		UserID:    userID,
+	})
+	assert.NoError(t, err)
+
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/user/teams":
+			_, _ = w.Write([]byte(`[{"name": "Platform", "slug": "platform", "organization": {"login": "GeneralTask"}}]`))
+		case "/user/orgs":
+			_, _ = w.Write([]byte(`[{"login": "GeneralTask"}]`))
+		case "/orgs/GeneralTask/teams/platform/members":
+			_, _ = w.Write([]byte(`[{"login": "jane"}, {"login": "sam"}]`))
+		case "/users/jane":
+			_, _ = w.Write([]byte(`{"login": "jane", "email": "` + memberEmail + `"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer githubServer.Close()
+	fetchExternalAPIToken := false
+	api.ExternalConfig.Github.ConfigValues = external.GithubConfigValues{
+		FetchExternalAPIToken:      &fetchExternalAPIToken,
+		GetUserURL:                 &githubServer.URL,
+		ListUserTeamsURL:           &githubServer.URL,
+		ListOrganizationMembersURL: &githubServer.URL,
+		ListTeamMembersURL:         &githubServer.URL,
+		ListUserOrganizationsURL:   &githubServer.URL,
+	}
+
+	UnauthorizedTest(t, "GET", "/dashboard/github_rosters/", nil)
+	NoBusinessAccessTest(t, "GET", "/dashboard/github_rosters/", api, authToken)
+	EnableBusinessAccess(t, api, userID)
+	t.Run("List", func(t *testing.T) {
+		var rosters []DashboardGithubRosterResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "GET", "/dashboard/github_rosters/", nil, http.StatusOK, api), &rosters))
+		assert.Equal(t, []DashboardGithubRosterResult{
+			{AccountID: "octocat", Organization: "GeneralTask", Name: "GeneralTask"},
+			{AccountID: "octocat", Organization: "GeneralTask", TeamSlug: "platform", Name: "GeneralTask/Platform"},
+		}, rosters)
+		// listing the rosters does not create a dashboard team
+		_, err := database.GetDashboardTeam(api.DB, userID)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+	})
+	t.Run("Modify", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/dashboard/github_roster/", bytes.NewBuffer([]byte(`{"account_id":"octocat","organization":"other-org"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "PATCH", "/dashboard/github_roster/", bytes.NewBuffer([]byte(`{"account_id":"octocat","organization":"GeneralTask","team_slug":"platform"}`)), http.StatusOK, api)
+
+		var teamMembers []DashboardTeamMemberResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "GET", "/dashboard/team_members/", nil, http.StatusOK, api), &teamMembers))
+		assert.Equal(t, 2, len(teamMembers))
+		assert.Equal(t, "Jane", teamMembers[0].Name)
+		assert.True(t, teamMembers[0].IsGithubRosterMember)
+		assert.True(t, teamMembers[0].IsGeneralTaskUser)
+		assert.Equal(t, "sam", teamMembers[1].Name)
+		assert.False(t, teamMembers[1].IsGeneralTaskUser)
+		// roster members leave the team by leaving the GitHub team
+		ServeRequest(t, authToken, "DELETE", "/dashboard/team_members/"+teamMembers[1].ID+"/", nil, http.StatusBadRequest, api)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", "/dashboard/github_roster/", nil, http.StatusOK, api)
+		var teamMembers []DashboardTeamMemberResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "GET", "/dashboard/team_members/", nil, http.StatusOK, api), &teamMembers))
+		assert.Equal(t, 2, len(teamMembers))
+		assert.False(t, teamMembers[1].IsGithubRosterMember)
+		ServeRequest(t, authToken, "DELETE", "/dashboard/team_members/"+teamMembers[1].ID+"/", nil, http.StatusNoContent, api)
+	})
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"time"
 
 	"github.com/GeneralTask/task-manager/backend/database"
 	"github.com/gin-gonic/gin"
@@ -18,6 +19,12 @@ type DashboardTeamMemberResult struct {
 	Name     string `json:"name,omitempty"`
 	Email    string `json:"email,omitempty"`
 	GithubID string `json:"github_id,omitempty"`
+	// members of the user's workspaces are added to the team automatically
+	IsWorkspaceMember bool `json:"is_workspace_member,omitempty"`
+	// members imported from the team's GitHub roster, flagged with the time they left it
+	IsGithubRosterMember bool   `json:"is_github_roster_member,omitempty"`
+	RemovedFromRosterAt  string `json:"removed_from_roster_at,omitempty"`
+	IsGeneralTaskUser    bool   `json:"is_general_task_user,omitempty"`
 }
 
 func (api *API) DashboardTeamMemberCreate(c *gin.Context) {
@@ -68,6 +75,19 @@ func (api *API) DashboardTeamMemberDelete(c *gin.Context) {
 		return
 	}
 	teamMemberCollection := database.GetDashboardTeamMemberCollection(api.DB)
+	var teamMember database.DashboardTeamMember
+	err = teamMemberCollection.FindOne(context.Background(), database.DashboardTeamMember{
+		ID:     teamMemberID,
+		TeamID: dashboardTeam.ID,
+	}).Decode(&teamMember)
+	if err == nil && teamMember.UserID != primitive.NilObjectID {
+		c.JSON(400, gin.H{"detail": "workspace members are removed from the team by leaving the workspace"})
+		return
+	}
+	if err == nil && teamMember.IsGithubRosterMember && teamMember.RemovedFromRosterAt == 0 {
+		c.JSON(400, gin.H{"detail": "github roster members are removed from the team by leaving the github organization or team"})
+		return
+	}
 	deletedResult, err := teamMemberCollection.DeleteOne(context.Background(), database.DashboardTeamMember{
 		ID:     teamMemberID,
 		TeamID: dashboardTeam.ID,
@@ -96,7 +116,6 @@ func (api *API) DashboardTeamMembersList(c *gin.Context) {
 		Handle500(c)
 		return
 	}
-
 	dashboardTeamMembers, err := database.GetDashboardTeamMembers(api.DB, dashboardTeam.ID)
 	if err != nil || dashboardTeamMembers == nil {
 		Handle500(c)
@@ -104,12 +123,19 @@ func (api *API) DashboardTeamMembersList(c *gin.Context) {
 	}
 	var teamMemberResults []DashboardTeamMemberResult
 	for _, dashboardTeamMember := range *dashboardTeamMembers {
-		teamMemberResults = append(teamMemberResults, DashboardTeamMemberResult{
-			ID:       dashboardTeamMember.ID.Hex(),
-			Name:     dashboardTeamMember.Name,
-			Email:    dashboardTeamMember.Email,
-			GithubID: dashboardTeamMember.GithubID,
-		})
+		teamMemberResult := DashboardTeamMemberResult{
+			ID:                   dashboardTeamMember.ID.Hex(),
+			Name:                 dashboardTeamMember.Name,
+			Email:                dashboardTeamMember.Email,
+			GithubID:             dashboardTeamMember.GithubID,
+			IsWorkspaceMember:    dashboardTeamMember.UserID != primitive.NilObjectID,
+			IsGithubRosterMember: dashboardTeamMember.IsGithubRosterMember,
+			IsGeneralTaskUser:    dashboardTeamMember.UserID != primitive.NilObjectID || dashboardTeamMember.MatchedUserID != primitive.NilObjectID,
+		}
+		if dashboardTeamMember.RemovedFromRosterAt != 0 {
+			teamMemberResult.RemovedFromRosterAt = dashboardTeamMember.RemovedFromRosterAt.Time().UTC().Format(time.RFC3339)
+		}
+		teamMemberResults = append(teamMemberResults, teamMemberResult)
 	}
 	c.JSON(200, teamMemberResults)
 }
@@ -59,15 +59,24 @@ func (api *API) EventCreate(c *gin.Context) {
 		linkedSourceID = linkedPR.SourceID
 	}
 
+	insertedEvent, err := api.createCalendarEvent(userID, sourceID, taskSourceResult, eventCreateObject, linkedSourceID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(201, gin.H{"id": insertedEvent.ID.Hex()})
+}
+
+// creates the event in the external source and then stores it in the database
+func (api *API) createCalendarEvent(userID primitive.ObjectID, sourceID string, taskSourceResult *external.TaskSourceResult, eventCreateObject external.EventCreateObject, linkedSourceID string) (*database.CalendarEvent, error) {
 	// generate ID for event so we can use this when inserting into database
 	externalEventID := primitive.NewObjectID()
 	eventCreateObject.ID = externalEventID
 
-	err = taskSourceResult.Source.CreateNewEvent(api.DB, userID, eventCreateObject.AccountID, eventCreateObject)
+	err := taskSourceResult.Source.CreateNewEvent(api.DB, userID, eventCreateObject.AccountID, eventCreateObject)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to update external task source")
-		Handle500(c)
-		return
+		return nil, err
 	}
 
 	event := database.CalendarEvent{
@@ -94,11 +103,9 @@ func (api *API) EventCreate(c *gin.Context) {
 		event,
 		nil,
 	)
-
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to create calendar event in database")
-		Handle500(c)
-		return
+		return nil, err
 	}
-	c.JSON(201, gin.H{"id": insertedEvent.ID.Hex()})
+	return insertedEvent, nil
 }
@@ -26,6 +26,12 @@ func (api *API) EventDelete(c *gin.Context) {
 		c.JSON(404, gin.H{"detail": "event not found", "eventID": eventID})
 		return
 	}
+	recurrenceScope := c.Query("recurrence_scope")
+	err = validateRecurrenceScope(recurrenceScope, event)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
 
 	taskSourceResult, err := api.ExternalConfig.GetSourceResult(event.SourceID)
 	if err != nil {
@@ -34,7 +40,7 @@ func (api *API) EventDelete(c *gin.Context) {
 		return
 	}
 
-	err = taskSourceResult.Source.DeleteEvent(api.DB, userID, event.SourceAccountID, event.IDExternal, event.CalendarID)
+	err = taskSourceResult.Source.DeleteEvent(api.DB, userID, event.SourceAccountID, event.IDExternal, event.CalendarID, recurrenceScope)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to update external task source")
 		Handle500(c)
@@ -42,6 +48,19 @@ func (api *API) EventDelete(c *gin.Context) {
 	}
 
 	eventCollection := database.GetCalendarEventCollection(api.DB)
+	if isSeriesRecurrenceScope(recurrenceScope) {
+		_, err = eventCollection.DeleteMany(
+			context.Background(),
+			bson.M{"$and": append(getRecurrenceScopeFilters(recurrenceScope, event), bson.M{"user_id": userID})},
+		)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to update internal DB")
+			Handle500(c)
+			return
+		}
+		c.JSON(200, gin.H{})
+		return
+	}
 	res, err := eventCollection.DeleteOne(
 		context.Background(),
 		bson.M{"$and": []bson.M{
@@ -99,3 +99,56 @@ func TestEventDelete(t *testing.T) {
 		assert.Equal(t, int64(0), count)
 	})
 }
+
+func TestEventDeleteRecurrenceScope(t *testing.T) {
+	authToken := login("test_event_delete_recurrence_scope@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	calendarDeleteServer := testutils.GetMockAPIServer(t, 200, `{"id":"instance","recurringEventId":"series","originalStartTime":{"dateTime":"2022-01-08T10:00:00Z"},"start":{"dateTime":"2022-01-01T10:00:00Z"},"recurrence":["RRULE:FREQ=WEEKLY"]}`)
+	defer calendarDeleteServer.Close()
+	api.ExternalConfig.GoogleOverrideURLs.CalendarDeleteURL = &calendarDeleteServer.URL
+
+	eventCollection := database.GetCalendarEventCollection(api.DB)
+	instanceIDs := []primitive.ObjectID{}
+	for _, start := range []primitive.DateTime{primitive.DateTime(1641031200000), primitive.DateTime(1641636000000), primitive.DateTime(1642240800000)} {
+		insertResult, err := eventCollection.InsertOne(context.Background(), database.CalendarEvent{
+			UserID:           userID,
+			SourceAccountID:  "account_id",
+			IDExternal:       "instance_" + start.Time().Format("20060102"),
+			SourceID:         external.TASK_SOURCE_ID_GCAL,
+			RecurringEventID: "series",
+			DatetimeStart:    start,
+		})
+		assert.NoError(t, err)
+		instanceIDs = append(instanceIDs, insertResult.InsertedID.(primitive.ObjectID))
+	}
+	singleEvent, err := eventCollection.InsertOne(context.Background(), database.CalendarEvent{
+		UserID:          userID,
+		SourceAccountID: "account_id",
+		IDExternal:      "single_event",
+		SourceID:        external.TASK_SOURCE_ID_GCAL,
+	})
+	assert.NoError(t, err)
+
+	t.Run("InvalidScope", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "DELETE", "/events/delete/"+instanceIDs[1].Hex()+"/?recurrence_scope=some", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid recurrence scope, must be one of 'this', 'following' or 'all'"}`, string(body))
+	})
+	t.Run("NotRecurring", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "DELETE", "/events/delete/"+singleEvent.InsertedID.(primitive.ObjectID).Hex()+"/?recurrence_scope=all", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"event is not part of a recurring event"}`, string(body))
+	})
+	t.Run("SuccessFollowing", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", "/events/delete/"+instanceIDs[1].Hex()+"/?recurrence_scope=following", nil, http.StatusOK, api)
+		count, err := eventCollection.CountDocuments(context.Background(), bson.M{"_id": bson.M{"$in": instanceIDs}})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+	t.Run("SuccessAll", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", "/events/delete/"+instanceIDs[0].Hex()+"/?recurrence_scope=all", nil, http.StatusOK, api)
+		count, err := eventCollection.CountDocuments(context.Background(), bson.M{"recurring_event_id": "series", "user_id": userID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -42,6 +42,18 @@ type EventResult struct {
 	Logo                string               `json:"logo"`
 	ColorBackground     string               `json:"color_background,omitempty"`
 	ColorForeground     string               `json:"color_foreground,omitempty"`
+	// empty when the user is not an attendee of the event
+	RSVPStatus string                `json:"rsvp_status,omitempty"`
+	Attendees  []EventAttendeeResult `json:"attendees,omitempty"`
+}
+
+type EventAttendeeResult struct {
+	Email          string `json:"email"`
+	Name           string `json:"name"`
+	ResponseStatus string `json:"response_status"`
+	Comment        string `json:"comment,omitempty"`
+	IsOrganizer    bool   `json:"is_organizer"`
+	IsSelf         bool   `json:"is_self"`
 }
 
 func (api *API) EventsList(c *gin.Context) {
@@ -108,6 +120,7 @@ func (api *API) EventsList(c *gin.Context) {
 			log.Error().Err(calendarResult.Error).Send()
 			continue
 		}
+		api.createUpcomingSeriesInstanceNotes(userID, calendarResult.CalendarEvents)
 		calendarEventsForChannel := []EventResult{}
 		for _, event := range calendarResult.CalendarEvents {
 			result, err := api.calendarEventToResult(event, userID)
@@ -172,7 +185,18 @@ func (api *API) calendarEventToResult(event *database.CalendarEvent, userID prim
 			api.Logger.Error().Err(err).Msg("linked task source ID is empty")
 		}
 	}
-	linkedNoteID := api.getLinkedNoteID(event.ID, userID)
+	linkedNoteID := api.getEventNoteID(event, userID)
+	var attendees []EventAttendeeResult
+	for _, attendee := range event.Attendees {
+		attendees = append(attendees, EventAttendeeResult{
+			Email:          attendee.Email,
+			Name:           attendee.DisplayName,
+			ResponseStatus: attendee.ResponseStatus,
+			Comment:        attendee.Comment,
+			IsOrganizer:    attendee.IsOrganizer,
+			IsSelf:         attendee.IsSelf,
+		})
+	}
 	return EventResult{
 		ID:            event.ID,
 		AccountID:     event.SourceAccountID,
@@ -197,6 +221,8 @@ func (api *API) calendarEventToResult(event *database.CalendarEvent, userID prim
 		LinkedNoteID:        linkedNoteID,
 		ColorBackground:     event.ColorBackground,
 		ColorForeground:     event.ColorForeground,
+		RSVPStatus:          event.SelfResponseStatus,
+		Attendees:           attendees,
 	}, nil
 }
 
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"errors"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
 	"github.com/GeneralTask/task-manager/backend/database"
 	"github.com/GeneralTask/task-manager/backend/external"
 	"github.com/gin-gonic/gin"
@@ -25,7 +28,7 @@ func (api *API) EventModify(c *gin.Context) {
 	}
 
 	// check that modifyParams isn't empty
-	emptyObj := external.EventModifyObject{AccountID: modifyParams.AccountID}
+	emptyObj := external.EventModifyObject{AccountID: modifyParams.AccountID, RecurrenceScope: modifyParams.RecurrenceScope}
 	if modifyParams == emptyObj {
 		c.JSON(400, gin.H{"detail": "parameter missing"})
 		return
@@ -38,6 +41,11 @@ func (api *API) EventModify(c *gin.Context) {
 		c.JSON(404, gin.H{"detail": "event not found", "eventID": eventID})
 		return
 	}
+	err = validateRecurrenceScope(modifyParams.RecurrenceScope, event)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
 
 	eventSourceResult, err := api.ExternalConfig.GetSourceResult(event.SourceID)
 	if err != nil {
@@ -53,7 +61,11 @@ func (api *API) EventModify(c *gin.Context) {
 		return
 	}
 
-	err = api.updateEventInDB(modifyParams, event, userID)
+	if isSeriesRecurrenceScope(modifyParams.RecurrenceScope) {
+		err = api.updateRecurringEventInDB(modifyParams, event, userID)
+	} else {
+		err = api.updateEventInDB(modifyParams, event, userID)
+	}
 	if err != nil {
 		Handle500(c)
 		return
@@ -84,3 +96,59 @@ func (api *API) updateEventInDB(modifyParams external.EventModifyObject, event *
 	}
 	return nil
 }
+
+func validateRecurrenceScope(recurrenceScope string, event *database.CalendarEvent) error {
+	if recurrenceScope != "" &&
+		recurrenceScope != constants.RecurrenceScopeThis &&
+		recurrenceScope != constants.RecurrenceScopeFollowing &&
+		recurrenceScope != constants.RecurrenceScopeAll {
+		return errors.New("invalid recurrence scope, must be one of 'this', 'following' or 'all'")
+	}
+	if isSeriesRecurrenceScope(recurrenceScope) && event.RecurringEventID == "" {
+		return errors.New("event is not part of a recurring event")
+	}
+	return nil
+}
+
+func isSeriesRecurrenceScope(recurrenceScope string) bool {
+	return recurrenceScope == constants.RecurrenceScopeFollowing || recurrenceScope == constants.RecurrenceScopeAll
+}
+
+// returns the filters for the stored instances of the event which a change with the recurrence scope applies to
+func getRecurrenceScopeFilters(recurrenceScope string, event *database.CalendarEvent) []bson.M {
+	filters := []bson.M{
+		{"source_account_id": event.SourceAccountID},
+		{"recurring_event_id": event.RecurringEventID},
+	}
+	if recurrenceScope == constants.RecurrenceScopeFollowing {
+		filters = append(filters, bson.M{"datetime_start": bson.M{"$gte": event.DatetimeStart}})
+	}
+	return filters
+}
+
+// the instances are moved by the same amount as the event, the next sync replaces them with the instances from the source
+func (api *API) updateRecurringEventInDB(modifyParams external.EventModifyObject, event *database.CalendarEvent, userID primitive.ObjectID) error {
+	instances, err := database.GetCalendarEvents(api.DB, userID, &[]bson.M{
+		{"$and": getRecurrenceScopeFilters(modifyParams.RecurrenceScope, event)},
+	})
+	if err != nil {
+		return err
+	}
+	for _, instance := range *instances {
+		instance := instance
+		instanceParams := modifyParams
+		if modifyParams.DatetimeStart != nil {
+			datetimeStart := instance.DatetimeStart.Time().Add(modifyParams.DatetimeStart.Sub(event.DatetimeStart.Time()))
+			instanceParams.DatetimeStart = &datetimeStart
+		}
+		if modifyParams.DatetimeEnd != nil {
+			datetimeEnd := instance.DatetimeEnd.Time().Add(modifyParams.DatetimeEnd.Sub(event.DatetimeEnd.Time()))
+			instanceParams.DatetimeEnd = &datetimeEnd
+		}
+		err = api.updateEventInDB(instanceParams, &instance, userID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/GeneralTask/task-manager/backend/external"
 	"github.com/GeneralTask/task-manager/backend/testutils"
@@ -188,3 +189,61 @@ func TestEventModify(t *testing.T) {
 		ServeRequest(t, otherUserAuthToken, "PATCH", validUrl, body, http.StatusNotFound, nil)
 	})
 }
+
+func TestEventModifyRecurrenceScope(t *testing.T) {
+	authToken := login("test_event_modify_recurrence_scope@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	calendarModifyServer := testutils.GetMockAPIServer(t, 200, "{}")
+	defer calendarModifyServer.Close()
+	api.ExternalConfig.GoogleOverrideURLs.CalendarModifyURL = &calendarModifyServer.URL
+
+	eventCollection := database.GetCalendarEventCollection(api.DB)
+	firstStart := time.Date(2022, time.January, 3, 10, 0, 0, 0, time.UTC)
+	instanceIDs := []primitive.ObjectID{}
+	for week := 0; week < 3; week++ {
+		start := firstStart.AddDate(0, 0, 7*week)
+		insertResult, err := eventCollection.InsertOne(context.Background(), database.CalendarEvent{
+			UserID:           userID,
+			SourceAccountID:  "account_id",
+			CalendarID:       "calendar_id",
+			IDExternal:       fmt.Sprintf("instance_%d", week),
+			SourceID:         external.TASK_SOURCE_ID_GCAL,
+			Title:            "weekly sync",
+			RecurringEventID: "series",
+			DatetimeStart:    primitive.NewDateTimeFromTime(start),
+			DatetimeEnd:      primitive.NewDateTimeFromTime(start.Add(time.Hour)),
+		})
+		assert.NoError(t, err)
+		instanceIDs = append(instanceIDs, insertResult.InsertedID.(primitive.ObjectID))
+	}
+	getInstance := func(index int) *database.CalendarEvent {
+		event, err := database.GetCalendarEvent(api.DB, instanceIDs[index], userID)
+		assert.NoError(t, err)
+		return event
+	}
+
+	t.Run("ScopeOnly", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", "/events/modify/"+instanceIDs[0].Hex()+"/", bytes.NewBuffer([]byte(`{"account_id":"account_id","recurrence_scope":"all"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"parameter missing"}`, string(body))
+	})
+	t.Run("InvalidScope", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", "/events/modify/"+instanceIDs[0].Hex()+"/", bytes.NewBuffer([]byte(`{"account_id":"account_id","summary":"sync","recurrence_scope":"some"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid recurrence scope, must be one of 'this', 'following' or 'all'"}`, string(body))
+	})
+	t.Run("SuccessFollowing", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/events/modify/"+instanceIDs[1].Hex()+"/", bytes.NewBuffer([]byte(`{"account_id":"account_id","summary":"new sync","recurrence_scope":"following"}`)), http.StatusOK, api)
+		assert.Equal(t, "weekly sync", getInstance(0).Title)
+		assert.Equal(t, "new sync", getInstance(1).Title)
+		assert.Equal(t, "new sync", getInstance(2).Title)
+	})
+	t.Run("SuccessAllMovesInstances", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/events/modify/"+instanceIDs[1].Hex()+"/", bytes.NewBuffer([]byte(`{"account_id":"account_id","datetime_start":"2022-01-10T11:00:00Z","datetime_end":"2022-01-10T12:00:00Z","recurrence_scope":"all"}`)), http.StatusOK, api)
+		for index := range instanceIDs {
+			start := firstStart.AddDate(0, 0, 7*index).Add(time.Hour)
+			assert.True(t, start.Equal(getInstance(index).DatetimeStart.Time()))
+			assert.True(t, start.Add(time.Hour).Equal(getInstance(index).DatetimeEnd.Time()))
+		}
+	})
+}
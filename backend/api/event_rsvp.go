@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EventRSVPParams struct {
+	ResponseStatus string  `json:"response_status" binding:"required"`
+	Comment        *string `json:"comment"`
+}
+
+func (api *API) EventRSVP(c *gin.Context) {
+	eventID, err := primitive.ObjectIDFromHex(c.Param("event_id"))
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "event ID missing or malformed"})
+		return
+	}
+	var params EventRSVPParams
+	err = c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "parameter missing or malformed"})
+		return
+	}
+	err = validateEventRSVPParams(params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	userID := getUserIDFromContext(c)
+
+	event, err := database.GetCalendarEvent(api.DB, eventID, userID)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "event not found", "eventID": eventID})
+		return
+	}
+	if !isEventAttendee(event) {
+		c.JSON(400, gin.H{"detail": "user is not an attendee of the event"})
+		return
+	}
+
+	eventSourceResult, err := api.ExternalConfig.GetSourceResult(event.SourceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load external task source")
+		Handle500(c)
+		return
+	}
+	err = eventSourceResult.Source.ModifyEvent(api.DB, userID, event.SourceAccountID, event.IDExternal, &external.EventModifyObject{
+		AccountID:       event.SourceAccountID,
+		CalendarID:      event.CalendarID,
+		ResponseStatus:  &params.ResponseStatus,
+		ResponseComment: params.Comment,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update external task source")
+		Handle500(c)
+		return
+	}
+
+	setEventSelfResponse(event, params.ResponseStatus, params.Comment)
+	_, err = database.GetCalendarEventCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": eventID},
+			{"user_id": userID},
+		}},
+		bson.M{"$set": bson.M{
+			"self_response_status": event.SelfResponseStatus,
+			"attendees":            event.Attendees,
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update event response")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func validateEventRSVPParams(params EventRSVPParams) error {
+	if params.ResponseStatus != constants.RSVPStatusAccepted &&
+		params.ResponseStatus != constants.RSVPStatusDeclined &&
+		params.ResponseStatus != constants.RSVPStatusTentative {
+		return errors.New("invalid response status, must be one of 'accepted', 'declined' or 'tentative'")
+	}
+	if params.Comment != nil && len(*params.Comment) > constants.MAX_RSVP_COMMENT_LENGTH {
+		return errors.New("comment is too long")
+	}
+	return nil
+}
+
+func isEventAttendee(event *database.CalendarEvent) bool {
+	for _, attendee := range event.Attendees {
+		if attendee.IsSelf {
+			return true
+		}
+	}
+	return false
+}
+
+func setEventSelfResponse(event *database.CalendarEvent, responseStatus string, comment *string) {
+	event.SelfResponseStatus = responseStatus
+	for index, attendee := range event.Attendees {
+		if !attendee.IsSelf {
+			continue
+		}
+		event.Attendees[index].ResponseStatus = responseStatus
+		if comment != nil {
+			event.Attendees[index].Comment = *comment
+		}
+	}
+}
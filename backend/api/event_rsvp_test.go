@@ -0,0 +1,76 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateEventRSVPParams(t *testing.T) {
+	longComment := strings.Repeat("a", constants.MAX_RSVP_COMMENT_LENGTH+1)
+	assert.NoError(t, validateEventRSVPParams(EventRSVPParams{ResponseStatus: constants.RSVPStatusTentative}))
+	assert.EqualError(t, validateEventRSVPParams(EventRSVPParams{ResponseStatus: constants.RSVPStatusNeedsAction}), "invalid response status, must be one of 'accepted', 'declined' or 'tentative'")
+	assert.EqualError(t, validateEventRSVPParams(EventRSVPParams{ResponseStatus: constants.RSVPStatusAccepted, Comment: &longComment}), "comment is too long")
+}
+
+func TestEventRSVP(t *testing.T) {
+	authToken := login("test_event_rsvp@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	calendarModifyServer := testutils.GetMockAPIServer(t, 200, `{"attendees":[{"email":"test_event_rsvp@generaltask.com","self":true}]}`)
+	defer calendarModifyServer.Close()
+	api.ExternalConfig.GoogleOverrideURLs.CalendarModifyURL = &calendarModifyServer.URL
+
+	eventCollection := database.GetCalendarEventCollection(api.DB)
+	insertResult, err := eventCollection.InsertOne(context.Background(), database.CalendarEvent{
+		UserID:             userID,
+		SourceID:           external.TASK_SOURCE_ID_GCAL,
+		SourceAccountID:    "test_event_rsvp@generaltask.com",
+		IDExternal:         "rsvp_event",
+		SelfResponseStatus: constants.RSVPStatusNeedsAction,
+		Attendees: []database.CalendarEventAttendee{
+			{Email: "organizer@generaltask.com", ResponseStatus: constants.RSVPStatusAccepted, IsOrganizer: true},
+			{Email: "test_event_rsvp@generaltask.com", ResponseStatus: constants.RSVPStatusNeedsAction, IsSelf: true},
+		},
+	})
+	assert.NoError(t, err)
+	eventID := insertResult.InsertedID.(primitive.ObjectID)
+	organizedEvent, err := eventCollection.InsertOne(context.Background(), database.CalendarEvent{
+		UserID:   userID,
+		SourceID: external.TASK_SOURCE_ID_GCAL,
+	})
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, "POST", "/events/rsvp/"+eventID.Hex()+"/", nil)
+	t.Run("InvalidStatus", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/events/rsvp/"+eventID.Hex()+"/", bytes.NewBuffer([]byte(`{"response_status":"maybe"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid response status, must be one of 'accepted', 'declined' or 'tentative'"}`, string(body))
+	})
+	t.Run("EventNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/events/rsvp/"+primitive.NewObjectID().Hex()+"/", bytes.NewBuffer([]byte(`{"response_status":"accepted"}`)), http.StatusNotFound, api)
+	})
+	t.Run("NotAttendee", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/events/rsvp/"+organizedEvent.InsertedID.(primitive.ObjectID).Hex()+"/", bytes.NewBuffer([]byte(`{"response_status":"accepted"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"user is not an attendee of the event"}`, string(body))
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/events/rsvp/"+eventID.Hex()+"/", bytes.NewBuffer([]byte(`{"response_status":"declined","comment":"on vacation"}`)), http.StatusOK, api)
+
+		event, err := database.GetCalendarEvent(api.DB, eventID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.RSVPStatusDeclined, event.SelfResponseStatus)
+		assert.Equal(t, constants.RSVPStatusAccepted, event.Attendees[0].ResponseStatus)
+		assert.Equal(t, constants.RSVPStatusDeclined, event.Attendees[1].ResponseStatus)
+		assert.Equal(t, "on vacation", event.Attendees[1].Comment)
+	})
+}
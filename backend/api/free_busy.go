@@ -0,0 +1,101 @@
+package api
+
+import (
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FreeBusyParams struct {
+	DatetimeStart *time.Time `form:"datetime_start" binding:"required"`
+	DatetimeEnd   *time.Time `form:"datetime_end" binding:"required"`
+}
+
+type FreeBusyResult struct {
+	Busy []FreeBusySlot `json:"busy"`
+	// working hours which are not busy or blocked off for no meetings
+	Free []FreeBusySlot `json:"free"`
+}
+
+type FreeBusySlot struct {
+	DatetimeStart time.Time `json:"datetime_start"`
+	DatetimeEnd   time.Time `json:"datetime_end"`
+}
+
+func (api *API) FreeBusy(c *gin.Context) {
+	var params FreeBusyParams
+	err := c.BindQuery(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter."})
+		return
+	}
+	if !params.DatetimeEnd.After(*params.DatetimeStart) {
+		c.JSON(400, gin.H{"detail": "'datetime_end' must be after 'datetime_start'"})
+		return
+	}
+	if params.DatetimeEnd.Sub(*params.DatetimeStart) > constants.FREE_BUSY_MAX_DAYS*24*time.Hour {
+		c.JSON(400, gin.H{"detail": "time range is too long"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	location, err := api.getUserLocation(c, user)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	busySlots, err := api.getBusyTimeSlots(userID, *params.DatetimeStart, *params.DatetimeEnd)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch calendar events")
+		Handle500(c)
+		return
+	}
+	freeSlots := getMeetingFreeTimeSlots(utils.GetUserAvailability(user), location, busySlots, *params.DatetimeStart, *params.DatetimeEnd)
+	c.JSON(200, FreeBusyResult{
+		Busy: getFreeBusySlotResults(busySlots),
+		Free: getFreeBusySlotResults(freeSlots),
+	})
+}
+
+// merges the events from every linked calendar account, as they are all stored for the user
+func (api *API) getBusyTimeSlots(userID primitive.ObjectID, start time.Time, end time.Time) ([]utils.TimeSlot, error) {
+	events, err := database.GetCalendarEvents(api.DB, userID, &[]bson.M{
+		{"datetime_end": bson.M{"$gt": primitive.NewDateTimeFromTime(start)}},
+		{"datetime_start": bson.M{"$lt": primitive.NewDateTimeFromTime(end)}},
+		{"self_response_status": bson.M{"$ne": constants.RSVPStatusDeclined}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	busySlots := []utils.TimeSlot{}
+	for _, event := range *events {
+		busySlots = append(busySlots, utils.TimeSlot{Start: event.DatetimeStart.Time(), End: event.DatetimeEnd.Time()})
+	}
+	return utils.MergeTimeSlots(busySlots), nil
+}
+
+// unlike task scheduling, no meeting blocks are not free for meetings
+func getMeetingFreeTimeSlots(availability database.UserAvailability, location *time.Location, busySlots []utils.TimeSlot, start time.Time, end time.Time) []utils.TimeSlot {
+	freeSlots := utils.GetWorkingHourSlots(availability, location, start, end)
+	freeSlots = utils.SubtractTimeSlots(freeSlots, utils.GetAvailabilityBlockSlots(availability, constants.AvailabilityBlockTypeNoMeetings, location, start, end))
+	return utils.SubtractTimeSlots(freeSlots, busySlots)
+}
+
+func getFreeBusySlotResults(slots []utils.TimeSlot) []FreeBusySlot {
+	results := []FreeBusySlot{}
+	for _, slot := range slots {
+		results = append(results, FreeBusySlot{DatetimeStart: slot.Start, DatetimeEnd: slot.End})
+	}
+	return results
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFreeBusy(t *testing.T) {
+	authToken := login("test_free_busy@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	// events from two different accounts which overlap
+	for _, event := range []database.CalendarEvent{
+		{
+			UserID:          userID,
+			SourceAccountID: "work@generaltask.com",
+			DatetimeStart:   primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 10, 0, 0, 0, time.UTC)),
+			DatetimeEnd:     primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 11, 0, 0, 0, time.UTC)),
+		},
+		{
+			UserID:          userID,
+			SourceAccountID: "personal@gmail.com",
+			DatetimeStart:   primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 10, 30, 0, 0, time.UTC)),
+			DatetimeEnd:     primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 12, 0, 0, 0, time.UTC)),
+		},
+	} {
+		_, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), event)
+		assert.NoError(t, err)
+	}
+
+	UnauthorizedTest(t, "GET", "/free_busy/", nil)
+	t.Run("MissingParams", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/free_busy/", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid or missing parameter."}`, string(body))
+	})
+	t.Run("RangeTooLong", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/free_busy/?datetime_start=2022-11-01T00:00:00Z&datetime_end=2023-01-01T00:00:00Z", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"time range is too long"}`, string(body))
+	})
+	t.Run("Success", func(t *testing.T) {
+		router := GetRouter(api)
+		request, _ := http.NewRequest("GET", "/free_busy/?datetime_start=2022-11-14T00:00:00Z&datetime_end=2022-11-15T00:00:00Z", nil)
+		request.Header.Add("Authorization", "Bearer "+authToken)
+		request.Header.Add("Timezone-Offset", "0")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		body, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+
+		var result FreeBusyResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result.Busy))
+		assert.Equal(t, time.Date(2022, time.November, 14, 10, 0, 0, 0, time.UTC), result.Busy[0].DatetimeStart.UTC())
+		assert.Equal(t, time.Date(2022, time.November, 14, 12, 0, 0, 0, time.UTC), result.Busy[0].DatetimeEnd.UTC())
+		assert.Equal(t, 2, len(result.Free))
+		assert.Equal(t, time.Date(2022, time.November, 14, 9, 0, 0, 0, time.UTC), result.Free[0].DatetimeStart.UTC())
+		assert.Equal(t, time.Date(2022, time.November, 14, 12, 0, 0, 0, time.UTC), result.Free[1].DatetimeStart.UTC())
+	})
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
@@ -10,7 +11,9 @@ import (
 
 	"github.com/GeneralTask/task-manager/backend/database"
 	"github.com/GeneralTask/task-manager/backend/logging"
+	"github.com/GeneralTask/task-manager/backend/utils"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -76,22 +79,73 @@ func GetTimezoneOffsetFromHeader(c *gin.Context) (time.Duration, error) {
 	return duration, nil
 }
 
+// uses the timezone from the user's availability profile, falling back to the Timezone-Offset header
+func (api *API) getUserLocation(c *gin.Context, user *database.User) (*time.Location, error) {
+	location := database.GetUserLocation(user, nil)
+	if location != nil {
+		return location, nil
+	}
+	timezoneOffset, err := GetTimezoneOffsetFromHeader(c)
+	if err != nil {
+		return nil, err
+	}
+	return time.FixedZone("", int(-1*timezoneOffset.Seconds())), nil
+}
+
+// returns the user's current offset in the same format as the Timezone-Offset header
+func (api *API) getUserTimezoneOffset(c *gin.Context, user *database.User) (time.Duration, error) {
+	location, err := api.getUserLocation(c, user)
+	if err != nil {
+		return 0, err
+	}
+	_, offsetSeconds := api.GetCurrentTime().In(location).Zone()
+	return -time.Duration(offsetSeconds) * time.Second, nil
+}
+
+// checks that the user has linked the account with the service which provides the source
+func (api *API) isLinkedSourceAccount(userID primitive.ObjectID, sourceID string, accountID string) bool {
+	for serviceID, taskServiceResult := range api.ExternalConfig.GetNameToService() {
+		for _, taskSourceResult := range taskServiceResult.Sources {
+			if taskSourceResult.Details.ID != sourceID {
+				continue
+			}
+			count, err := database.GetExternalTokenCollection(api.DB).CountDocuments(
+				context.Background(),
+				bson.M{"$and": []bson.M{
+					{"account_id": accountID},
+					{"service_id": serviceID},
+					{"user_id": userID},
+				}},
+			)
+			if err == nil && count > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tasks titled "<to name>..." are assigned to name@domain, for people whose company email domain is the same as the user's
 func getValidExternalOwnerAssignedTask(db *mongo.Database, userID primitive.ObjectID, taskTitle string) (*database.User, string, error) {
 	fromToken, err := database.GetUser(db, userID)
 	if err != nil {
 		return nil, "", err
 	}
 
-	if strings.HasSuffix(fromToken.Email, "@generaltask.com") && strings.HasPrefix(taskTitle, "<to ") {
-		regex, err := regexp.Compile(`<to [a-zA-Z]+>`)
+	domain, err := database.GetEmailDomain(strings.ToLower(fromToken.Email))
+	if err == nil && !utils.IsOpenEmailAddress(domain) && strings.HasPrefix(taskTitle, "<to ") {
+		regex, err := regexp.Compile(`<to [a-zA-Z.\-_]+>`)
 		if err != nil {
 			logger := logging.GetSentryLogger()
 			logger.Error().Err(err).Msg("error compiling regex")
 		}
 		name := regex.FindString(taskTitle)
-		name = strings.Trim(name, "<to ")
-		name = strings.Trim(name, ">")
-		matchingUser, err := database.GetGeneralTaskUserByName(db, name)
+		name = strings.TrimPrefix(name, "<to ")
+		name = strings.TrimSuffix(name, ">")
+		if name == "" {
+			return nil, "", errors.New("invalid assignee")
+		}
+		matchingUser, err := database.GetUserByEmail(db, name+"@"+domain)
 		if err != nil {
 			return nil, "", err
 		}
@@ -99,7 +153,7 @@ func getValidExternalOwnerAssignedTask(db *mongo.Database, userID primitive.Obje
 		taskTitle = regex.ReplaceAllString(taskTitle, "") + " from: " + fromToken.Email
 		return matchingUser, taskTitle, nil
 	}
-	return nil, "", errors.New("unable to perform with non General Task users")
+	return nil, "", errors.New("unable to assign tasks outside of a company email domain")
 }
 
 func GetTaskSectionViewItemIDs(viewItems []*TaskResult) []string {
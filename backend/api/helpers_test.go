@@ -15,11 +15,13 @@ func TestGetValidExternalOwnerAssignedTask(t *testing.T) {
 
 	userCollection := database.GetUserCollection(api.DB)
 	julianUser, err := userCollection.InsertOne(context.Background(), database.User{
-		Email: "julian@generaltask.com",
+		Email:          "julian@generaltask.com",
+		EmailLowercase: "julian@generaltask.com",
 	})
 	assert.NoError(t, err)
 	johnUser, err := userCollection.InsertOne(context.Background(), database.User{
-		Email: "john@generaltask.com",
+		Email:          "john@generaltask.com",
+		EmailLowercase: "john@generaltask.com",
 	})
 	assert.NoError(t, err)
 
@@ -44,4 +46,23 @@ func TestGetValidExternalOwnerAssignedTask(t *testing.T) {
 		assert.Equal(t, "Hello there! from: julian@generaltask.com", title)
 		assert.Equal(t, johnUser.InsertedID.(primitive.ObjectID), user.ID)
 	})
+	t.Run("SuccessCompanyDomain", func(t *testing.T) {
+		aliceUser, err := userCollection.InsertOne(context.Background(), database.User{Email: "alice@acme.io", EmailLowercase: "alice@acme.io"})
+		assert.NoError(t, err)
+		bobUser, err := userCollection.InsertOne(context.Background(), database.User{Email: "bob@acme.io", EmailLowercase: "bob@acme.io"})
+		assert.NoError(t, err)
+		user, title, err := getValidExternalOwnerAssignedTask(api.DB, aliceUser.InsertedID.(primitive.ObjectID), "<to bob>Hello there!")
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello there! from: alice@acme.io", title)
+		assert.Equal(t, bobUser.InsertedID.(primitive.ObjectID), user.ID)
+	})
+	t.Run("OpenEmailProvider", func(t *testing.T) {
+		carolUser, err := userCollection.InsertOne(context.Background(), database.User{Email: "carol@gmail.com", EmailLowercase: "carol@gmail.com"})
+		assert.NoError(t, err)
+		_, err = userCollection.InsertOne(context.Background(), database.User{Email: "dave@gmail.com", EmailLowercase: "dave@gmail.com"})
+		assert.NoError(t, err)
+		_, title, err := getValidExternalOwnerAssignedTask(api.DB, carolUser.InsertedID.(primitive.ObjectID), "<to dave>Hello there!")
+		assert.Error(t, err)
+		assert.Equal(t, "", title)
+	})
 }
@@ -419,6 +419,12 @@ func (api *API) removeTaskOwnerIfExists(issuePayload LinearIssuePayload) {
 	if err != nil {
 		logger := logging.GetSentryLogger()
 		logger.Error().Err(err).Msg("unable to delete task with owner not in GT")
+		return
+	}
+	err = database.DeleteNoteLinksToTarget(api.DB, task.ID, task.UserID)
+	if err != nil {
+		logger := logging.GetSentryLogger()
+		logger.Error().Err(err).Msg("unable to delete note links to task")
 	}
 }
 
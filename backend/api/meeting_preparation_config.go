@@ -0,0 +1,294 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MeetingPreparationConfigParams struct {
+	Rules        *[]database.MeetingPreparationRule `json:"rules"`
+	BodyTemplate *string                            `json:"body_template"`
+}
+
+func (api *API) MeetingPreparationConfigGet(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, getMeetingPreparationConfig(user))
+}
+
+// fields which are not provided keep their current value
+func (api *API) MeetingPreparationConfigUpdate(c *gin.Context) {
+	var params MeetingPreparationConfigParams
+	err := c.BindJSON(&params)
+	if err != nil || (params.Rules == nil && params.BodyTemplate == nil) {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameters."})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	config := getMeetingPreparationConfig(user)
+	if params.Rules != nil {
+		config.Rules = append([]database.MeetingPreparationRule{}, *params.Rules...)
+	}
+	if params.BodyTemplate != nil {
+		config.BodyTemplate = *params.BodyTemplate
+	}
+	err = validateMeetingPreparationConfig(config)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	_, err = database.GetUserCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"_id": userID},
+		bson.M{"$set": bson.M{"meeting_preparation": config}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update meeting preparation config")
+		Handle500(c)
+		return
+	}
+	if params.Rules != nil {
+		err = api.deleteExcludedMeetingPrepTasks(user, config.Rules)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to delete excluded meeting preparation tasks")
+			Handle500(c)
+			return
+		}
+	}
+	c.JSON(200, gin.H{})
+}
+
+// prep tasks which are not done yet are deleted once their meeting no longer matches the rules
+func (api *API) deleteExcludedMeetingPrepTasks(user *database.User, rules []database.MeetingPreparationRule) error {
+	tasks, err := database.GetTasks(api.DB, user.ID, &[]bson.M{
+		{"is_meeting_preparation_task": true},
+		{"is_completed": false},
+		{"is_deleted": false},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	eventIDs := []primitive.ObjectID{}
+	for _, task := range *tasks {
+		if task.MeetingPreparationParams != nil {
+			eventIDs = append(eventIDs, task.MeetingPreparationParams.CalendarEventID)
+		}
+	}
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	events, err := database.GetCalendarEvents(api.DB, user.ID, &[]bson.M{{"_id": bson.M{"$in": eventIDs}}})
+	if err != nil {
+		return err
+	}
+	excludedEventIDs := []primitive.ObjectID{}
+	for _, event := range *events {
+		if !shouldCreateMeetingPrepTask(rules, event, user.Email) {
+			excludedEventIDs = append(excludedEventIDs, event.ID)
+		}
+	}
+	if len(excludedEventIDs) == 0 {
+		return nil
+	}
+	_, err = database.GetTaskCollection(api.DB).UpdateMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": user.ID},
+			{"is_meeting_preparation_task": true},
+			{"is_completed": false},
+			{"meeting_preparation_params.event_id": bson.M{"$in": excludedEventIDs}},
+		}},
+		bson.M{"$set": bson.M{
+			"is_deleted": true,
+			"deleted_at": primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+		}},
+	)
+	return err
+}
+
+func getMeetingPreparationConfig(user *database.User) database.MeetingPreparationConfig {
+	if user == nil || user.MeetingPreparation == nil {
+		return database.MeetingPreparationConfig{Rules: []database.MeetingPreparationRule{}}
+	}
+	return *user.MeetingPreparation
+}
+
+func validateMeetingPreparationConfig(config database.MeetingPreparationConfig) error {
+	if len(config.Rules) > constants.MAX_MEETING_PREP_RULES {
+		return errors.New("too many rules")
+	}
+	if len(config.BodyTemplate) > constants.MAX_MEETING_PREP_TEMPLATE_LENGTH {
+		return errors.New("'body_template' is too long")
+	}
+	for _, rule := range config.Rules {
+		if rule.Action != constants.MeetingPrepRuleActionInclude && rule.Action != constants.MeetingPrepRuleActionExclude {
+			return fmt.Errorf("invalid rule action '%s'", rule.Action)
+		}
+		if (rule.MinAttendees != nil && *rule.MinAttendees < 0) || (rule.MaxAttendees != nil && *rule.MaxAttendees < 0) {
+			return errors.New("attendee counts must not be negative")
+		}
+		if rule.TitleRegex != "" {
+			_, err := regexp.Compile(rule.TitleRegex)
+			if err != nil {
+				return fmt.Errorf("invalid 'title_regex': %s", rule.TitleRegex)
+			}
+		}
+	}
+	return nil
+}
+
+// a meeting gets a prep task unless it matches an exclude rule, and if there are include rules it must match one of them
+func shouldCreateMeetingPrepTask(rules []database.MeetingPreparationRule, event database.CalendarEvent, userEmail string) bool {
+	hasIncludeRules := false
+	matchesIncludeRule := false
+	for _, rule := range rules {
+		matches := meetingPrepRuleMatches(rule, event, userEmail)
+		if rule.Action == constants.MeetingPrepRuleActionExclude {
+			if matches {
+				return false
+			}
+			continue
+		}
+		hasIncludeRules = true
+		matchesIncludeRule = matchesIncludeRule || matches
+	}
+	return !hasIncludeRules || matchesIncludeRule
+}
+
+func meetingPrepRuleMatches(rule database.MeetingPreparationRule, event database.CalendarEvent, userEmail string) bool {
+	attendeeCount := len(event.AttendeeEmails)
+	if rule.MinAttendees != nil && attendeeCount < *rule.MinAttendees {
+		return false
+	}
+	if rule.MaxAttendees != nil && attendeeCount > *rule.MaxAttendees {
+		return false
+	}
+	if rule.HasExternalAttendees != nil {
+		userDomain := getEmailDomain(userEmail)
+		hasExternalAttendees := false
+		for _, attendee := range event.AttendeeEmails {
+			if getEmailDomain(attendee) != userDomain {
+				hasExternalAttendees = true
+			}
+		}
+		if hasExternalAttendees != *rule.HasExternalAttendees {
+			return false
+		}
+	}
+	if len(rule.AttendeeDomains) > 0 {
+		hasDomain := false
+		for _, attendee := range event.AttendeeEmails {
+			for _, domain := range rule.AttendeeDomains {
+				if getEmailDomain(attendee) == strings.ToLower(domain) {
+					hasDomain = true
+				}
+			}
+		}
+		if !hasDomain {
+			return false
+		}
+	}
+	if rule.TitleRegex != "" {
+		titleRegex, err := regexp.Compile(rule.TitleRegex)
+		if err != nil || !titleRegex.MatchString(event.Title) {
+			return false
+		}
+	}
+	if len(rule.CalendarIDs) > 0 {
+		hasCalendar := false
+		for _, calendarID := range rule.CalendarIDs {
+			if calendarID == event.CalendarID {
+				hasCalendar = true
+			}
+		}
+		if !hasCalendar {
+			return false
+		}
+	}
+	if rule.IsRecurring != nil && (event.RecurringEventID != "") != *rule.IsRecurring {
+		return false
+	}
+	return true
+}
+
+func getEmailDomain(email string) string {
+	index := strings.LastIndex(email, "@")
+	if index == -1 {
+		return ""
+	}
+	return strings.ToLower(email[index+1:])
+}
+
+// returns the notes linked to earlier instances of the same recurring event, most recent first
+func (api *API) getPriorMeetingNotes(userID primitive.ObjectID, event database.CalendarEvent) ([]database.Note, error) {
+	if event.RecurringEventID == "" {
+		return []database.Note{}, nil
+	}
+	priorEvents, err := database.GetCalendarEvents(api.DB, userID, &[]bson.M{
+		{"recurring_event_id": event.RecurringEventID},
+		{"datetime_start": bson.M{"$lt": event.DatetimeStart}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	priorEventIDs := []primitive.ObjectID{}
+	for _, priorEvent := range *priorEvents {
+		priorEventIDs = append(priorEventIDs, priorEvent.ID)
+	}
+	if len(priorEventIDs) == 0 {
+		return []database.Note{}, nil
+	}
+	var notes []database.Note
+	err = database.FindWithCollection(
+		database.GetNoteCollection(api.DB),
+		userID,
+		&[]bson.M{
+			{"linked_event_id": bson.M{"$in": priorEventIDs}},
+			{"is_deleted": bson.M{"$ne": true}},
+		},
+		&notes,
+		options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(constants.MAX_MEETING_PREP_PRIOR_NOTES),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+func getMeetingPrepTaskBody(bodyTemplate string, event database.CalendarEvent, priorNotes []database.Note) string {
+	if bodyTemplate == "" {
+		return ""
+	}
+	priorNoteLinks := []string{}
+	for _, note := range priorNotes {
+		title := "Untitled note"
+		if note.Title != nil && *note.Title != "" {
+			title = *note.Title
+		}
+		priorNoteLinks = append(priorNoteLinks, fmt.Sprintf("- [%s](%s)", title, getNoteURL(note.ID.Hex())))
+	}
+	body := strings.ReplaceAll(bodyTemplate, constants.MeetingPrepTemplateTitle, event.Title)
+	body = strings.ReplaceAll(body, constants.MeetingPrepTemplateAttendees, strings.Join(event.AttendeeEmails, ", "))
+	return strings.ReplaceAll(body, constants.MeetingPrepTemplatePriorNotes, strings.Join(priorNoteLinks, "\n"))
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestShouldCreateMeetingPrepTask(t *testing.T) {
+	two := 2
+	hasExternalAttendees := true
+	recurring := true
+	standup := database.CalendarEvent{Title: "Daily standup", CalendarID: "work", RecurringEventID: "abc", AttendeeEmails: []string{"me@generaltask.com", "teammate@generaltask.com"}}
+	customerCall := database.CalendarEvent{Title: "Customer call", CalendarID: "work", AttendeeEmails: []string{"me@generaltask.com", "customer@example.com", "other@example.com"}}
+	focusTime := database.CalendarEvent{Title: "Focus time", CalendarID: "personal"}
+
+	t.Run("NoRules", func(t *testing.T) {
+		assert.True(t, shouldCreateMeetingPrepTask(nil, focusTime, "me@generaltask.com"))
+	})
+	t.Run("IncludeRules", func(t *testing.T) {
+		rules := []database.MeetingPreparationRule{
+			{Action: constants.MeetingPrepRuleActionInclude, HasExternalAttendees: &hasExternalAttendees},
+			{Action: constants.MeetingPrepRuleActionInclude, AttendeeDomains: []string{"Example.com"}},
+		}
+		assert.False(t, shouldCreateMeetingPrepTask(rules, standup, "me@generaltask.com"))
+		assert.True(t, shouldCreateMeetingPrepTask(rules, customerCall, "me@generaltask.com"))
+		assert.False(t, shouldCreateMeetingPrepTask(rules, focusTime, "me@generaltask.com"))
+	})
+	t.Run("ExcludeRules", func(t *testing.T) {
+		rules := []database.MeetingPreparationRule{
+			{Action: constants.MeetingPrepRuleActionExclude, IsRecurring: &recurring},
+			{Action: constants.MeetingPrepRuleActionExclude, TitleRegex: "(?i)^focus"},
+		}
+		assert.False(t, shouldCreateMeetingPrepTask(rules, standup, "me@generaltask.com"))
+		assert.True(t, shouldCreateMeetingPrepTask(rules, customerCall, "me@generaltask.com"))
+		assert.False(t, shouldCreateMeetingPrepTask(rules, focusTime, "me@generaltask.com"))
+	})
+	t.Run("AllConditionsMustMatch", func(t *testing.T) {
+		rules := []database.MeetingPreparationRule{
+			{Action: constants.MeetingPrepRuleActionInclude, MinAttendees: &two, MaxAttendees: &two, CalendarIDs: []string{"work"}},
+		}
+		assert.True(t, shouldCreateMeetingPrepTask(rules, standup, "me@generaltask.com"))
+		assert.False(t, shouldCreateMeetingPrepTask(rules, customerCall, "me@generaltask.com"))
+	})
+}
+
+func TestGetMeetingPrepTaskBody(t *testing.T) {
+	noteTitle := "last week"
+	note := database.Note{ID: primitive.NewObjectID(), Title: &noteTitle}
+	untitledNote := database.Note{ID: primitive.NewObjectID()}
+	event := database.CalendarEvent{Title: "1:1", AttendeeEmails: []string{"a@generaltask.com", "b@generaltask.com"}}
+
+	assert.Equal(t, "", getMeetingPrepTaskBody("", event, []database.Note{note}))
+	assert.Equal(t,
+		"Agenda for 1:1 with a@generaltask.com, b@generaltask.com\n- [last week]("+getNoteURL(note.ID.Hex())+")\n- [Untitled note]("+getNoteURL(untitledNote.ID.Hex())+")",
+		getMeetingPrepTaskBody("Agenda for {{title}} with {{attendees}}\n{{prior_notes}}", event, []database.Note{note, untitledNote}),
+	)
+}
+
+func TestMeetingPreparationConfig(t *testing.T) {
+	authToken := login("test_meeting_prep_config@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	UnauthorizedTest(t, "GET", "/meeting_preparation_tasks/config/", nil)
+	t.Run("GetDefault", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/meeting_preparation_tasks/config/", nil, http.StatusOK, api)
+		assert.Equal(t, `{"rules":[],"body_template":""}`, string(body))
+	})
+	t.Run("InvalidAction", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", "/meeting_preparation_tasks/config/", bytes.NewBuffer([]byte(`{"rules":[{"action":"maybe"}]}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid rule action 'maybe'"}`, string(body))
+	})
+	t.Run("InvalidRegex", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", "/meeting_preparation_tasks/config/", bytes.NewBuffer([]byte(`{"rules":[{"action":"include","title_regex":"("}]}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid 'title_regex': ("}`, string(body))
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/meeting_preparation_tasks/config/", bytes.NewBuffer([]byte(`{"rules":[{"action":"exclude","is_recurring":true}],"body_template":"{{prior_notes}}"}`)), http.StatusOK, api)
+		body := ServeRequest(t, authToken, "GET", "/meeting_preparation_tasks/config/", nil, http.StatusOK, api)
+		assert.Equal(t, `{"rules":[{"action":"exclude","is_recurring":true}],"body_template":"{{prior_notes}}"}`, string(body))
+
+		user, err := database.GetUser(api.DB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "{{prior_notes}}", user.MeetingPreparation.BodyTemplate)
+	})
+	t.Run("ExcludeRuleDeletesPrepTasks", func(t *testing.T) {
+		taskCollection := database.GetTaskCollection(api.DB)
+		eventStart := time.Now().Add(time.Hour)
+		createPrepTask := func(title string, isCompleted bool) primitive.ObjectID {
+			eventResult, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+				UserID:        userID,
+				IDExternal:    primitive.NewObjectID().Hex(),
+				Title:         title,
+				DatetimeStart: primitive.NewDateTimeFromTime(eventStart),
+				DatetimeEnd:   primitive.NewDateTimeFromTime(eventStart.Add(time.Hour)),
+			})
+			assert.NoError(t, err)
+			eventID := eventResult.InsertedID.(primitive.ObjectID)
+			taskResult, err := createTestMeetingPreparationTask(taskCollection, userID, title, eventID.Hex(), isCompleted, eventStart, eventStart.Add(time.Hour), eventID)
+			assert.NoError(t, err)
+			return taskResult.InsertedID.(primitive.ObjectID)
+		}
+		interviewTaskID := createPrepTask("Interview", false)
+		completedInterviewTaskID := createPrepTask("Interview", true)
+		planningTaskID := createPrepTask("Planning", false)
+
+		ServeRequest(t, authToken, "PATCH", "/meeting_preparation_tasks/config/", bytes.NewBuffer([]byte(`{"rules":[{"action":"exclude","title_regex":"^Interview"}]}`)), http.StatusOK, api)
+
+		interviewTask, err := database.GetTask(api.DB, interviewTaskID, userID)
+		assert.NoError(t, err)
+		assert.True(t, *interviewTask.IsDeleted)
+		completedInterviewTask, err := database.GetTask(api.DB, completedInterviewTaskID, userID)
+		assert.NoError(t, err)
+		assert.False(t, *completedInterviewTask.IsDeleted)
+		planningTask, err := database.GetTask(api.DB, planningTaskID, userID)
+		assert.NoError(t, err)
+		assert.False(t, *planningTask.IsDeleted)
+	})
+}
+
+func TestMeetingPrepCarryover(t *testing.T) {
+	authToken := login("test_meeting_prep_carryover@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	currentTime := time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC)
+	taskCollection := database.GetTaskCollection(api.DB)
+
+	_, err := database.GetUserSettingsCollection(api.DB).InsertOne(context.Background(), database.UserSetting{
+		UserID:     userID,
+		FieldKey:   constants.SettingFieldMeetingPrepCarryover,
+		FieldValue: "true",
+	})
+	assert.NoError(t, err)
+	insertResult, err := createTestMeetingPreparationTask(taskCollection, userID, "Planning", primitive.NewObjectID().Hex(), false, currentTime.Add(-2*time.Hour), currentTime.Add(-time.Hour), primitive.NewObjectID())
+	assert.NoError(t, err)
+	prepTaskID := insertResult.InsertedID.(primitive.ObjectID)
+
+	err = api.MarkEarlierMeetingPrepTasksAutomaticallyComplete(userID, currentTime)
+	assert.NoError(t, err)
+	// running again does not create another follow-up task
+	err = api.MarkEarlierMeetingPrepTasksAutomaticallyComplete(userID, currentTime)
+	assert.NoError(t, err)
+
+	prepTask, err := database.GetTask(api.DB, prepTaskID, userID)
+	assert.NoError(t, err)
+	assert.True(t, *prepTask.IsCompleted)
+	assert.True(t, prepTask.MeetingPreparationParams.HasBeenAutomaticallyCompleted)
+
+	var followUpTasks []database.Task
+	err = database.FindWithCollection(taskCollection, userID, &[]bson.M{{"title": "Follow up: Planning"}}, &followUpTasks, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(followUpTasks))
+	assert.Equal(t, followUpTasks[0].ID, prepTask.MeetingPreparationParams.FollowUpTaskID)
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/GeneralTask/task-manager/backend/constants"
 	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/settings"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,13 +17,13 @@ import (
 
 func (api *API) MeetingPreparationTasksList(c *gin.Context) {
 	userID := getUserIDFromContext(c)
-	_, err := database.GetUser(api.DB, userID)
+	user, err := database.GetUser(api.DB, userID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to get user")
 		Handle500(c)
 		return
 	}
-	timezoneOffset, err := GetTimezoneOffsetFromHeader(c)
+	timezoneOffset, err := api.getUserTimezoneOffset(c, user)
 	if err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
@@ -86,7 +88,7 @@ func (api *API) GetMeetingPreparationTasksResult(userID primitive.ObjectID, time
 		return (allTasks)[i].MeetingPreparationParams.DatetimeStart < (allTasks)[j].MeetingPreparationParams.DatetimeStart
 	})
 
-	meetingTaskResult := api.taskListToTaskResultListV4(&allTasks)
+	meetingTaskResult := api.taskListToTaskResultListV4(&allTasks, userID)
 	return meetingTaskResult, nil
 }
 
@@ -124,6 +126,11 @@ func (api *API) GetAndUpdateMeetingPreparationTasksFromEvents(userID primitive.O
 	if err != nil {
 		return nil, err
 	}
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	config := getMeetingPreparationConfig(user)
 	calendarToAccessRole := createCalendarToAccessRoleMap(calendarAccount)
 
 	var tasks []database.Task
@@ -136,7 +143,10 @@ func (api *API) GetAndUpdateMeetingPreparationTasksFromEvents(userID primitive.O
 		} else {
 			continue
 		}
-		task, err := getOrCreateMeetingPrepTask(userID, event, taskCollection)
+		if !shouldCreateMeetingPrepTask(config.Rules, event, user.Email) {
+			continue
+		}
+		task, err := api.getOrCreateMeetingPrepTask(userID, event, config.BodyTemplate, taskCollection)
 		if err != nil {
 			return nil, err
 		}
@@ -152,7 +162,7 @@ func (api *API) GetAndUpdateMeetingPreparationTasksFromEvents(userID primitive.O
 	return &tasks, nil
 }
 
-func getOrCreateMeetingPrepTask(userID primitive.ObjectID, event database.CalendarEvent, taskCollection *mongo.Collection) (database.Task, error) {
+func (api *API) getOrCreateMeetingPrepTask(userID primitive.ObjectID, event database.CalendarEvent, bodyTemplate string, taskCollection *mongo.Collection) (database.Task, error) {
 	// Check if meeting preparation task exists
 	var task database.Task
 	err := taskCollection.FindOne(
@@ -191,6 +201,14 @@ func getOrCreateMeetingPrepTask(userID primitive.ObjectID, event database.Calend
 				EventMovedOrDeleted:           false,
 			},
 		}
+		if bodyTemplate != "" {
+			priorNotes, err := api.getPriorMeetingNotes(userID, event)
+			if err != nil {
+				return database.Task{}, err
+			}
+			body := getMeetingPrepTaskBody(bodyTemplate, event, priorNotes)
+			taskToInsert.Body = &body
+		}
 
 		insertResult, err := taskCollection.InsertOne(context.Background(), taskToInsert)
 		if err != nil {
@@ -250,6 +268,17 @@ func (api *API) MarkEarlierMeetingPrepTasksAutomaticallyComplete(userID primitiv
 		{"is_deleted": false},
 	}
 
+	carryoverSetting, err := settings.GetUserSettingValue(api.DB, userID, settings.MeetingPrepCarryoverSetting)
+	if err != nil {
+		return err
+	}
+	if carryoverSetting == "true" {
+		err = api.createMeetingPrepFollowUpTasks(userID, filter)
+		if err != nil {
+			return err
+		}
+	}
+
 	// TODO switch to use datetime from event
 	completedAt := primitive.NewDateTimeFromTime(time.Now())
 	update := bson.M{
@@ -259,6 +288,51 @@ func (api *API) MarkEarlierMeetingPrepTasksAutomaticallyComplete(userID primitiv
 	}
 
 	taskCollection := database.GetTaskCollection(api.DB)
-	_, err := taskCollection.UpdateMany(context.Background(), bson.M{"$and": filter}, bson.M{"$set": update}, nil)
+	_, err = taskCollection.UpdateMany(context.Background(), bson.M{"$and": filter}, bson.M{"$set": update}, nil)
 	return err
 }
+
+// unfinished prep tasks carry over as regular tasks, so that the preparation is not lost when they are auto-completed
+func (api *API) createMeetingPrepFollowUpTasks(userID primitive.ObjectID, filter []bson.M) error {
+	var tasks []database.Task
+	err := database.FindWithCollection(
+		database.GetTaskCollection(api.DB),
+		userID,
+		&[]bson.M{
+			{"$and": filter},
+			{"is_meeting_preparation_task": true},
+			{"meeting_preparation_params.follow_up_task_id": bson.M{"$exists": false}},
+		},
+		&tasks,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		title := constants.MEETING_PREP_FOLLOW_UP_TITLE_PREFIX
+		if task.Title != nil {
+			title += *task.Title
+		}
+		body := ""
+		if task.Body != nil {
+			body = *task.Body
+		}
+		followUpTaskID, err := external.GeneralTaskTaskSource{}.CreateNewTask(api.DB, userID, external.GeneralTaskDefaultAccountID, external.TaskCreationObject{
+			Title: title,
+			Body:  body,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = database.GetTaskCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"$and": []bson.M{{"_id": task.ID}, {"user_id": userID}}},
+			bson.M{"$set": bson.M{"meeting_preparation_params.follow_up_task_id": followUpTaskID}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
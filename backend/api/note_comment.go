@@ -0,0 +1,98 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (api *API) NoteAddComment(c *gin.Context) {
+	note, ok := api.getNoteForComment(c)
+	if !ok {
+		return
+	}
+	var params CommentModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "parameter missing or malformatted"})
+		return
+	}
+	api.addComment(c, getUserIDFromContext(c), params.Body, api.getNoteCommentedItem(note))
+}
+
+func (api *API) NoteCommentModify(c *gin.Context) {
+	note, ok := api.getNoteForComment(c)
+	if !ok {
+		return
+	}
+	api.modifyComment(c, getUserIDFromContext(c), c.Param("comment_id"), api.getNoteCommentedItem(note))
+}
+
+func (api *API) NoteCommentDelete(c *gin.Context) {
+	note, ok := api.getNoteForComment(c)
+	if !ok {
+		return
+	}
+	api.deleteComment(c, getUserIDFromContext(c), c.Param("comment_id"), api.getNoteCommentedItem(note))
+}
+
+// owners can comment on their notes, other users only if the note is shared with them as commenters
+func (api *API) getCommentableNote(noteID primitive.ObjectID, userID primitive.ObjectID) (*database.Note, error) {
+	note, err := database.GetNote(api.DB, noteID, userID)
+	if err == nil {
+		return note, nil
+	}
+	note, err = database.GetSharedNoteWithAuth(api.DB, noteID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !api.isCommenter(userID, note.SharedWith) {
+		return nil, errors.New("user cannot comment on this note")
+	}
+	return note, nil
+}
+
+func (api *API) getNoteForComment(c *gin.Context) (*database.Note, bool) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	note, err := api.getCommentableNote(noteID, getUserIDFromContext(c))
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "note not found.", "noteId": noteID})
+		return nil, false
+	}
+	return note, true
+}
+
+func (api *API) getNoteCommentedItem(note *database.Note) *commentedItem {
+	return &commentedItem{
+		collection: database.GetNoteCollection(api.DB),
+		filter: []bson.M{
+			{"_id": note.ID},
+			{"user_id": note.UserID},
+		},
+		comments:               note.Comments,
+		getMentionNotification: api.getNoteMentionNotifier(note),
+	}
+}
+
+func (api *API) getNoteMentionNotifier(note *database.Note) func(*database.User) *database.Notification {
+	return func(mentionedUser *database.User) *database.Notification {
+		if mentionedUser.ID != note.UserID {
+			_, err := database.GetSharedNoteWithAuth(api.DB, note.ID, mentionedUser.ID)
+			if err != nil {
+				return nil
+			}
+		}
+		title := ""
+		if note.Title != nil {
+			title = *note.Title
+		}
+		return &database.Notification{NoteID: note.ID, Title: title}
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNoteComments(t *testing.T) {
+	ownerToken := login("test_note_comments_owner@generaltask.com", "Owner")
+	commenterToken := login("test_note_comments_commenter@generaltask.com", "")
+	otherToken := login("test_note_comments_other@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	ownerID := getUserIDFromAuthToken(t, api.DB, ownerToken)
+	otherID := getUserIDFromAuthToken(t, api.DB, otherToken)
+
+	insertResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{
+		UserID:     ownerID,
+		SharedWith: []database.SharedWithEntry{{Email: "test_note_comments_commenter@generaltask.com", Role: constants.AccessControlCommenter}},
+	})
+	assert.NoError(t, err)
+	noteID := insertResult.InsertedID.(primitive.ObjectID)
+	commentsURL := "/notes/" + noteID.Hex() + "/comments/"
+
+	UnauthorizedTest(t, "POST", commentsURL+"add/", nil)
+	t.Run("NotShared", func(t *testing.T) {
+		ServeRequest(t, otherToken, "POST", commentsURL+"add/", bytes.NewBuffer([]byte(`{"body":"hi"}`)), http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, ownerToken, "POST", commentsURL+"add/", bytes.NewBuffer([]byte(`{"body":"first"}`)), http.StatusOK, api)
+		// the other user cannot access the note, so is not notified
+		ServeRequest(t, commenterToken, "POST", commentsURL+"add/", bytes.NewBuffer([]byte(`{"body":"second @test_note_comments_other@generaltask.com @test_note_comments_owner@generaltask.com"}`)), http.StatusOK, api)
+
+		note, err := database.GetNote(api.DB, noteID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(*note.Comments))
+		assert.Equal(t, "first", (*note.Comments)[0].Body)
+
+		// mentions are handled in order, so the other user has been checked once the owner is notified
+		waitForInboxNotifications(t, api, ownerID, 1)
+		notifications, err := database.GetInboxNotifications(api.DB, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*notifications))
+		assert.Equal(t, noteID, (*notifications)[0].NoteID)
+		notifications, err = database.GetInboxNotifications(api.DB, otherID)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(*notifications))
+	})
+}
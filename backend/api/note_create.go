@@ -7,6 +7,7 @@ import (
 
 	"github.com/GeneralTask/task-manager/backend/database"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -17,6 +18,8 @@ type NoteCreateParams struct {
 	SharedUntil   primitive.DateTime     `json:"shared_until"`
 	SharedAccess  *database.SharedAccess `json:"shared_access,omitempty"`
 	LinkedEventID primitive.ObjectID     `json:"linked_event_id,omitempty"`
+	// links the note to every instance of a recurring event
+	LinkedRecurringEventID string `json:"linked_recurring_event_id,omitempty"`
 }
 
 func (api *API) NoteCreate(c *gin.Context) {
@@ -38,6 +41,29 @@ func (api *API) NoteCreate(c *gin.Context) {
 		}
 	}
 
+	if noteCreateParams.LinkedRecurringEventID != "" {
+		if noteCreateParams.LinkedEventID != primitive.NilObjectID {
+			c.JSON(400, gin.H{"detail": "a note cannot be linked to both an event and a recurring event"})
+			return
+		}
+		_, err = database.GetCalendarEventCollection(api.DB).FindOne(
+			context.Background(),
+			bson.M{"$and": []bson.M{
+				{"user_id": userID},
+				{"recurring_event_id": noteCreateParams.LinkedRecurringEventID},
+			}},
+		).DecodeBytes()
+		if err != nil {
+			c.JSON(400, gin.H{"detail": fmt.Sprintf("linked recurring event not found: %s", noteCreateParams.LinkedRecurringEventID)})
+			return
+		}
+		_, err = api.getSeriesNote(userID, noteCreateParams.LinkedRecurringEventID)
+		if err == nil {
+			c.JSON(400, gin.H{"detail": "recurring event already has a note"})
+			return
+		}
+	}
+
 	sharedAccessValid := database.CheckNoteSharingAccessValid(noteCreateParams.SharedAccess)
 	if !sharedAccessValid {
 		api.Logger.Error().Err(err).Msg("invalid shared access token")
@@ -46,15 +72,16 @@ func (api *API) NoteCreate(c *gin.Context) {
 	}
 
 	newNote := database.Note{
-		UserID:        userID,
-		Title:         &noteCreateParams.Title,
-		Body:          &noteCreateParams.Body,
-		Author:        noteCreateParams.Author,
-		CreatedAt:     primitive.NewDateTimeFromTime(time.Now()),
-		UpdatedAt:     primitive.NewDateTimeFromTime(time.Now()),
-		SharedUntil:   noteCreateParams.SharedUntil,
-		SharedAccess:  noteCreateParams.SharedAccess,
-		LinkedEventID: noteCreateParams.LinkedEventID,
+		UserID:                 userID,
+		Title:                  &noteCreateParams.Title,
+		Body:                   &noteCreateParams.Body,
+		Author:                 noteCreateParams.Author,
+		CreatedAt:              primitive.NewDateTimeFromTime(time.Now()),
+		UpdatedAt:              primitive.NewDateTimeFromTime(time.Now()),
+		SharedUntil:            noteCreateParams.SharedUntil,
+		SharedAccess:           noteCreateParams.SharedAccess,
+		LinkedEventID:          noteCreateParams.LinkedEventID,
+		LinkedRecurringEventID: noteCreateParams.LinkedRecurringEventID,
 	}
 	insertResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), newNote)
 	if err != nil {
@@ -62,5 +89,11 @@ func (api *API) NoteCreate(c *gin.Context) {
 		return
 	}
 
-	c.JSON(200, gin.H{"note_id": insertResult.InsertedID.(primitive.ObjectID)})
+	newNote.ID = insertResult.InsertedID.(primitive.ObjectID)
+	err = api.syncNoteReferenceLinks(&newNote, noteCreateParams.Body)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update note links")
+	}
+
+	c.JSON(200, gin.H{"note_id": newNote.ID})
 }
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// permanently deletes the note, notes are soft deleted by modifying is_deleted
+func (api *API) NoteDelete(c *gin.Context) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		// This means the note ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	err = database.DeleteNote(api.DB, noteID, getUserIDFromContext(c))
+	if err == mongo.ErrNoDocuments {
+		Handle404(c)
+		return
+	} else if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete note")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"time"
 
+	"github.com/GeneralTask/task-manager/backend/constants"
 	"github.com/GeneralTask/task-manager/backend/database"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -36,13 +37,21 @@ func (api *API) NoteDetails(c *gin.Context) {
 			Handle404(c)
 			return
 		}
+		if note.SharedUntil < primitive.NewDateTimeFromTime(time.Now()) {
+			Handle404(c)
+			return
+		}
 	}
-
-	if note.SharedUntil < primitive.NewDateTimeFromTime(time.Now()) {
-		Handle404(c)
-		return
-	}
+	api.recordSharedItemView(constants.SharedItemTypeNote, note.ID, note.UserID, userID)
 
 	noteResult := api.noteToNoteResult(note)
+	if userID == nil || *userID != note.UserID {
+		noteResult.SharedWith = nil
+	} else {
+		err = api.populateNoteLinks(note.UserID, []*NoteResult{noteResult})
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to fetch note links")
+		}
+	}
 	c.JSON(200, noteResult)
 }
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/templating"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var noteExportFiles = map[string]struct {
+	Extension   string
+	ContentType string
+}{
+	constants.NoteExportFormatMarkdown: {Extension: ".md", ContentType: "text/markdown; charset=utf-8"},
+	constants.NoteExportFormatHTML:     {Extension: ".html", ContentType: "text/html; charset=utf-8"},
+}
+
+var noteFileNameRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// NoteExport exports a single note as a file download
+func (api *API) NoteExport(c *gin.Context) {
+	format, ok := getNoteExportFormat(c)
+	if !ok {
+		return
+	}
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		// This means the note ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	note, err := database.GetNote(api.DB, noteID, userID)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "note not found.", "noteId": noteID})
+		return
+	}
+	body, err := getNoteExport(api.getNoteTemplateData(note, userID), format)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to encode note export")
+		Handle500(c)
+		return
+	}
+	exportFile := noteExportFiles[format]
+	c.Header("Content-Disposition", "attachment; filename="+getNoteExportFileName(note.Title)+exportFile.Extension)
+	c.Data(200, exportFile.ContentType, body)
+}
+
+// NotesExport exports all of the user's notes as a zip with one file per note
+func (api *API) NotesExport(c *gin.Context) {
+	format, ok := getNoteExportFormat(c)
+	if !ok {
+		return
+	}
+	userID := getUserIDFromContext(c)
+	notes, err := database.GetNotes(api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	buffer := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buffer)
+	for _, note := range *notes {
+		if note.IsDeleted != nil && *note.IsDeleted {
+			continue
+		}
+		// for implicit memory aliasing
+		tempNote := note
+		body, err := getNoteExport(api.getNoteTemplateData(&tempNote, userID), format)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to encode note export")
+			Handle500(c)
+			return
+		}
+		// the note ID keeps file names unique when notes have the same title
+		fileName := fmt.Sprintf("%s-%s%s", getNoteExportFileName(note.Title), note.ID.Hex(), noteExportFiles[format].Extension)
+		fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+			Name:     fileName,
+			Method:   zip.Deflate,
+			Modified: note.UpdatedAt.Time(),
+		})
+		if err == nil {
+			_, err = fileWriter.Write(body)
+		}
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to write note export")
+			Handle500(c)
+			return
+		}
+	}
+	err = zipWriter.Close()
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to write note export")
+		Handle500(c)
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=notes.zip")
+	c.Data(200, "application/zip", buffer.Bytes())
+}
+
+func getNoteExportFormat(c *gin.Context) (string, bool) {
+	format := c.DefaultQuery("format", constants.NoteExportFormatMarkdown)
+	if _, ok := noteExportFiles[format]; !ok {
+		c.JSON(400, gin.H{"detail": "'format' must be md or html"})
+		return "", false
+	}
+	return format, true
+}
+
+func (api *API) getNoteTemplateData(note *database.Note, userID primitive.ObjectID) templating.NoteTemplateData {
+	data := templating.NoteTemplateData{
+		Title:     "Untitled note",
+		Author:    note.Author,
+		CreatedAt: note.CreatedAt.Time().UTC().Format(time.RFC3339),
+		UpdatedAt: note.UpdatedAt.Time().UTC().Format(time.RFC3339),
+	}
+	if note.Title != nil && *note.Title != "" {
+		data.Title = *note.Title
+	}
+	if note.Body != nil {
+		data.Body = *note.Body
+	}
+	if note.LinkedEventID != primitive.NilObjectID {
+		event, err := database.GetCalendarEvent(api.DB, note.LinkedEventID, userID)
+		if err == nil {
+			data.LinkedEvent = fmt.Sprintf("%s (%s)", event.Title, event.DatetimeStart.Time().UTC().Format(time.RFC3339))
+		}
+	}
+	return data
+}
+
+func getNoteExport(data templating.NoteTemplateData, format string) ([]byte, error) {
+	if format == constants.NoteExportFormatHTML {
+		html, err := templating.FormatNoteAsHTML(data)
+		return []byte(html), err
+	}
+	return []byte(getNoteMarkdown(data)), nil
+}
+
+// the metadata is written as YAML front matter, strings are quoted so they can contain any characters
+func getNoteMarkdown(data templating.NoteTemplateData) string {
+	frontMatter := []string{
+		"---",
+		"title: " + strconv.Quote(data.Title),
+		"created_at: " + data.CreatedAt,
+		"updated_at: " + data.UpdatedAt,
+	}
+	if data.LinkedEvent != "" {
+		frontMatter = append(frontMatter, "linked_event: "+strconv.Quote(data.LinkedEvent))
+	}
+	if data.Author != "" {
+		frontMatter = append(frontMatter, "author: "+strconv.Quote(data.Author))
+	}
+	frontMatter = append(frontMatter, "---", "")
+	return strings.Join(frontMatter, "\n") + "\n" + data.Body
+}
+
+func getNoteExportFileName(title *string) string {
+	fileName := ""
+	if title != nil {
+		fileName = strings.Trim(noteFileNameRegex.ReplaceAllString(strings.ToLower(*title), "-"), "-")
+	}
+	if len(fileName) > 50 {
+		fileName = strings.TrimRight(fileName[:50], "-")
+	}
+	if fileName == "" {
+		return "note"
+	}
+	return fileName
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/templating"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetNoteMarkdown(t *testing.T) {
+	assert.Equal(t,
+		"---\ntitle: \"1:1 \\\"notes\\\"\"\ncreated_at: 2022-11-14T09:00:00Z\nupdated_at: 2022-11-15T09:00:00Z\nlinked_event: \"1:1 (2022-11-14T10:00:00Z)\"\nauthor: \"Jane\"\n---\n\n# Agenda",
+		getNoteMarkdown(templating.NoteTemplateData{
+			Title:       "1:1 \"notes\"",
+			Author:      "Jane",
+			CreatedAt:   "2022-11-14T09:00:00Z",
+			UpdatedAt:   "2022-11-15T09:00:00Z",
+			LinkedEvent: "1:1 (2022-11-14T10:00:00Z)",
+			Body:        "# Agenda",
+		}),
+	)
+}
+
+func TestGetNoteExportFileName(t *testing.T) {
+	title := "  Q4 Planning: Budget & Hiring! "
+	emptyTitle := "???"
+	assert.Equal(t, "q4-planning-budget-hiring", getNoteExportFileName(&title))
+	assert.Equal(t, "note", getNoteExportFileName(&emptyTitle))
+	assert.Equal(t, "note", getNoteExportFileName(nil))
+}
+
+func TestNoteExport(t *testing.T) {
+	authToken := login("test_note_export@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	createdAt := primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 9, 0, 0, 0, time.UTC))
+
+	eventResult, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+		UserID:        userID,
+		Title:         "Planning",
+		DatetimeStart: primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 10, 0, 0, 0, time.UTC)),
+	})
+	assert.NoError(t, err)
+	title := "Planning notes"
+	body := "# Agenda"
+	isDeleted := true
+	noteResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{
+		UserID:        userID,
+		Title:         &title,
+		Body:          &body,
+		Author:        "Jane",
+		LinkedEventID: eventResult.InsertedID.(primitive.ObjectID),
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	})
+	assert.NoError(t, err)
+	noteID := noteResult.InsertedID.(primitive.ObjectID)
+	_, err = database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{
+		UserID:    userID,
+		Title:     &title,
+		IsDeleted: &isDeleted,
+	})
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, "GET", "/notes/export/", nil)
+	t.Run("InvalidFormat", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/notes/"+noteID.Hex()+"/export/?format=pdf", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'format' must be md or html"}`, string(body))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/notes/"+primitive.NewObjectID().Hex()+"/export/", nil, http.StatusNotFound, api)
+	})
+	t.Run("Markdown", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/notes/"+noteID.Hex()+"/export/", nil, http.StatusOK, api)
+		assert.Equal(t, "---\ntitle: \"Planning notes\"\ncreated_at: 2022-11-14T09:00:00Z\nupdated_at: 2022-11-14T09:00:00Z\nlinked_event: \"Planning (2022-11-14T10:00:00Z)\"\nauthor: \"Jane\"\n---\n\n# Agenda", string(body))
+	})
+	t.Run("HTML", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/notes/"+noteID.Hex()+"/export/?format=html", nil, http.StatusOK, api)
+		assert.Contains(t, string(body), "<h1>Planning notes</h1>")
+		assert.Contains(t, string(body), "<div>Meeting: Planning (2022-11-14T10:00:00Z)</div>")
+	})
+	t.Run("BulkExport", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/notes/export/", nil, http.StatusOK, api)
+		zipReader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		assert.NoError(t, err)
+		// deleted notes are not exported
+		assert.Equal(t, 1, len(zipReader.File))
+		assert.Equal(t, "planning-notes-"+noteID.Hex()+".md", zipReader.File[0].Name)
+		file, err := zipReader.File[0].Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(file)
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "title: \"Planning notes\"")
+	})
+}
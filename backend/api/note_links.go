@@ -0,0 +1,373 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NoteLinkParams struct {
+	TargetID   string `json:"target_id" binding:"required"`
+	TargetType string `json:"target_type" binding:"required"`
+}
+
+type NoteLinkResult struct {
+	ID        primitive.ObjectID `json:"id"`
+	Type      string             `json:"type"`
+	Title     string             `json:"title"`
+	IsDone    bool               `json:"is_done"`
+	IsDeleted bool               `json:"is_deleted"`
+}
+
+type noteLinkTarget struct {
+	ID   primitive.ObjectID
+	Type string
+}
+
+// references are written in the note body as [[type:id]], e.g. [[task:6373b3b9ab1db1e5e0e5a1b2]]
+var noteReferenceRegex = regexp.MustCompile(`\[\[(task|pull_request|note):([0-9a-f]{24})\]\]`)
+
+func (api *API) NoteLinkAdd(c *gin.Context) {
+	note, ok := api.getNoteForLinks(c)
+	if !ok {
+		return
+	}
+	var params NoteLinkParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	targetID, err := primitive.ObjectIDFromHex(params.TargetID)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid target_id"})
+		return
+	}
+	err = api.validateNoteLinkTarget(note, noteLinkTarget{ID: targetID, Type: params.TargetType})
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	linkCollection := database.GetNoteLinkCollection(api.DB)
+	linkCount, err := linkCollection.CountDocuments(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"note_id": note.ID},
+			{"user_id": note.UserID},
+			{"is_reference": false},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to count note links")
+		Handle500(c)
+		return
+	}
+	if linkCount >= constants.MAX_NOTE_LINKS {
+		c.JSON(400, gin.H{"detail": "too many links"})
+		return
+	}
+	_, err = linkCollection.UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"note_id": note.ID},
+			{"user_id": note.UserID},
+			{"target_id": targetID},
+			{"is_reference": false},
+		}},
+		bson.M{"$setOnInsert": bson.M{
+			"target_type": params.TargetType,
+			"created_at":  primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+		}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to add note link")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// only removes links added explicitly, references are removed by editing the note body
+func (api *API) NoteLinkDelete(c *gin.Context) {
+	note, ok := api.getNoteForLinks(c)
+	if !ok {
+		return
+	}
+	targetID, err := primitive.ObjectIDFromHex(c.Query("target_id"))
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid target_id"})
+		return
+	}
+	result, err := database.GetNoteLinkCollection(api.DB).DeleteOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"note_id": note.ID},
+			{"user_id": note.UserID},
+			{"target_id": targetID},
+			{"is_reference": false},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete note link")
+		Handle500(c)
+		return
+	}
+	if result.DeletedCount != 1 {
+		c.JSON(404, gin.H{"detail": "link not found"})
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) getNoteForLinks(c *gin.Context) (*database.Note, bool) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		// This means the note ID is improperly formatted
+		Handle404(c)
+		return nil, false
+	}
+	note, err := database.GetNote(api.DB, noteID, getUserIDFromContext(c))
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "note not found.", "noteId": noteID})
+		return nil, false
+	}
+	return note, true
+}
+
+// a note can link to the owner's tasks, pull requests and other notes
+func (api *API) validateNoteLinkTarget(note *database.Note, target noteLinkTarget) error {
+	var err error
+	switch target.Type {
+	case constants.NoteLinkTargetTask:
+		_, err = database.GetTask(api.DB, target.ID, note.UserID)
+	case constants.NoteLinkTargetPullRequest:
+		_, err = database.GetPullRequest(api.DB, target.ID, note.UserID)
+	case constants.NoteLinkTargetNote:
+		if target.ID == note.ID {
+			return errors.New("a note cannot link to itself")
+		}
+		_, err = database.GetNote(api.DB, target.ID, note.UserID)
+	default:
+		return errors.New("invalid target_type, must be one of 'task', 'pull_request' or 'note'")
+	}
+	if err != nil {
+		return errors.New("link target not found")
+	}
+	return nil
+}
+
+// returns the items referenced in the note body, without duplicates
+func getNoteReferences(body string) []noteLinkTarget {
+	targets := []noteLinkTarget{}
+	for _, match := range noteReferenceRegex.FindAllStringSubmatch(body, -1) {
+		targetID, err := primitive.ObjectIDFromHex(match[2])
+		if err != nil {
+			continue
+		}
+		isDuplicate := false
+		for _, target := range targets {
+			if target.ID == targetID {
+				isDuplicate = true
+			}
+		}
+		if !isDuplicate && len(targets) < constants.MAX_NOTE_LINKS {
+			targets = append(targets, noteLinkTarget{ID: targetID, Type: match[1]})
+		}
+	}
+	return targets
+}
+
+// replaces the reference links of the note with the references in its new body, references to items which
+// don't exist are ignored
+func (api *API) syncNoteReferenceLinks(note *database.Note, body string) error {
+	links := []interface{}{}
+	for _, target := range getNoteReferences(body) {
+		if api.validateNoteLinkTarget(note, target) != nil {
+			continue
+		}
+		links = append(links, database.NoteLink{
+			UserID:      note.UserID,
+			NoteID:      note.ID,
+			TargetID:    target.ID,
+			TargetType:  target.Type,
+			IsReference: true,
+			CreatedAt:   primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+		})
+	}
+	linkCollection := database.GetNoteLinkCollection(api.DB)
+	_, err := linkCollection.DeleteMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"note_id": note.ID},
+			{"user_id": note.UserID},
+			{"is_reference": true},
+		}},
+	)
+	if err != nil || len(links) == 0 {
+		return err
+	}
+	_, err = linkCollection.InsertMany(context.Background(), links)
+	return err
+}
+
+// sets the links and backlinks of the owner's notes
+func (api *API) populateNoteLinks(userID primitive.ObjectID, noteResults []*NoteResult) error {
+	if len(noteResults) == 0 {
+		return nil
+	}
+	noteIDs := []primitive.ObjectID{}
+	for _, noteResult := range noteResults {
+		noteIDs = append(noteIDs, noteResult.ID)
+	}
+	var links []database.NoteLink
+	err := database.FindWithCollection(
+		database.GetNoteLinkCollection(api.DB),
+		userID,
+		&[]bson.M{{"$or": []bson.M{
+			{"note_id": bson.M{"$in": noteIDs}},
+			{"target_id": bson.M{"$in": noteIDs}},
+		}}},
+		&links,
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		return err
+	}
+	targets := []noteLinkTarget{}
+	for _, link := range links {
+		targets = append(targets, noteLinkTarget{ID: link.TargetID, Type: link.TargetType}, noteLinkTarget{ID: link.NoteID, Type: constants.NoteLinkTargetNote})
+	}
+	targetResults, err := api.getNoteLinkTargetResults(userID, targets)
+	if err != nil {
+		return err
+	}
+	for _, noteResult := range noteResults {
+		noteResult.Links = []NoteLinkResult{}
+		noteResult.Backlinks = []NoteLinkResult{}
+		for _, link := range links {
+			if link.NoteID == noteResult.ID {
+				noteResult.Links = appendNoteLinkResult(noteResult.Links, targetResults, link.TargetID)
+			}
+			if link.TargetID == noteResult.ID {
+				noteResult.Backlinks = appendNoteLinkResult(noteResult.Backlinks, targetResults, link.NoteID)
+			}
+		}
+	}
+	return nil
+}
+
+// sets the notes which link to each of the owner's tasks
+func (api *API) populateTaskBacklinks(userID primitive.ObjectID, taskResults []*TaskResultV4) error {
+	if len(taskResults) == 0 {
+		return nil
+	}
+	taskIDs := []primitive.ObjectID{}
+	for _, taskResult := range taskResults {
+		taskIDs = append(taskIDs, taskResult.ID)
+	}
+	var links []database.NoteLink
+	err := database.FindWithCollection(
+		database.GetNoteLinkCollection(api.DB),
+		userID,
+		&[]bson.M{{"target_id": bson.M{"$in": taskIDs}}},
+		&links,
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		return err
+	}
+	targets := []noteLinkTarget{}
+	for _, link := range links {
+		targets = append(targets, noteLinkTarget{ID: link.NoteID, Type: constants.NoteLinkTargetNote})
+	}
+	targetResults, err := api.getNoteLinkTargetResults(userID, targets)
+	if err != nil {
+		return err
+	}
+	for _, taskResult := range taskResults {
+		for _, link := range links {
+			if link.TargetID == taskResult.ID {
+				taskResult.Backlinks = appendNoteLinkResult(taskResult.Backlinks, targetResults, link.NoteID)
+			}
+		}
+	}
+	return nil
+}
+
+// looks up the titles and status of the link targets, targets which no longer exist are not returned
+func (api *API) getNoteLinkTargetResults(userID primitive.ObjectID, targets []noteLinkTarget) (map[primitive.ObjectID]NoteLinkResult, error) {
+	targetIDs := map[string][]primitive.ObjectID{}
+	for _, target := range targets {
+		targetIDs[target.Type] = append(targetIDs[target.Type], target.ID)
+	}
+	results := map[primitive.ObjectID]NoteLinkResult{}
+	if len(targetIDs[constants.NoteLinkTargetTask]) > 0 {
+		var tasks []database.Task
+		err := database.FindWithCollection(database.GetTaskCollection(api.DB), userID, &[]bson.M{{"_id": bson.M{"$in": targetIDs[constants.NoteLinkTargetTask]}}}, &tasks, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range tasks {
+			results[task.ID] = NoteLinkResult{
+				ID:        task.ID,
+				Type:      constants.NoteLinkTargetTask,
+				Title:     getStringValue(task.Title),
+				IsDone:    task.IsCompleted != nil && *task.IsCompleted,
+				IsDeleted: task.IsDeleted != nil && *task.IsDeleted,
+			}
+		}
+	}
+	if len(targetIDs[constants.NoteLinkTargetPullRequest]) > 0 {
+		var pullRequests []database.PullRequest
+		err := database.FindWithCollection(database.GetPullRequestCollection(api.DB), userID, &[]bson.M{{"_id": bson.M{"$in": targetIDs[constants.NoteLinkTargetPullRequest]}}}, &pullRequests, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, pullRequest := range pullRequests {
+			results[pullRequest.ID] = NoteLinkResult{
+				ID:     pullRequest.ID,
+				Type:   constants.NoteLinkTargetPullRequest,
+				Title:  pullRequest.Title,
+				IsDone: pullRequest.IsCompleted != nil && *pullRequest.IsCompleted,
+			}
+		}
+	}
+	if len(targetIDs[constants.NoteLinkTargetNote]) > 0 {
+		var notes []database.Note
+		err := database.FindWithCollection(database.GetNoteCollection(api.DB), userID, &[]bson.M{{"_id": bson.M{"$in": targetIDs[constants.NoteLinkTargetNote]}}}, &notes, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, note := range notes {
+			results[note.ID] = NoteLinkResult{
+				ID:        note.ID,
+				Type:      constants.NoteLinkTargetNote,
+				Title:     getStringValue(note.Title),
+				IsDeleted: note.IsDeleted != nil && *note.IsDeleted,
+			}
+		}
+	}
+	return results, nil
+}
+
+// a note can link to the same item both explicitly and with a reference, it is only returned once
+func appendNoteLinkResult(linkResults []NoteLinkResult, targetResults map[primitive.ObjectID]NoteLinkResult, targetID primitive.ObjectID) []NoteLinkResult {
+	targetResult, exists := targetResults[targetID]
+	if !exists {
+		return linkResults
+	}
+	for _, linkResult := range linkResults {
+		if linkResult.ID == targetID {
+			return linkResults
+		}
+	}
+	return append(linkResults, targetResult)
+}
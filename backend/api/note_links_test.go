@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetNoteReferences(t *testing.T) {
+	taskID := primitive.NewObjectID()
+	noteID := primitive.NewObjectID()
+	body := "follow up on [[task:" + taskID.Hex() + "]] and [[note:" + noteID.Hex() + "]], again [[task:" + taskID.Hex() + "]]" +
+		" but not [[event:" + noteID.Hex() + "]] or [[task:1234]]"
+	assert.Equal(t, []noteLinkTarget{
+		{ID: taskID, Type: constants.NoteLinkTargetTask},
+		{ID: noteID, Type: constants.NoteLinkTargetNote},
+	}, getNoteReferences(body))
+	assert.Equal(t, []noteLinkTarget{}, getNoteReferences("no references"))
+}
+
+func TestNoteLinks(t *testing.T) {
+	authToken := login("test_note_links@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	taskTitle := "write launch plan"
+	taskResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+		UserID:   userID,
+		Title:    &taskTitle,
+		SourceID: external.TASK_SOURCE_ID_GT_TASK,
+	})
+	assert.NoError(t, err)
+	taskID := taskResult.InsertedID.(primitive.ObjectID)
+	pullRequestResult, err := database.GetPullRequestCollection(api.DB).InsertOne(context.Background(), database.PullRequest{
+		UserID: userID,
+		Title:  "launch flag",
+	})
+	assert.NoError(t, err)
+	pullRequestID := pullRequestResult.InsertedID.(primitive.ObjectID)
+	otherTitle := "launch retro"
+	otherNoteResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{
+		UserID: userID,
+		Title:  &otherTitle,
+	})
+	assert.NoError(t, err)
+	otherNoteID := otherNoteResult.InsertedID.(primitive.ObjectID)
+
+	body := `{"title":"launch","body":"see [[task:` + taskID.Hex() + `]] and [[task:` + primitive.NewObjectID().Hex() + `]]"}`
+	var createResult map[string]primitive.ObjectID
+	assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "POST", "/notes/create/", bytes.NewBuffer([]byte(body)), http.StatusOK, api), &createResult))
+	noteID := createResult["note_id"]
+	linksURL := "/notes/" + noteID.Hex() + "/links/"
+	getNote := func() *NoteResult {
+		var notes []*NoteResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "GET", "/notes/", nil, http.StatusOK, api), &notes))
+		for _, note := range notes {
+			if note.ID == noteID {
+				return note
+			}
+		}
+		return nil
+	}
+	getTaskBacklinks := func() []NoteLinkResult {
+		var task TaskResultV4
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "GET", "/tasks/detail/"+taskID.Hex()+"/", nil, http.StatusOK, api), &task))
+		return task.Backlinks
+	}
+
+	t.Run("References", func(t *testing.T) {
+		note := getNote()
+		// references to items which don't exist are ignored
+		assert.Equal(t, []NoteLinkResult{{ID: taskID, Type: constants.NoteLinkTargetTask, Title: taskTitle}}, note.Links)
+		assert.Equal(t, []NoteLinkResult{{ID: noteID, Type: constants.NoteLinkTargetNote, Title: "launch"}}, getTaskBacklinks())
+	})
+	t.Run("AddInvalid", func(t *testing.T) {
+		UnauthorizedTest(t, "POST", linksURL, nil)
+		ServeRequest(t, authToken, "POST", linksURL, bytes.NewBuffer([]byte(`{"target_id":"`+pullRequestID.Hex()+`","target_type":"event"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "POST", linksURL, bytes.NewBuffer([]byte(`{"target_id":"`+primitive.NewObjectID().Hex()+`","target_type":"task"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "POST", linksURL, bytes.NewBuffer([]byte(`{"target_id":"`+noteID.Hex()+`","target_type":"note"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "POST", "/notes/"+primitive.NewObjectID().Hex()+"/links/", bytes.NewBuffer([]byte(`{"target_id":"`+taskID.Hex()+`","target_type":"task"}`)), http.StatusNotFound, api)
+	})
+	t.Run("Add", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", linksURL, bytes.NewBuffer([]byte(`{"target_id":"`+pullRequestID.Hex()+`","target_type":"pull_request"}`)), http.StatusOK, api)
+		ServeRequest(t, authToken, "POST", linksURL, bytes.NewBuffer([]byte(`{"target_id":"`+otherNoteID.Hex()+`","target_type":"note"}`)), http.StatusOK, api)
+		// adding the same link again doesn't duplicate it
+		ServeRequest(t, authToken, "POST", linksURL, bytes.NewBuffer([]byte(`{"target_id":"`+otherNoteID.Hex()+`","target_type":"note"}`)), http.StatusOK, api)
+		// a task can be linked both explicitly and with a reference
+		ServeRequest(t, authToken, "POST", linksURL, bytes.NewBuffer([]byte(`{"target_id":"`+taskID.Hex()+`","target_type":"task"}`)), http.StatusOK, api)
+
+		note := getNote()
+		assert.Equal(t, 3, len(note.Links))
+		assert.Equal(t, pullRequestID, note.Links[1].ID)
+		assert.Equal(t, otherNoteID, note.Links[2].ID)
+		assert.Equal(t, 1, len(getTaskBacklinks()))
+	})
+	t.Run("CompletedTask", func(t *testing.T) {
+		_, err := database.GetTaskCollection(api.DB).UpdateOne(context.Background(), bson.M{"_id": taskID}, bson.M{"$set": bson.M{"is_completed": true}})
+		assert.NoError(t, err)
+		note := getNote()
+		assert.True(t, note.Links[0].IsDone)
+	})
+	t.Run("ModifyBody", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/notes/modify/"+noteID.Hex()+"/", bytes.NewBuffer([]byte(`{"body":"no more references"}`)), http.StatusOK, api)
+		// the explicit link to the task is kept
+		assert.Equal(t, 3, len(getNote().Links))
+		ServeRequest(t, authToken, "DELETE", linksURL+"?target_id="+taskID.Hex(), nil, http.StatusOK, api)
+		ServeRequest(t, authToken, "DELETE", linksURL+"?target_id="+taskID.Hex(), nil, http.StatusNotFound, api)
+		assert.Equal(t, 2, len(getNote().Links))
+		assert.Equal(t, 0, len(getTaskBacklinks()))
+	})
+	t.Run("DeleteNote", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", "/notes/delete/"+otherNoteID.Hex()+"/", nil, http.StatusOK, api)
+		note := getNote()
+		assert.Equal(t, 1, len(note.Links))
+		assert.Equal(t, pullRequestID, note.Links[0].ID)
+		count, err := database.GetNoteLinkCollection(api.DB).CountDocuments(context.Background(), bson.M{"target_id": otherNoteID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -25,6 +25,15 @@ type NoteResult struct {
 	LinkedEventStart string             `json:"linked_event_start,omitempty"`
 	LinkedEventEnd   string             `json:"linked_event_end,omitempty"`
 	SharedAccess     string             `json:"shared_access,omitempty"`
+	// set on series notes and the notes of each instance in the series
+	LinkedRecurringEventID string `json:"linked_recurring_event_id,omitempty"`
+	PreviousNoteID         string `json:"previous_note_id,omitempty"`
+	// only returned to the owner of the note
+	SharedWith []database.SharedWithEntry `json:"shared_with,omitempty"`
+	Comments   *[]database.Comment        `json:"comments,omitempty"`
+	// only returned to the owner of the note
+	Links     []NoteLinkResult `json:"links,omitempty"`
+	Backlinks []NoteLinkResult `json:"backlinks,omitempty"`
 }
 
 func (api *API) NotesList(c *gin.Context) {
@@ -45,6 +54,10 @@ func (api *API) NotesList(c *gin.Context) {
 		return
 	}
 	noteResults := api.noteListToNoteResultList(notes)
+	err = api.populateNoteLinks(userID, noteResults)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch note links")
+	}
 	c.JSON(200, noteResults)
 }
 
@@ -93,6 +106,12 @@ func (api *API) noteToNoteResult(note *database.Note) *NoteResult {
 		}
 	}
 	noteResult.SharedAccess = sharedAccess
+	noteResult.LinkedRecurringEventID = note.LinkedRecurringEventID
+	noteResult.SharedWith = note.SharedWith
+	noteResult.Comments = note.Comments
+	if note.PreviousNoteID != primitive.NilObjectID {
+		noteResult.PreviousNoteID = note.PreviousNoteID.Hex()
+	}
 	if note.LinkedEventID != primitive.NilObjectID {
 		noteResult.LinkedEventID = note.LinkedEventID.Hex()
 		calEvent, err := database.GetCalendarEventWithoutUserID(api.DB, note.LinkedEventID)
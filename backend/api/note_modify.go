@@ -97,7 +97,23 @@ func (api *API) NoteModify(c *gin.Context) {
 			CreatedAt:    note.CreatedAt,
 		}
 
-		api.UpdateNoteInDB(c, note, userID, &updatedNote)
+		err = api.UpdateNoteInDBWithError(note, userID, &updatedNote)
+		if err != nil {
+			Handle500(c)
+			return
+		}
+		if updatedNote.Title != nil || updatedNote.Body != nil {
+			err = api.saveNoteRevision(note, updatedNote.Title, updatedNote.Body)
+			if err != nil {
+				api.Logger.Error().Err(err).Msg("failed to save note revision")
+			}
+		}
+		if updatedNote.Body != nil {
+			err = api.syncNoteReferenceLinks(note, *updatedNote.Body)
+			if err != nil {
+				api.Logger.Error().Err(err).Msg("failed to update note links")
+			}
+		}
 	}
 
 	c.JSON(200, gin.H{})
@@ -0,0 +1,292 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"github.com/pmezard/go-difflib/difflib"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type NoteRevisionResult struct {
+	ID        primitive.ObjectID `json:"id"`
+	Title     string             `json:"title"`
+	Body      string             `json:"body,omitempty"`
+	CreatedAt string             `json:"created_at"`
+	UpdatedAt string             `json:"updated_at"`
+}
+
+type NoteDiffLine struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type NoteRevisionDiffResult struct {
+	From  NoteRevisionResult `json:"from"`
+	To    NoteRevisionResult `json:"to"`
+	Lines []NoteDiffLine     `json:"lines"`
+}
+
+// most recent first, without the body of each revision
+func (api *API) NoteRevisionsList(c *gin.Context) {
+	note, ok := api.getNoteForRevisions(c)
+	if !ok {
+		return
+	}
+	var revisions []database.NoteRevision
+	err := database.FindWithCollection(
+		database.GetNoteRevisionCollection(api.DB),
+		note.UserID,
+		&[]bson.M{{"note_id": note.ID}},
+		&revisions,
+		options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(constants.MAX_NOTE_REVISIONS),
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch note revisions")
+		Handle500(c)
+		return
+	}
+	results := []NoteRevisionResult{}
+	for _, revision := range revisions {
+		result := noteRevisionToResult(&revision)
+		result.Body = ""
+		results = append(results, result)
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) NoteRevisionDetails(c *gin.Context) {
+	note, ok := api.getNoteForRevisions(c)
+	if !ok {
+		return
+	}
+	revision, ok := api.getNoteRevision(c, note, c.Param("revision_id"))
+	if !ok {
+		return
+	}
+	c.JSON(200, noteRevisionToResult(revision))
+}
+
+func (api *API) NoteRevisionsDiff(c *gin.Context) {
+	note, ok := api.getNoteForRevisions(c)
+	if !ok {
+		return
+	}
+	fromRevision, ok := api.getNoteRevision(c, note, c.Query("from"))
+	if !ok {
+		return
+	}
+	toRevision, ok := api.getNoteRevision(c, note, c.Query("to"))
+	if !ok {
+		return
+	}
+	c.JSON(200, NoteRevisionDiffResult{
+		From:  noteRevisionToResult(fromRevision),
+		To:    noteRevisionToResult(toRevision),
+		Lines: getNoteDiffLines(fromRevision.Body, toRevision.Body),
+	})
+}
+
+// restoring a revision is saved as a new revision, so it can be undone
+func (api *API) NoteRevisionRestore(c *gin.Context) {
+	note, ok := api.getNoteForRevisions(c)
+	if !ok {
+		return
+	}
+	revision, ok := api.getNoteRevision(c, note, c.Param("revision_id"))
+	if !ok {
+		return
+	}
+	updatedNote := database.Note{
+		Title:     &revision.Title,
+		Body:      &revision.Body,
+		UpdatedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	}
+	err := api.UpdateNoteInDBWithError(note, note.UserID, &updatedNote)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	err = api.insertNoteRevision(note, revision.Title, revision.Body, api.GetCurrentTime())
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to save note revision")
+		Handle500(c)
+		return
+	}
+	err = api.syncNoteReferenceLinks(note, revision.Body)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update note links")
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) getNoteForRevisions(c *gin.Context) (*database.Note, bool) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		// This means the note ID is improperly formatted
+		Handle404(c)
+		return nil, false
+	}
+	note, err := database.GetNote(api.DB, noteID, getUserIDFromContext(c))
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "note not found.", "noteId": noteID})
+		return nil, false
+	}
+	return note, true
+}
+
+func (api *API) getNoteRevision(c *gin.Context, note *database.Note, revisionIDHex string) (*database.NoteRevision, bool) {
+	revisionID, err := primitive.ObjectIDFromHex(revisionIDHex)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "revision not found."})
+		return nil, false
+	}
+	var revision database.NoteRevision
+	err = database.GetNoteRevisionCollection(api.DB).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": revisionID},
+			{"note_id": note.ID},
+			{"user_id": note.UserID},
+		}},
+	).Decode(&revision)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "revision not found."})
+		return nil, false
+	}
+	return &revision, true
+}
+
+// stores the content of the note after a save, the title and body are nil if they were not changed
+func (api *API) saveNoteRevision(note *database.Note, title *string, body *string) error {
+	updatedTitle := note.Title
+	if title != nil {
+		updatedTitle = title
+	}
+	updatedBody := note.Body
+	if body != nil {
+		updatedBody = body
+	}
+	currentTime := api.GetCurrentTime()
+
+	var latestRevision database.NoteRevision
+	err := database.GetNoteRevisionCollection(api.DB).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"note_id": note.ID},
+			{"user_id": note.UserID},
+		}},
+		options.FindOne().SetSort(bson.M{"created_at": -1}),
+	).Decode(&latestRevision)
+	if err == mongo.ErrNoDocuments {
+		// keep the content from before revisions were stored so it can still be restored
+		if note.Title != nil || note.Body != nil {
+			err = api.insertNoteRevision(note, getStringValue(note.Title), getStringValue(note.Body), note.UpdatedAt.Time())
+			if err != nil {
+				return err
+			}
+		}
+	} else if err != nil {
+		return err
+	} else if latestRevision.CreatedAt.Time().After(currentTime.Add(-constants.NOTE_REVISION_COALESCE_WINDOW)) {
+		_, err = database.GetNoteRevisionCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"_id": latestRevision.ID},
+			bson.M{"$set": bson.M{
+				"title":      getStringValue(updatedTitle),
+				"body":       getStringValue(updatedBody),
+				"updated_at": primitive.NewDateTimeFromTime(currentTime),
+			}},
+		)
+		return err
+	}
+	return api.insertNoteRevision(note, getStringValue(updatedTitle), getStringValue(updatedBody), currentTime)
+}
+
+func (api *API) insertNoteRevision(note *database.Note, title string, body string, createdAt time.Time) error {
+	_, err := database.GetNoteRevisionCollection(api.DB).InsertOne(context.Background(), database.NoteRevision{
+		NoteID:    note.ID,
+		UserID:    note.UserID,
+		Title:     title,
+		Body:      body,
+		CreatedAt: primitive.NewDateTimeFromTime(createdAt),
+		UpdatedAt: primitive.NewDateTimeFromTime(createdAt),
+	})
+	if err != nil {
+		return err
+	}
+	return api.pruneNoteRevisions(note)
+}
+
+// only the newest MAX_NOTE_REVISIONS revisions of a note are kept
+func (api *API) pruneNoteRevisions(note *database.Note) error {
+	var staleRevisions []database.NoteRevision
+	err := database.FindWithCollection(
+		database.GetNoteRevisionCollection(api.DB),
+		note.UserID,
+		&[]bson.M{{"note_id": note.ID}},
+		&staleRevisions,
+		options.Find().SetSort(bson.M{"created_at": -1}).SetSkip(constants.MAX_NOTE_REVISIONS).SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil || len(staleRevisions) == 0 {
+		return err
+	}
+	staleRevisionIDs := []primitive.ObjectID{}
+	for _, revision := range staleRevisions {
+		staleRevisionIDs = append(staleRevisionIDs, revision.ID)
+	}
+	_, err = database.GetNoteRevisionCollection(api.DB).DeleteMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": bson.M{"$in": staleRevisionIDs}},
+			{"user_id": note.UserID},
+		}},
+	)
+	return err
+}
+
+func noteRevisionToResult(revision *database.NoteRevision) NoteRevisionResult {
+	return NoteRevisionResult{
+		ID:        revision.ID,
+		Title:     revision.Title,
+		Body:      revision.Body,
+		CreatedAt: revision.CreatedAt.Time().UTC().Format(time.RFC3339),
+		UpdatedAt: revision.UpdatedAt.Time().UTC().Format(time.RFC3339),
+	}
+}
+
+// a line by line diff, changed lines are returned as the deleted lines followed by the inserted lines
+func getNoteDiffLines(from string, to string) []NoteDiffLine {
+	fromLines := strings.Split(from, "\n")
+	toLines := strings.Split(to, "\n")
+	lines := []NoteDiffLine{}
+	for _, opCode := range difflib.NewMatcher(fromLines, toLines).GetOpCodes() {
+		if opCode.Tag == 'e' {
+			for _, line := range fromLines[opCode.I1:opCode.I2] {
+				lines = append(lines, NoteDiffLine{Type: constants.NoteDiffLineEqual, Text: line})
+			}
+			continue
+		}
+		for _, line := range fromLines[opCode.I1:opCode.I2] {
+			lines = append(lines, NoteDiffLine{Type: constants.NoteDiffLineDelete, Text: line})
+		}
+		for _, line := range toLines[opCode.J1:opCode.J2] {
+			lines = append(lines, NoteDiffLine{Type: constants.NoteDiffLineInsert, Text: line})
+		}
+	}
+	return lines
+}
+
+func getStringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
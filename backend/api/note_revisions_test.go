@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetNoteDiffLines(t *testing.T) {
+	assert.Equal(t, []NoteDiffLine{
+		{Type: constants.NoteDiffLineEqual, Text: "# Agenda"},
+		{Type: constants.NoteDiffLineDelete, Text: "- budget"},
+		{Type: constants.NoteDiffLineInsert, Text: "- hiring"},
+		{Type: constants.NoteDiffLineEqual, Text: "- roadmap"},
+		{Type: constants.NoteDiffLineInsert, Text: "- offsite"},
+	}, getNoteDiffLines("# Agenda\n- budget\n- roadmap", "# Agenda\n- hiring\n- roadmap\n- offsite"))
+}
+
+func TestNoteRevisions(t *testing.T) {
+	authToken := login("test_note_revisions@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	currentTime := time.Date(2022, time.November, 14, 9, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+
+	title := "v0"
+	body := "line1"
+	insertResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{
+		UserID:    userID,
+		Title:     &title,
+		Body:      &body,
+		UpdatedAt: primitive.NewDateTimeFromTime(currentTime.AddDate(0, 0, -1)),
+	})
+	assert.NoError(t, err)
+	noteID := insertResult.InsertedID.(primitive.ObjectID)
+	noteURL := "/notes/" + noteID.Hex() + "/"
+	modifyNote := func(params string, modifyTime time.Time) {
+		api.OverrideTime = &modifyTime
+		ServeRequest(t, authToken, "PATCH", "/notes/modify/"+noteID.Hex()+"/", bytes.NewBuffer([]byte(params)), http.StatusOK, api)
+	}
+	listRevisions := func() []NoteRevisionResult {
+		var revisions []NoteRevisionResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "GET", noteURL+"revisions/", nil, http.StatusOK, api), &revisions))
+		return revisions
+	}
+
+	UnauthorizedTest(t, "GET", noteURL+"revisions/", nil)
+	modifyNote(`{"body":"line1\nline2"}`, currentTime)
+	// autosaves shortly after are merged into the latest revision
+	modifyNote(`{"body":"line1\nline2\nline3"}`, currentTime.Add(time.Minute))
+	modifyNote(`{"title":"v1"}`, currentTime.Add(10*time.Minute))
+
+	revisions := listRevisions()
+	assert.Equal(t, 3, len(revisions))
+	assert.Equal(t, "v1", revisions[0].Title)
+	assert.Equal(t, "2022-11-14T09:00:00Z", revisions[1].CreatedAt)
+	assert.Equal(t, "2022-11-14T09:01:00Z", revisions[1].UpdatedAt)
+	assert.Equal(t, "v0", revisions[2].Title)
+	baselineID := revisions[2].ID.Hex()
+	latestID := revisions[0].ID.Hex()
+
+	t.Run("Details", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", noteURL+"revisions/"+latestID+"/", nil, http.StatusOK, api)
+		var revision NoteRevisionResult
+		assert.NoError(t, json.Unmarshal(body, &revision))
+		assert.Equal(t, "line1\nline2\nline3", revision.Body)
+		ServeRequest(t, authToken, "GET", noteURL+"revisions/"+primitive.NewObjectID().Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("Diff", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", noteURL+"revisions/diff/?from="+baselineID+"&to="+latestID, nil, http.StatusOK, api)
+		var diff NoteRevisionDiffResult
+		assert.NoError(t, json.Unmarshal(body, &diff))
+		assert.Equal(t, "v0", diff.From.Title)
+		assert.Equal(t, []NoteDiffLine{
+			{Type: constants.NoteDiffLineEqual, Text: "line1"},
+			{Type: constants.NoteDiffLineInsert, Text: "line2"},
+			{Type: constants.NoteDiffLineInsert, Text: "line3"},
+		}, diff.Lines)
+	})
+	t.Run("Restore", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", noteURL+"revisions/"+baselineID+"/restore/", nil, http.StatusOK, api)
+		note, err := database.GetNote(api.DB, noteID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "v0", *note.Title)
+		assert.Equal(t, "line1", *note.Body)
+		assert.Equal(t, 4, len(listRevisions()))
+	})
+	t.Run("PruneOldRevisions", func(t *testing.T) {
+		oldRevisions := []interface{}{}
+		for i := 0; i < constants.MAX_NOTE_REVISIONS; i++ {
+			oldRevisions = append(oldRevisions, database.NoteRevision{
+				NoteID:    noteID,
+				UserID:    userID,
+				Title:     "old",
+				CreatedAt: primitive.NewDateTimeFromTime(currentTime.AddDate(0, 0, -2).Add(time.Duration(i) * time.Minute)),
+			})
+		}
+		_, err := database.GetNoteRevisionCollection(api.DB).InsertMany(context.Background(), oldRevisions)
+		assert.NoError(t, err)
+
+		modifyNote(`{"title":"v2"}`, currentTime.Add(time.Hour))
+		count, err := database.GetNoteRevisionCollection(api.DB).CountDocuments(context.Background(), bson.M{"note_id": noteID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(constants.MAX_NOTE_REVISIONS), count)
+		// the oldest revisions are the ones deleted
+		revisions := listRevisions()
+		assert.Equal(t, "v2", revisions[0].Title)
+		assert.Equal(t, baselineID, revisions[4].ID.Hex())
+	})
+	t.Run("HardDeletePurgesRevisions", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", "/notes/delete/"+noteID.Hex()+"/", nil, http.StatusOK, api)
+		ServeRequest(t, authToken, "DELETE", "/notes/delete/"+noteID.Hex()+"/", nil, http.StatusNotFound, api)
+		count, err := database.GetNoteRevisionCollection(api.DB).CountDocuments(context.Background(), bson.M{"note_id": noteID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// resolves the note for an event, falling back to the note of its recurring event series until the instance has its own note
+func (api *API) getEventNoteID(event *database.CalendarEvent, userID primitive.ObjectID) string {
+	noteID := api.getLinkedNoteID(event.ID, userID)
+	if noteID != "" || event.RecurringEventID == "" {
+		return noteID
+	}
+	seriesNote, err := api.getSeriesNote(userID, event.RecurringEventID)
+	if err != nil {
+		return ""
+	}
+	return seriesNote.ID.Hex()
+}
+
+// EventNoteCreate returns the note of an instance of a recurring event, creating it from the series note when it is opened
+// before the instance is synced close to its start
+func (api *API) EventNoteCreate(c *gin.Context) {
+	eventID, err := primitive.ObjectIDFromHex(c.Param("event_id"))
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "event ID missing or malformed"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	event, err := database.GetCalendarEvent(api.DB, eventID, userID)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "event not found", "eventID": eventID})
+		return
+	}
+	noteID := api.getLinkedNoteID(event.ID, userID)
+	if noteID != "" {
+		c.JSON(200, gin.H{"note_id": noteID})
+		return
+	}
+	if event.RecurringEventID == "" {
+		c.JSON(400, gin.H{"detail": "event is not part of a recurring event series"})
+		return
+	}
+	seriesNote, err := api.getSeriesNote(userID, event.RecurringEventID)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "recurring event series has no note"})
+		return
+	}
+	instanceNote, err := api.getOrCreateSeriesInstanceNote(userID, event, seriesNote)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create note for recurring event")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{"note_id": instanceNote.ID.Hex()})
+}
+
+// creates the notes for synced instances of recurring event series which are about to start, so each
+// meeting of the series gets its own note before it is opened
+func (api *API) createUpcomingSeriesInstanceNotes(userID primitive.ObjectID, events []*database.CalendarEvent) {
+	currentTime := api.GetCurrentTime()
+	seriesNotes := make(map[string]*database.Note)
+	for _, event := range events {
+		if event == nil || event.ID == primitive.NilObjectID || event.RecurringEventID == "" {
+			continue
+		}
+		if event.DatetimeEnd.Time().Before(currentTime) || event.DatetimeStart.Time().After(currentTime.Add(constants.SERIES_NOTE_CREATE_AHEAD)) {
+			continue
+		}
+		seriesNote, exists := seriesNotes[event.RecurringEventID]
+		if !exists {
+			// series without a note are cached as nil so they are only looked up once
+			seriesNote, _ = api.getSeriesNote(userID, event.RecurringEventID)
+			seriesNotes[event.RecurringEventID] = seriesNote
+		}
+		if seriesNote == nil || api.getLinkedNoteID(event.ID, userID) != "" {
+			continue
+		}
+		_, err := api.getOrCreateSeriesInstanceNote(userID, event, seriesNote)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to create note for recurring event")
+		}
+	}
+}
+
+func (api *API) getSeriesNote(userID primitive.ObjectID, recurringEventID string) (*database.Note, error) {
+	var note database.Note
+	err := database.GetNoteCollection(api.DB).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"linked_recurring_event_id": recurringEventID},
+			{"linked_event_id": bson.M{"$exists": false}},
+			{"is_deleted": bson.M{"$ne": true}},
+		}},
+	).Decode(&note)
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// the instance note starts from the series note and links to the note of the previous instance
+func (api *API) getOrCreateSeriesInstanceNote(userID primitive.ObjectID, event *database.CalendarEvent, seriesNote *database.Note) (*database.Note, error) {
+	previousNoteID, err := api.getPreviousSeriesInstanceNoteID(userID, event)
+	if err != nil {
+		return nil, err
+	}
+
+	now := primitive.NewDateTimeFromTime(api.GetCurrentTime())
+	isDeleted := false
+	var note database.Note
+	// upsert so that loading the same event concurrently only creates one note
+	err = database.GetNoteCollection(api.DB).FindOneAndUpdate(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"linked_event_id": event.ID},
+			{"is_deleted": bson.M{"$ne": true}},
+		}},
+		bson.M{"$setOnInsert": database.Note{
+			UserID:                 userID,
+			LinkedEventID:          event.ID,
+			Title:                  seriesNote.Title,
+			Body:                   seriesNote.Body,
+			Author:                 seriesNote.Author,
+			CreatedAt:              now,
+			UpdatedAt:              now,
+			IsDeleted:              &isDeleted,
+			LinkedRecurringEventID: event.RecurringEventID,
+			PreviousNoteID:         previousNoteID,
+		}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&note)
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// returns the note of the latest earlier instance of the series which has a note
+func (api *API) getPreviousSeriesInstanceNoteID(userID primitive.ObjectID, event *database.CalendarEvent) (primitive.ObjectID, error) {
+	var previousEvents []database.CalendarEvent
+	err := database.FindWithCollection(
+		database.GetCalendarEventCollection(api.DB),
+		userID,
+		&[]bson.M{
+			{"recurring_event_id": event.RecurringEventID},
+			{"datetime_start": bson.M{"$lt": event.DatetimeStart}},
+		},
+		&previousEvents,
+		options.Find().SetSort(bson.M{"datetime_start": -1}),
+	)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	previousEventIDs := []primitive.ObjectID{}
+	for _, previousEvent := range previousEvents {
+		previousEventIDs = append(previousEventIDs, previousEvent.ID)
+	}
+	if len(previousEventIDs) == 0 {
+		return primitive.NilObjectID, nil
+	}
+	var notes []database.Note
+	err = database.FindWithCollection(
+		database.GetNoteCollection(api.DB),
+		userID,
+		&[]bson.M{
+			{"linked_event_id": bson.M{"$in": previousEventIDs}},
+			{"is_deleted": bson.M{"$ne": true}},
+		},
+		&notes,
+		nil,
+	)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	eventIDToNoteID := make(map[primitive.ObjectID]primitive.ObjectID)
+	for _, note := range notes {
+		eventIDToNoteID[note.LinkedEventID] = note.ID
+	}
+	for _, previousEventID := range previousEventIDs {
+		if noteID, exists := eventIDToNoteID[previousEventID]; exists {
+			return noteID, nil
+		}
+	}
+	return primitive.NilObjectID, nil
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSeriesNotes(t *testing.T) {
+	authToken := login("test_series_notes@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	currentTime := time.Date(2022, time.November, 14, 9, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+
+	createInstance := func(start time.Time) *database.CalendarEvent {
+		event := database.CalendarEvent{
+			UserID:           userID,
+			Title:            "1:1",
+			RecurringEventID: "weekly_one_on_one",
+			DatetimeStart:    primitive.NewDateTimeFromTime(start),
+			DatetimeEnd:      primitive.NewDateTimeFromTime(start.Add(30 * time.Minute)),
+		}
+		insertResult, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), event)
+		assert.NoError(t, err)
+		event.ID = insertResult.InsertedID.(primitive.ObjectID)
+		return &event
+	}
+	thisWeek := createInstance(currentTime.Add(time.Hour))
+	nextWeek := createInstance(currentTime.AddDate(0, 0, 7))
+
+	t.Run("CreateRecurringEventNotFound", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/notes/create/", bytes.NewBuffer([]byte(`{"title":"1:1 notes","linked_recurring_event_id":"missing"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"linked recurring event not found: missing"}`, string(body))
+	})
+	t.Run("CreateLinkedToBoth", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/notes/create/", bytes.NewBuffer([]byte(`{"title":"1:1 notes","linked_event_id":"`+thisWeek.ID.Hex()+`","linked_recurring_event_id":"weekly_one_on_one"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"a note cannot be linked to both an event and a recurring event"}`, string(body))
+	})
+	t.Run("NoSeriesNote", func(t *testing.T) {
+		assert.Equal(t, "", api.getEventNoteID(thisWeek, userID))
+	})
+
+	ServeRequest(t, authToken, "POST", "/notes/create/", bytes.NewBuffer([]byte(`{"title":"1:1 notes","body":"## Agenda","linked_recurring_event_id":"weekly_one_on_one"}`)), http.StatusOK, api)
+	seriesNote, err := api.getSeriesNote(userID, "weekly_one_on_one")
+	assert.NoError(t, err)
+
+	t.Run("CreateDuplicateSeriesNote", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/notes/create/", bytes.NewBuffer([]byte(`{"title":"1:1 notes","linked_recurring_event_id":"weekly_one_on_one"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"recurring event already has a note"}`, string(body))
+	})
+	t.Run("InstanceUsesSeriesNote", func(t *testing.T) {
+		assert.Equal(t, seriesNote.ID.Hex(), api.getEventNoteID(thisWeek, userID))
+		assert.Equal(t, seriesNote.ID.Hex(), api.getEventNoteID(nextWeek, userID))
+		// loading the event does not create a note for the instance
+		assert.Equal(t, "", api.getLinkedNoteID(thisWeek.ID, userID))
+	})
+	t.Run("CreateInstanceNoteBadEventID", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/events/note/123/", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"event ID missing or malformed"}`, string(body))
+	})
+	t.Run("CreateInstanceNoteEventNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/events/note/"+primitive.NewObjectID().Hex()+"/", nil, http.StatusNotFound, api)
+	})
+
+	createInstanceNote := func(event *database.CalendarEvent) *database.Note {
+		body := ServeRequest(t, authToken, "POST", "/events/note/"+event.ID.Hex()+"/", nil, http.StatusOK, api)
+		var result struct {
+			NoteID string `json:"note_id"`
+		}
+		assert.NoError(t, json.Unmarshal(body, &result))
+		noteID, err := primitive.ObjectIDFromHex(result.NoteID)
+		assert.NoError(t, err)
+		note, err := database.GetNote(api.DB, noteID, userID)
+		assert.NoError(t, err)
+		return note
+	}
+
+	t.Run("CreateInstanceNote", func(t *testing.T) {
+		note := createInstanceNote(thisWeek)
+		assert.NotEqual(t, seriesNote.ID, note.ID)
+		assert.Equal(t, "## Agenda", *note.Body)
+		assert.Equal(t, thisWeek.ID, note.LinkedEventID)
+		assert.Equal(t, primitive.NilObjectID, note.PreviousNoteID)
+		assert.Equal(t, note.ID.Hex(), api.getEventNoteID(thisWeek, userID))
+		// the same note is returned once it has been created
+		assert.Equal(t, note.ID, createInstanceNote(thisWeek).ID)
+	})
+	t.Run("CreateInstanceNoteLinksPreviousByEventStart", func(t *testing.T) {
+		previousNoteID := api.getLinkedNoteID(thisWeek.ID, userID)
+		// the note of a later instance is created first, so it is the most recently created note
+		inTwoWeeks := createInstance(currentTime.AddDate(0, 0, 14))
+		laterNote := createInstanceNote(inTwoWeeks)
+		assert.Equal(t, previousNoteID, laterNote.PreviousNoteID.Hex())
+
+		note := createInstanceNote(nextWeek)
+		assert.Equal(t, previousNoteID, note.PreviousNoteID.Hex())
+		assert.Equal(t, previousNoteID, api.noteToNoteResult(note).PreviousNoteID)
+	})
+	t.Run("CreateInstanceNoteAfterDelete", func(t *testing.T) {
+		previousNoteID := api.getLinkedNoteID(thisWeek.ID, userID)
+		ServeRequest(t, authToken, "PATCH", "/notes/modify/"+previousNoteID+"/", bytes.NewBuffer([]byte(`{"is_deleted":true}`)), http.StatusOK, api)
+		note := createInstanceNote(thisWeek)
+		assert.NotEqual(t, previousNoteID, note.ID.Hex())
+		assert.Equal(t, "## Agenda", *note.Body)
+	})
+	t.Run("SyncCreatesUpcomingInstanceNotes", func(t *testing.T) {
+		previousNoteID := api.getLinkedNoteID(thisWeek.ID, userID)
+		endedInstance := createInstance(currentTime.Add(-2 * time.Hour))
+		upcomingInstance := createInstance(currentTime.Add(3 * time.Hour))
+		laterInstance := createInstance(currentTime.AddDate(0, 0, 21))
+		api.createUpcomingSeriesInstanceNotes(userID, []*database.CalendarEvent{endedInstance, upcomingInstance, laterInstance})
+
+		noteID := api.getLinkedNoteID(upcomingInstance.ID, userID)
+		assert.NotEqual(t, "", noteID)
+		assert.Equal(t, noteID, api.getEventNoteID(upcomingInstance, userID))
+		noteObjectID, err := primitive.ObjectIDFromHex(noteID)
+		assert.NoError(t, err)
+		note, err := database.GetNote(api.DB, noteObjectID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "## Agenda", *note.Body)
+		assert.Equal(t, previousNoteID, note.PreviousNoteID.Hex())
+		// instances which ended or are further out keep using the series note
+		assert.Equal(t, "", api.getLinkedNoteID(endedInstance.ID, userID))
+		assert.Equal(t, "", api.getLinkedNoteID(laterInstance.ID, userID))
+
+		// syncing again keeps the existing note
+		api.createUpcomingSeriesInstanceNotes(userID, []*database.CalendarEvent{upcomingInstance})
+		assert.Equal(t, noteID, api.getLinkedNoteID(upcomingInstance.ID, userID))
+	})
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NotificationResult struct {
+	ID        primitive.ObjectID `json:"id"`
+	TaskID    primitive.ObjectID `json:"task_id,omitempty"`
+	NoteID    primitive.ObjectID `json:"note_id,omitempty"`
+	Type      string             `json:"type"`
+	Title     string             `json:"title"`
+	Body      string             `json:"body"`
+	IsRead    bool               `json:"is_read"`
+	CreatedAt string             `json:"created_at"`
+}
+
+type NotificationModifyParams struct {
+	IsRead *bool `json:"is_read"`
+}
+
+func (api *API) NotificationsList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	notifications, err := database.GetInboxNotifications(api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	results := []NotificationResult{}
+	for _, notification := range *notifications {
+		results = append(results, NotificationResult{
+			ID:        notification.ID,
+			TaskID:    notification.TaskID,
+			NoteID:    notification.NoteID,
+			Type:      notification.Type,
+			Title:     notification.Title,
+			Body:      notification.Body,
+			IsRead:    notification.IsRead,
+			CreatedAt: notification.CreatedAt.Time().UTC().Format(time.RFC3339),
+		})
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) NotificationModify(c *gin.Context) {
+	notificationID, err := primitive.ObjectIDFromHex(c.Param("notification_id"))
+	if err != nil {
+		// This means the notification ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	var modifyParams NotificationModifyParams
+	err = c.BindJSON(&modifyParams)
+	if err != nil || modifyParams.IsRead == nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	result, err := database.GetNotificationCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": notificationID},
+			{"user_id": userID},
+		}},
+		bson.M{"$set": bson.M{"is_read": *modifyParams.IsRead}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update notification")
+		Handle500(c)
+		return
+	}
+	if result.MatchedCount != 1 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
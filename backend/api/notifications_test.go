@@ -0,0 +1,73 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNotifications(t *testing.T) {
+	authToken := login("test_notifications@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	notificationCollection := database.GetNotificationCollection(api.DB)
+
+	createdAt := time.Date(2023, time.March, 10, 9, 0, 0, 0, time.UTC)
+	taskID := primitive.NewObjectID()
+	insertResult, err := notificationCollection.InsertOne(context.Background(), database.Notification{
+		UserID:    userID,
+		TaskID:    taskID,
+		Type:      constants.NotificationTypeTaskReminder,
+		Title:     "Reminder: task",
+		Body:      "\"task\" is due Friday, March 10",
+		Channels:  []string{constants.NotificationChannelInApp},
+		CreatedAt: primitive.NewDateTimeFromTime(createdAt),
+	})
+	assert.NoError(t, err)
+	notificationID := insertResult.InsertedID.(primitive.ObjectID)
+	// only notifications delivered in app show up in the inbox
+	_, err = notificationCollection.InsertOne(context.Background(), database.Notification{
+		UserID:   userID,
+		Channels: []string{constants.NotificationChannelEmail},
+	})
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, "GET", "/notifications/", nil)
+	t.Run("ListSuccess", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/notifications/", nil, http.StatusOK, api)
+		var results []NotificationResult
+		assert.NoError(t, json.Unmarshal(body, &results))
+		assert.Equal(t, []NotificationResult{{
+			ID:        notificationID,
+			TaskID:    taskID,
+			Type:      constants.NotificationTypeTaskReminder,
+			Title:     "Reminder: task",
+			Body:      "\"task\" is due Friday, March 10",
+			IsRead:    false,
+			CreatedAt: "2023-03-10T09:00:00Z",
+		}}, results)
+	})
+	t.Run("ModifyInvalidParams", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/notifications/modify/"+notificationID.Hex()+"/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("ModifyNotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/notifications/modify/invalid/", bytes.NewBuffer([]byte(`{"is_read": true}`)), http.StatusNotFound, api)
+		ServeRequest(t, authToken, "PATCH", "/notifications/modify/"+primitive.NewObjectID().Hex()+"/", bytes.NewBuffer([]byte(`{"is_read": true}`)), http.StatusNotFound, api)
+	})
+	t.Run("ModifySuccess", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/notifications/modify/"+notificationID.Hex()+"/", bytes.NewBuffer([]byte(`{"is_read": true}`)), http.StatusOK, api)
+		var notification database.Notification
+		assert.NoError(t, notificationCollection.FindOne(context.Background(), bson.M{"_id": notificationID}).Decode(&notification))
+		assert.True(t, notification.IsRead)
+	})
+}
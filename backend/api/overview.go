@@ -90,17 +90,17 @@ func (api *API) OverviewViewsList(c *gin.Context) {
 		return
 	}
 
-	timezoneOffset, err := GetTimezoneOffsetFromHeader(c)
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
 	if err != nil {
-		c.JSON(400, gin.H{"error": err.Error()})
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
 		return
 	}
 
-	userID := getUserIDFromContext(c)
-	_, err = database.GetUser(api.DB, userID)
+	timezoneOffset, err := api.getUserTimezoneOffset(c, user)
 	if err != nil {
-		api.Logger.Error().Err(err).Msg("failed to find user")
-		Handle500(c)
+		c.JSON(400, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -163,6 +163,10 @@ func (api *API) GetOverviewResults(views []database.View, userID primitive.Objec
 			singleOverviewResult, err = api.GetMeetingPreparationOverviewResult(view, userID, timezoneOffset, showMovedOrDeleted, ignoreMeetingPreparation)
 		case string(constants.ViewDueToday):
 			singleOverviewResult, err = api.GetDueTodayOverviewResult(view, userID, timezoneOffset)
+		case string(constants.ViewSnoozed):
+			singleOverviewResult, err = api.GetSnoozedOverviewResult(view, userID)
+		case string(constants.ViewDelegated):
+			singleOverviewResult, err = api.GetDelegatedOverviewResult(view, userID)
 		default:
 			err = errors.New("invalid view type")
 		}
@@ -193,6 +197,7 @@ func (api *API) GetTaskSectionOverviewResult(view database.View, userID primitiv
 	tasks, err := database.GetTasks(api.DB, userID, &[]bson.M{
 		{"is_deleted": bson.M{"$ne": true}},
 		{"id_task_section": view.TaskSectionID},
+		database.GetNotSnoozedFilter(api.GetCurrentTime()),
 	}, nil)
 	if err != nil {
 		return nil, err
@@ -317,7 +322,7 @@ func (api *API) UpdateViewsLinkedStatus(views *[]database.View, userID primitive
 			return errors.New("invalid user")
 		}
 		var serviceID string
-		if view.Type == string(constants.ViewTaskSection) || view.Type == string(constants.ViewMeetingPreparation) || view.Type == string(constants.ViewDueToday) {
+		if view.Type == string(constants.ViewTaskSection) || view.Type == string(constants.ViewMeetingPreparation) || view.Type == string(constants.ViewDueToday) || view.Type == string(constants.ViewSnoozed) || view.Type == string(constants.ViewDelegated) {
 			serviceID = external.TaskServiceGeneralTask.ID
 		} else if view.Type == string(constants.ViewJira) {
 			serviceID = external.TaskServiceAtlassian.ID
@@ -383,6 +388,7 @@ func (api *API) GetJiraOverviewResult(view database.View, userID primitive.Objec
 		{"is_completed": false},
 		{"is_deleted": bson.M{"$ne": true}},
 		{"source_id": external.TASK_SOURCE_ID_JIRA},
+		database.GetNotSnoozedFilter(api.GetCurrentTime()),
 	}, nil)
 	if err != nil {
 		return nil, err
@@ -431,6 +437,7 @@ func (api *API) GetLinearOverviewResult(view database.View, userID primitive.Obj
 		{"is_completed": false},
 		{"is_deleted": bson.M{"$ne": true}},
 		{"source_id": external.TASK_SOURCE_ID_LINEAR},
+		database.GetNotSnoozedFilter(api.GetCurrentTime()),
 	}, nil)
 	if err != nil {
 		return nil, err
@@ -479,6 +486,7 @@ func (api *API) GetSlackOverviewResult(view database.View, userID primitive.Obje
 		{"is_completed": false},
 		{"is_deleted": bson.M{"$ne": true}},
 		{"source_id": external.TASK_SOURCE_ID_SLACK_SAVED},
+		database.GetNotSnoozedFilter(api.GetCurrentTime()),
 	}, nil)
 	if err != nil {
 		return nil, err
@@ -683,6 +691,7 @@ func (api *API) GetDueTodayOverviewResult(view database.View, userID primitive.O
 		{"due_date": bson.M{"$ne": primitive.NewDateTimeFromTime(time.Time{})}},
 		{"due_date": bson.M{"$ne": primitive.NewDateTimeFromTime(time.Unix(0, 0))}},
 		{"due_date": bson.M{"$gte": primitive.NewDateTimeFromTime(time.Unix(63090000, 0))}},
+		database.GetNotSnoozedFilter(api.GetCurrentTime()),
 	}, nil)
 	if err != nil {
 		return nil, err
@@ -715,6 +724,66 @@ func (api *API) GetDueTodayOverviewResult(view database.View, userID primitive.O
 	return &result, nil
 }
 
+func (api *API) GetSnoozedOverviewResult(view database.View, userID primitive.ObjectID) (*OverviewResult[TaskResult], error) {
+	if view.UserID != userID {
+		return nil, errors.New("invalid user")
+	}
+	snoozedTasks, err := database.GetSnoozedTasks(api.DB, userID, api.GetCurrentTime())
+	if err != nil {
+		return nil, err
+	}
+	// tasks are sorted by the time they will resurface
+	taskResults := api.taskListToTaskResultList(snoozedTasks, userID)
+	for idx, result := range taskResults {
+		result.IDOrdering = idx
+	}
+
+	return &OverviewResult[TaskResult]{
+		ID:            view.ID,
+		Name:          constants.ViewSnoozedName,
+		Logo:          external.TaskServiceGeneralTask.LogoV2,
+		Type:          constants.ViewSnoozed,
+		IsLinked:      true,
+		Sources:       []SourcesResult{},
+		TaskSectionID: view.TaskSectionID,
+		IsReorderable: view.IsReorderable,
+		IDOrdering:    view.IDOrdering,
+		ViewItems:     taskResults,
+		ViewItemIDs:   GetTaskSectionViewItemIDs(taskResults),
+	}, nil
+}
+
+// the tasks the user assigned to other people, so they can follow their status
+func (api *API) GetDelegatedOverviewResult(view database.View, userID primitive.ObjectID) (*OverviewResult[TaskResult], error) {
+	if view.UserID != userID {
+		return nil, errors.New("invalid user")
+	}
+	completedAfter := api.GetCurrentTime().Add(-time.Duration(constants.DELEGATED_TASK_COMPLETED_LOOKBACK_SECONDS) * time.Second)
+	delegatedTasks, err := database.GetDelegatedTasks(api.DB, userID, completedAfter)
+	if err != nil {
+		return nil, err
+	}
+	// tasks are sorted by the time they were assigned, most recent first
+	taskResults := api.taskListToTaskResultList(delegatedTasks, userID)
+	for idx, result := range taskResults {
+		result.IDOrdering = idx
+	}
+
+	return &OverviewResult[TaskResult]{
+		ID:            view.ID,
+		Name:          constants.ViewDelegatedName,
+		Logo:          external.TaskServiceGeneralTask.LogoV2,
+		Type:          constants.ViewDelegated,
+		IsLinked:      true,
+		Sources:       []SourcesResult{},
+		TaskSectionID: view.TaskSectionID,
+		IsReorderable: view.IsReorderable,
+		IDOrdering:    view.IDOrdering,
+		ViewItems:     taskResults,
+		ViewItemIDs:   GetTaskSectionViewItemIDs(taskResults),
+	}, nil
+}
+
 func reorderTaskResultsByDueDate(taskResults []*TaskResult) []*TaskResult {
 	sort.SliceStable(taskResults, func(i, j int) bool {
 		a := taskResults[i]
@@ -883,8 +952,11 @@ func (api *API) GetMeetingPrepTaskResultWithAutocompletion(userID primitive.Obje
 	taskCollection := database.GetTaskCollection(api.DB)
 	result := []*TaskResult{}
 	for _, task := range *tasks {
-		// if meeting has ended or linked event no longer exists, mark task as complete
-		count, err := eventCollection.CountDocuments(context.Background(), bson.M{"_id": task.MeetingPreparationParams.CalendarEventID})
+		// if meeting has ended or linked event no longer exists or was declined, mark task as complete
+		count, err := eventCollection.CountDocuments(context.Background(), bson.M{"$and": []bson.M{
+			{"_id": task.MeetingPreparationParams.CalendarEventID},
+			{"self_response_status": bson.M{"$ne": constants.RSVPStatusDeclined}},
+		}})
 		if err != nil {
 			return nil, err
 		}
@@ -984,7 +1056,7 @@ func (api *API) OverviewViewAdd(c *gin.Context) {
 			return
 		}
 		githubID = *viewCreateParams.GithubID
-	} else if viewCreateParams.Type != string(constants.ViewJira) && viewCreateParams.Type != string(constants.ViewLinear) && viewCreateParams.Type != string(constants.ViewSlack) && viewCreateParams.Type != string(constants.ViewMeetingPreparation) && viewCreateParams.Type != string(constants.ViewDueToday) {
+	} else if viewCreateParams.Type != string(constants.ViewJira) && viewCreateParams.Type != string(constants.ViewLinear) && viewCreateParams.Type != string(constants.ViewSlack) && viewCreateParams.Type != string(constants.ViewMeetingPreparation) && viewCreateParams.Type != string(constants.ViewDueToday) && viewCreateParams.Type != string(constants.ViewSnoozed) && viewCreateParams.Type != string(constants.ViewDelegated) {
 		c.JSON(400, gin.H{"detail": "unsupported 'type'"})
 		return
 	}
@@ -1038,7 +1110,7 @@ func (api *API) ViewDoesExist(db *mongo.Database, userID primitive.ObjectID, par
 			return false, errors.New("'github_id' is required for github type views")
 		}
 		dbQuery["$and"] = append(dbQuery["$and"].([]bson.M), bson.M{"github_id": *params.GithubID})
-	} else if params.Type != string(constants.ViewLinear) && params.Type != string(constants.ViewSlack) && params.Type != string(constants.ViewJira) && params.Type != string(constants.ViewMeetingPreparation) && params.Type != string(constants.ViewDueToday) {
+	} else if params.Type != string(constants.ViewLinear) && params.Type != string(constants.ViewSlack) && params.Type != string(constants.ViewJira) && params.Type != string(constants.ViewMeetingPreparation) && params.Type != string(constants.ViewDueToday) && params.Type != string(constants.ViewSnoozed) && params.Type != string(constants.ViewDelegated) {
 		return false, errors.New("unsupported view type")
 	}
 	count, err := viewCollection.CountDocuments(context.Background(), dbQuery)
@@ -1249,6 +1321,32 @@ func (api *API) OverviewSupportedViewsList(c *gin.Context) {
 				},
 			},
 		},
+		{
+			Type:     constants.ViewSnoozed,
+			Name:     "Snoozed Tasks",
+			Logo:     external.TaskServiceGeneralTask.LogoV2,
+			IsNested: false,
+			IsLinked: true,
+			Views: []SupportedViewItem{
+				{
+					Name:    constants.ViewSnoozedName,
+					IsAdded: false,
+				},
+			},
+		},
+		{
+			Type:     constants.ViewDelegated,
+			Name:     "Delegated Tasks",
+			Logo:     external.TaskServiceGeneralTask.LogoV2,
+			IsNested: false,
+			IsLinked: true,
+			Views: []SupportedViewItem{
+				{
+					Name:    constants.ViewDelegatedName,
+					IsAdded: false,
+				},
+			},
+		},
 		{
 			Type:     constants.ViewTaskSection,
 			Name:     "Task Folders",
@@ -1398,7 +1496,7 @@ func (api *API) getViewFromSupportedView(db *mongo.Database, userID primitive.Ob
 		return api.getView(db, userID, viewType, &[]bson.M{
 			{"task_section_id": view.TaskSectionID},
 		})
-	} else if slices.Contains([]constants.ViewType{constants.ViewJira, constants.ViewLinear, constants.ViewSlack, constants.ViewMeetingPreparation, constants.ViewDueToday}, viewType) {
+	} else if slices.Contains([]constants.ViewType{constants.ViewJira, constants.ViewLinear, constants.ViewSlack, constants.ViewMeetingPreparation, constants.ViewDueToday, constants.ViewSnoozed, constants.ViewDelegated}, viewType) {
 		return api.getView(db, userID, viewType, nil)
 	} else if viewType == constants.ViewGithub {
 		return api.getView(db, userID, viewType, &[]bson.M{
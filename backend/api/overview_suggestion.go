@@ -47,7 +47,7 @@ func (api *API) OverviewViewsSuggestion(c *gin.Context) {
 		return
 	}
 
-	timezoneOffset, err := GetTimezoneOffsetFromHeader(c)
+	timezoneOffset, err := api.getUserTimezoneOffset(c, user)
 	if err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
@@ -250,7 +250,7 @@ func (api *API) OverviewViewsSuggestionsRemaining(c *gin.Context) {
 		return
 	}
 
-	timezoneOffset, err := GetTimezoneOffsetFromHeader(c)
+	timezoneOffset, err := api.getUserTimezoneOffset(c, user)
 	if err != nil {
 		c.JSON(400, gin.H{"error": err.Error()})
 		return
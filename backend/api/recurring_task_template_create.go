@@ -2,8 +2,14 @@ package api
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
+	"github.com/GeneralTask/task-manager/backend/constants"
 	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/utils"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -13,11 +19,29 @@ type RecurringTaskTemplateCreateParams struct {
 	Body                         *string  `json:"body,omitempty"`
 	IDTaskSection                *string  `json:"id_task_section,omitempty"`
 	PriorityNormalized           *float64 `json:"priority_normalized,omitempty"`
-	RecurrenceRate               *int     `json:"recurrence_rate,omitempty" binding:"required"`
+	RecurrenceRate               *int     `json:"recurrence_rate,omitempty"`
+	RecurrenceRule               *string  `json:"recurrence_rule,omitempty"`
 	TimeOfDaySecondsToCreateTask *int     `json:"time_of_day_seconds_to_create_task,omitempty" binding:"required"`
 	DayToCreateTask              *int     `json:"day_to_create_task,omitempty"`
 	MonthToCreateTask            *int     `json:"month_to_create_task,omitempty"`
 	ReplaceExisting              *bool    `json:"replace_existing,omitempty"`
+	RecurringTaskTemplateTaskParams
+}
+
+// fields for the tasks generated by a template which are shared by the create and modify endpoints
+type RecurringTaskTemplateTaskParams struct {
+	TimeDuration      *int                                  `json:"time_duration,omitempty"`
+	DueDateOffsetDays *int                                  `json:"due_date_offset_days,omitempty"`
+	SourceID          *string                               `json:"source_id,omitempty"`
+	AccountID         *string                               `json:"account_id,omitempty"`
+	Subtasks          *[]RecurringTaskTemplateSubtaskParams `json:"subtasks,omitempty"`
+}
+
+type RecurringTaskTemplateSubtaskParams struct {
+	Title        string                               `json:"title"`
+	Body         string                               `json:"body"`
+	TimeDuration *int                                 `json:"time_duration"`
+	Subtasks     []RecurringTaskTemplateSubtaskParams `json:"subtasks"`
 }
 
 func (api *API) RecurringTaskTemplateCreate(c *gin.Context) {
@@ -27,8 +51,24 @@ func (api *API) RecurringTaskTemplateCreate(c *gin.Context) {
 		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
 		return
 	}
+	if templateCreateParams.RecurrenceRate == nil && templateCreateParams.RecurrenceRule == nil {
+		c.JSON(400, gin.H{"detail": "'recurrence_rate' or 'recurrence_rule' is required"})
+		return
+	}
+	if templateCreateParams.RecurrenceRule != nil {
+		_, err = utils.ParseRecurrence(*templateCreateParams.RecurrenceRule)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": "invalid 'recurrence_rule': " + err.Error()})
+			return
+		}
+	}
 
 	userID := getUserIDFromContext(c)
+	taskTemplate, err := api.getRecurringTaskTemplateTaskFields(userID, templateCreateParams.RecurringTaskTemplateTaskParams)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
 
 	var taskSection primitive.ObjectID
 	if templateCreateParams.IDTaskSection != nil {
@@ -47,10 +87,16 @@ func (api *API) RecurringTaskTemplateCreate(c *gin.Context) {
 		Body:                         templateCreateParams.Body,
 		IDTaskSection:                taskSection,
 		PriorityNormalized:           templateCreateParams.PriorityNormalized,
+		TimeAllocation:               taskTemplate.TimeAllocation,
+		DueDateOffsetDays:            taskTemplate.DueDateOffsetDays,
+		SourceID:                     taskTemplate.SourceID,
+		SourceAccountID:              taskTemplate.SourceAccountID,
+		Subtasks:                     taskTemplate.Subtasks,
 		IsEnabled:                    &enabled,
 		IsDeleted:                    &deleted,
 		ReplaceExisting:              templateCreateParams.ReplaceExisting,
 		RecurrenceRate:               templateCreateParams.RecurrenceRate,
+		RecurrenceRule:               templateCreateParams.RecurrenceRule,
 		TimeOfDaySecondsToCreateTask: templateCreateParams.TimeOfDaySecondsToCreateTask,
 		DayToCreateTask:              templateCreateParams.DayToCreateTask,
 		MonthToCreateTask:            templateCreateParams.MonthToCreateTask,
@@ -69,3 +115,86 @@ func (api *API) RecurringTaskTemplateCreate(c *gin.Context) {
 
 	c.JSON(200, gin.H{"template_id": insertID.InsertedID.(primitive.ObjectID)})
 }
+
+// validates the generated task fields, only the fields which were provided are set on the returned template
+func (api *API) getRecurringTaskTemplateTaskFields(userID primitive.ObjectID, params RecurringTaskTemplateTaskParams) (*database.RecurringTaskTemplate, error) {
+	template := database.RecurringTaskTemplate{}
+	if params.TimeDuration != nil {
+		if *params.TimeDuration < 0 {
+			return nil, errors.New("'time_duration' must not be negative")
+		}
+		timeAllocation := (time.Duration(*params.TimeDuration) * time.Second).Nanoseconds()
+		template.TimeAllocation = &timeAllocation
+	}
+	if params.DueDateOffsetDays != nil {
+		if *params.DueDateOffsetDays < 0 {
+			return nil, errors.New("'due_date_offset_days' must not be negative")
+		}
+		template.DueDateOffsetDays = params.DueDateOffsetDays
+	}
+	if params.SourceID != nil {
+		template.SourceID = *params.SourceID
+		template.SourceAccountID = external.GeneralTaskDefaultAccountID
+		if *params.SourceID != external.TASK_SOURCE_ID_GT_TASK {
+			taskSourceResult, err := api.ExternalConfig.GetSourceResult(*params.SourceID)
+			if err != nil || !taskSourceResult.Details.CanCreateTask {
+				return nil, errors.New("'source_id' cannot create tasks")
+			}
+			if params.AccountID == nil {
+				return nil, errors.New("'account_id' is required for 'source_id'")
+			}
+			if !api.isLinkedSourceAccount(userID, *params.SourceID, *params.AccountID) {
+				return nil, errors.New("account ID not found")
+			}
+			template.SourceAccountID = *params.AccountID
+		}
+	}
+	if params.Subtasks != nil {
+		subtasks, err := getRecurringTaskTemplateSubtasks(*params.Subtasks)
+		if err != nil {
+			return nil, err
+		}
+		if countRecurringTaskTemplateSubtasks(subtasks) > constants.MAX_RECURRING_TASK_TEMPLATE_SUBTASKS {
+			return nil, errors.New("too many subtasks")
+		}
+		template.Subtasks = &subtasks
+	}
+	return &template, nil
+}
+
+func getRecurringTaskTemplateSubtasks(params []RecurringTaskTemplateSubtaskParams) ([]database.RecurringTaskTemplateSubtask, error) {
+	subtasks := []database.RecurringTaskTemplateSubtask{}
+	for _, param := range params {
+		if strings.TrimSpace(param.Title) == "" {
+			return nil, errors.New("every subtask must have a title")
+		}
+		subtask := database.RecurringTaskTemplateSubtask{
+			Title: strings.TrimSpace(param.Title),
+			Body:  param.Body,
+		}
+		if param.TimeDuration != nil {
+			if *param.TimeDuration < 0 {
+				return nil, errors.New("'time_duration' must not be negative")
+			}
+			timeAllocation := (time.Duration(*param.TimeDuration) * time.Second).Nanoseconds()
+			subtask.TimeAllocation = &timeAllocation
+		}
+		if len(param.Subtasks) > 0 {
+			children, err := getRecurringTaskTemplateSubtasks(param.Subtasks)
+			if err != nil {
+				return nil, err
+			}
+			subtask.Subtasks = children
+		}
+		subtasks = append(subtasks, subtask)
+	}
+	return subtasks, nil
+}
+
+func countRecurringTaskTemplateSubtasks(subtasks []database.RecurringTaskTemplateSubtask) int {
+	count := 0
+	for _, subtask := range subtasks {
+		count += 1 + countRecurringTaskTemplateSubtasks(subtask.Subtasks)
+	}
+	return count
+}
@@ -2,12 +2,14 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -85,4 +87,63 @@ func TestRecurringTaskTemplateCreate(t *testing.T) {
 		assert.Equal(t, "hello!", *(templates[0].Title))
 		assert.Equal(t, primitive.NewDateTimeFromTime(currentTime), templates[0].CreatedAt)
 	})
+	t.Run("MissingRecurrence", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/recurring_task_templates/create/", bytes.NewBuffer([]byte(`{"title": "hello!", "time_of_day_seconds_to_create_task": 0}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'recurrence_rate' or 'recurrence_rule' is required"}`, string(body))
+	})
+	t.Run("InvalidRecurrenceRule", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/recurring_task_templates/create/", bytes.NewBuffer([]byte(`{"title": "hello!", "recurrence_rule": "FREQ=HOURLY", "time_of_day_seconds_to_create_task": 0}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid 'recurrence_rule': FREQ 'HOURLY' is not supported"}`, string(body))
+	})
+	t.Run("SuccessRecurrenceRule", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/recurring_task_templates/create/", bytes.NewBuffer([]byte(`{"title": "every other tuesday", "recurrence_rule": "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU\nEXDATE:20230103", "time_of_day_seconds_to_create_task": 0}`)), http.StatusOK, api)
+
+		var templates []database.RecurringTaskTemplate
+		err = database.FindWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, &[]bson.M{{"title": "every other tuesday"}}, &templates, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(templates))
+		assert.Nil(t, templates[0].RecurrenceRate)
+		assert.Equal(t, "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU\nEXDATE:20230103", *templates[0].RecurrenceRule)
+	})
+	t.Run("InvalidSubtask", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/recurring_task_templates/create/", bytes.NewBuffer([]byte(`{"title": "hello!", "recurrence_rate": 0, "time_of_day_seconds_to_create_task": 0, "subtasks": [{"title": "ok", "subtasks": [{"title": " "}]}]}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"every subtask must have a title"}`, string(body))
+	})
+	t.Run("NegativeDueDateOffset", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/recurring_task_templates/create/", bytes.NewBuffer([]byte(`{"title": "hello!", "recurrence_rate": 0, "time_of_day_seconds_to_create_task": 0, "due_date_offset_days": -1}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'due_date_offset_days' must not be negative"}`, string(body))
+	})
+	t.Run("SourceCannotCreateTasks", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/recurring_task_templates/create/", bytes.NewBuffer([]byte(`{"title": "hello!", "recurrence_rate": 0, "time_of_day_seconds_to_create_task": 0, "source_id": "asana_task", "account_id": "asana@generaltask.com"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'source_id' cannot create tasks"}`, string(body))
+	})
+	t.Run("AccountNotFound", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/recurring_task_templates/create/", bytes.NewBuffer([]byte(`{"title": "hello!", "recurrence_rate": 0, "time_of_day_seconds_to_create_task": 0, "source_id": "slack_saved", "account_id": "slack@generaltask.com"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"account ID not found"}`, string(body))
+	})
+	t.Run("SuccessLinkedAccount", func(t *testing.T) {
+		_, err := database.GetExternalTokenCollection(api.DB).InsertOne(context.Background(), database.ExternalAPIToken{
+			UserID:    userID,
+			ServiceID: external.TASK_SERVICE_ID_SLACK,
+			AccountID: "slack@generaltask.com",
+		})
+		assert.NoError(t, err)
+		ServeRequest(t, authToken, "POST", "/recurring_task_templates/create/", bytes.NewBuffer([]byte(`{"title": "slack template", "recurrence_rate": 0, "time_of_day_seconds_to_create_task": 0, "source_id": "slack_saved", "account_id": "slack@generaltask.com"}`)), http.StatusOK, api)
+	})
+	t.Run("SuccessSubtasks", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/recurring_task_templates/create/", bytes.NewBuffer([]byte(`{"title": "release checklist", "recurrence_rate": 0, "time_of_day_seconds_to_create_task": 0, "time_duration": 3600, "due_date_offset_days": 2, "subtasks": [{"title": "tag release", "time_duration": 300}, {"title": "deploy", "subtasks": [{"title": "deploy backend"}]}]}`)), http.StatusOK, api)
+
+		var templates []database.RecurringTaskTemplate
+		err = database.FindWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, &[]bson.M{{"title": "release checklist"}}, &templates, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(templates))
+		assert.Equal(t, time.Hour.Nanoseconds(), *templates[0].TimeAllocation)
+		assert.Equal(t, 2, *templates[0].DueDateOffsetDays)
+		assert.Equal(t, "", templates[0].SourceID)
+		subtasks := *templates[0].Subtasks
+		assert.Equal(t, 2, len(subtasks))
+		assert.Equal(t, "tag release", subtasks[0].Title)
+		assert.Equal(t, (5 * time.Minute).Nanoseconds(), *subtasks[0].TimeAllocation)
+		assert.Equal(t, "deploy backend", subtasks[1].Subtasks[0].Title)
+	})
 }
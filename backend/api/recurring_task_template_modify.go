@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/utils"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -16,12 +17,14 @@ type RecurringTaskTemplateModifyParams struct {
 	IDTaskSection                *string  `json:"id_task_section,omitempty"`
 	PriorityNormalized           *float64 `json:"priority_normalized,omitempty"`
 	RecurrenceRate               *int     `json:"recurrence_rate,omitempty"`
+	RecurrenceRule               *string  `json:"recurrence_rule,omitempty"`
 	TimeOfDaySecondsToCreateTask *int     `json:"time_of_day_seconds_to_create_task,omitempty"`
 	DayToCreateTask              *int     `json:"day_to_create_task,omitempty"`
 	MonthToCreateTask            *int     `json:"month_to_create_task,omitempty"`
 	IsEnabled                    *bool    `json:"is_enabled,omitempty"`
 	IsDeleted                    *bool    `json:"is_deleted,omitempty"`
 	ReplaceExisting              *bool    `json:"replace_existing,omitempty"`
+	RecurringTaskTemplateTaskParams
 }
 
 func (api *API) RecurringTaskTemplateModify(c *gin.Context) {
@@ -40,8 +43,22 @@ func (api *API) RecurringTaskTemplateModify(c *gin.Context) {
 		return
 	}
 
+	if modifyParams.RecurrenceRule != nil && *modifyParams.RecurrenceRule != "" {
+		_, err = utils.ParseRecurrence(*modifyParams.RecurrenceRule)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": "invalid 'recurrence_rule': " + err.Error()})
+			return
+		}
+	}
+
 	userID := getUserIDFromContext(c)
 
+	taskTemplate, err := api.getRecurringTaskTemplateTaskFields(userID, modifyParams.RecurringTaskTemplateTaskParams)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+
 	var taskSection primitive.ObjectID
 	if modifyParams.IDTaskSection != nil {
 		taskSection, err = getValidTaskSection(*modifyParams.IDTaskSection, userID, api.DB)
@@ -69,9 +86,15 @@ func (api *API) RecurringTaskTemplateModify(c *gin.Context) {
 		Title:                        modifyParams.Title,
 		Body:                         modifyParams.Body,
 		PriorityNormalized:           modifyParams.PriorityNormalized,
+		TimeAllocation:               taskTemplate.TimeAllocation,
+		DueDateOffsetDays:            taskTemplate.DueDateOffsetDays,
+		SourceID:                     taskTemplate.SourceID,
+		SourceAccountID:              taskTemplate.SourceAccountID,
+		Subtasks:                     taskTemplate.Subtasks,
 		IsEnabled:                    modifyParams.IsEnabled,
 		IsDeleted:                    modifyParams.IsDeleted,
 		RecurrenceRate:               modifyParams.RecurrenceRate,
+		RecurrenceRule:               modifyParams.RecurrenceRule,
 		TimeOfDaySecondsToCreateTask: modifyParams.TimeOfDaySecondsToCreateTask,
 		DayToCreateTask:              modifyParams.DayToCreateTask,
 		MonthToCreateTask:            modifyParams.MonthToCreateTask,
@@ -79,6 +102,13 @@ func (api *API) RecurringTaskTemplateModify(c *gin.Context) {
 		ReplaceExisting:              modifyParams.ReplaceExisting,
 		UpdatedAt:                    primitive.NewDateTimeFromTime(api.GetCurrentTime()),
 	}
+	// an empty recurrence rule switches the template back to its recurrence rate
+	update := bson.M{}
+	if modifyParams.RecurrenceRule != nil && *modifyParams.RecurrenceRule == "" {
+		updateTemplate.RecurrenceRule = nil
+		update["$unset"] = bson.M{"recurrence_rule": ""}
+	}
+	update["$set"] = updateTemplate
 
 	mongoResult := database.GetRecurringTaskTemplateCollection(api.DB).FindOneAndUpdate(
 		context.Background(),
@@ -88,7 +118,7 @@ func (api *API) RecurringTaskTemplateModify(c *gin.Context) {
 				{"user_id": userID},
 			},
 		},
-		bson.M{"$set": updateTemplate},
+		update,
 		options.FindOneAndUpdate().SetReturnDocument(options.After),
 	)
 	if mongoResult.Err() != nil {
@@ -100,6 +100,39 @@ func TestRecurringTaskTemplateModify(t *testing.T) {
 		assert.True(t, *templates[0].ReplaceExisting)
 		assert.Equal(t, primitive.NewDateTimeFromTime(currentTime), templates[0].UpdatedAt)
 	})
+	t.Run("InvalidRecurrenceRule", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", "/recurring_task_templates/modify/"+templateID.Hex()+"/", bytes.NewBuffer([]byte(`{"recurrence_rule": "FREQ=MONTHLY;BYDAY=9MO"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid 'recurrence_rule': invalid BYDAY"}`, string(body))
+	})
+	t.Run("SuccessRecurrenceRule", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/recurring_task_templates/modify/"+templateID.Hex()+"/", bytes.NewBuffer([]byte(`{"recurrence_rule": "FREQ=MONTHLY;BYDAY=-1FR"}`)), http.StatusOK, api)
+		var template database.RecurringTaskTemplate
+		err := database.FindOneWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, templateID).Decode(&template)
+		assert.NoError(t, err)
+		assert.Equal(t, "FREQ=MONTHLY;BYDAY=-1FR", *template.RecurrenceRule)
+
+		// an empty rule goes back to the recurrence rate
+		ServeRequest(t, authToken, "PATCH", "/recurring_task_templates/modify/"+templateID.Hex()+"/", bytes.NewBuffer([]byte(`{"recurrence_rule": ""}`)), http.StatusOK, api)
+		template = database.RecurringTaskTemplate{}
+		err = database.FindOneWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, templateID).Decode(&template)
+		assert.NoError(t, err)
+		assert.Nil(t, template.RecurrenceRule)
+	})
+	t.Run("SuccessSubtasks", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/recurring_task_templates/modify/"+templateID.Hex()+"/", bytes.NewBuffer([]byte(`{"due_date_offset_days": 1, "subtasks": [{"title": "write changelog"}]}`)), http.StatusOK, api)
+		var template database.RecurringTaskTemplate
+		err := database.FindOneWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, templateID).Decode(&template)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *template.DueDateOffsetDays)
+		assert.Equal(t, []database.RecurringTaskTemplateSubtask{{Title: "write changelog"}}, *template.Subtasks)
+
+		// an empty list removes the subtasks
+		ServeRequest(t, authToken, "PATCH", "/recurring_task_templates/modify/"+templateID.Hex()+"/", bytes.NewBuffer([]byte(`{"subtasks": []}`)), http.StatusOK, api)
+		template = database.RecurringTaskTemplate{}
+		err = database.FindOneWithCollection(database.GetRecurringTaskTemplateCollection(api.DB), userID, templateID).Decode(&template)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(*template.Subtasks))
+	})
 	t.Run("Delete", func(t *testing.T) {
 		template2Title := "whats up!"
 		insertResult, err := templateCollection.InsertOne(context.Background(), database.RecurringTaskTemplate{
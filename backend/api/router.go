@@ -2,6 +2,7 @@ package api
 
 import (
 	"github.com/GeneralTask/task-manager/backend/config"
+	"github.com/GeneralTask/task-manager/backend/constants"
 	_ "github.com/GeneralTask/task-manager/backend/docs"
 
 	"github.com/gin-gonic/gin"
@@ -46,6 +47,14 @@ func GetRouter(handlers *API) *gin.Engine {
 
 	router.POST("/linear/webhook/", handlers.LinearWebhook)
 
+	// scheduling links are shared with guests who do not have an account
+	schedulingRateLimits := []gin.HandlerFunc{
+		RateLimitMiddleware(handlers.DB, "scheduling_ip", constants.SCHEDULING_LINK_IP_RATE_LIMIT, func(c *gin.Context) string { return c.ClientIP() }),
+		RateLimitMiddleware(handlers.DB, "scheduling_token", constants.SCHEDULING_LINK_TOKEN_RATE_LIMIT, func(c *gin.Context) string { return c.Param("token") }),
+	}
+	router.GET("/scheduling/:token/", append(schedulingRateLimits, handlers.SchedulingLinkPage)...)
+	router.POST("/scheduling/:token/book/", append(schedulingRateLimits, handlers.SchedulingLinkBook)...)
+
 	// Slack App (Workspace level) endpoint for oauth verification
 	// We need this as we don't actually use the token provided, but still need to access it to
 	// successfully install our app in a new Workspace
@@ -82,14 +91,36 @@ func GetRouter(handlers *API) *gin.Engine {
 	router.GET("/events/:event_id/", handlers.EventDetail)
 	router.DELETE("/events/delete/:event_id/", handlers.EventDelete)
 	router.PATCH("/events/modify/:event_id/", handlers.EventModify)
+	router.POST("/events/rsvp/:event_id/", handlers.EventRSVP)
+	router.POST("/events/note/:event_id/", handlers.EventNoteCreate)
+	router.GET("/free_busy/", handlers.FreeBusy)
+
+	router.GET("/scheduling_links/", handlers.SchedulingLinksList)
+	router.POST("/scheduling_links/create/", handlers.SchedulingLinkCreate)
+	router.DELETE("/scheduling_links/delete/:link_id/", handlers.SchedulingLinkDelete)
 
 	router.GET("/tasks/fetch/", handlers.TasksFetch)
 	router.GET("/tasks/v3/", handlers.TasksListV3)
 	router.GET("/tasks/v4/", handlers.TasksListV4)
 	router.POST("/tasks/create/:source_id/", handlers.TaskCreate)
 	router.PATCH("/tasks/modify/:task_id/", handlers.TaskModify)
+	router.POST("/tasks/bulk_modify/", handlers.TaskBulkModify)
+	router.GET("/tasks/export/", handlers.TaskExport)
+	router.POST("/tasks/import/", handlers.TaskImport)
+	router.POST("/tasks/schedule/", handlers.TaskSchedule)
+	router.POST("/tasks/schedule/confirm/", handlers.TaskScheduleConfirm)
+	router.POST("/tasks/schedule/reflow/", handlers.TaskScheduleReflow)
 	router.GET("/tasks/detail/:task_id/", handlers.TaskDetail)
 	router.POST("/tasks/:task_id/comments/add/", handlers.TaskAddComment)
+	router.PATCH("/tasks/:task_id/comments/:comment_id/", handlers.TaskCommentModify)
+	router.DELETE("/tasks/:task_id/comments/:comment_id/", handlers.TaskCommentDelete)
+	router.PATCH("/tasks/:task_id/reminders/", handlers.TaskRemindersModify)
+	router.DELETE("/tasks/:task_id/reminders/", handlers.TaskRemindersDelete)
+	router.PATCH("/tasks/:task_id/shared_with/", handlers.TaskSharedWithModify)
+	router.DELETE("/tasks/:task_id/shared_with/", handlers.TaskSharedWithDelete)
+	router.GET("/tasks/:task_id/views/", handlers.TaskViewsList)
+	router.POST("/tasks/:task_id/assign/", handlers.TaskAssign)
+	router.PATCH("/tasks/:task_id/assignment/", handlers.TaskAssignmentModify)
 
 	router.GET("/recurring_task_templates/", handlers.RecurringTaskTemplateList)
 	router.GET("/recurring_task_templates/v2/", handlers.RecurringTaskTemplateListV2)
@@ -100,6 +131,32 @@ func GetRouter(handlers *API) *gin.Engine {
 	router.GET("/notes/", handlers.NotesList)
 	router.PATCH("/notes/modify/:note_id/", handlers.NoteModify)
 	router.POST("/notes/create/", handlers.NoteCreate)
+	router.DELETE("/notes/delete/:note_id/", handlers.NoteDelete)
+	router.GET("/notes/export/", handlers.NotesExport)
+	router.GET("/notes/:note_id/export/", handlers.NoteExport)
+	router.GET("/notes/:note_id/revisions/", handlers.NoteRevisionsList)
+	router.GET("/notes/:note_id/revisions/diff/", handlers.NoteRevisionsDiff)
+	router.GET("/notes/:note_id/revisions/:revision_id/", handlers.NoteRevisionDetails)
+	router.POST("/notes/:note_id/revisions/:revision_id/restore/", handlers.NoteRevisionRestore)
+	router.PATCH("/notes/:note_id/shared_with/", handlers.NoteSharedWithModify)
+	router.DELETE("/notes/:note_id/shared_with/", handlers.NoteSharedWithDelete)
+	router.GET("/notes/:note_id/views/", handlers.NoteViewsList)
+	router.POST("/notes/:note_id/comments/add/", handlers.NoteAddComment)
+	router.PATCH("/notes/:note_id/comments/:comment_id/", handlers.NoteCommentModify)
+	router.DELETE("/notes/:note_id/comments/:comment_id/", handlers.NoteCommentDelete)
+	router.POST("/notes/:note_id/links/", handlers.NoteLinkAdd)
+	router.DELETE("/notes/:note_id/links/", handlers.NoteLinkDelete)
+
+	router.GET("/shared_with_me/", handlers.SharedWithMeList)
+
+	router.GET("/time_entries/", handlers.TimeEntriesList)
+	router.POST("/time_entries/create/", handlers.TimeEntryCreate)
+	router.POST("/time_entries/start/", handlers.TimeEntryStart)
+	router.POST("/time_entries/stop/", handlers.TimeEntryStop)
+	router.DELETE("/time_entries/delete/:time_entry_id/", handlers.TimeEntryDelete)
+
+	router.GET("/notifications/", handlers.NotificationsList)
+	router.PATCH("/notifications/modify/:notification_id/", handlers.NotificationModify)
 
 	router.GET("/ping_authed/", handlers.Ping)
 
@@ -111,6 +168,8 @@ func GetRouter(handlers *API) *gin.Engine {
 
 	router.GET("/user_info/", handlers.UserInfoGet)
 	router.PATCH("/user_info/", handlers.UserInfoUpdate)
+	router.GET("/availability/", handlers.UserAvailabilityGet)
+	router.PATCH("/availability/", handlers.UserAvailabilityUpdate)
 
 	router.GET("/sections/", handlers.SectionList)
 	router.GET("/sections/v2/", handlers.SectionListV2)
@@ -118,11 +177,31 @@ func GetRouter(handlers *API) *gin.Engine {
 	router.PATCH("/sections/modify/:section_id/", handlers.SectionModify)
 	router.DELETE("/sections/delete/:section_id/", handlers.SectionDelete)
 
+	router.GET("/workspaces/", handlers.WorkspacesList)
+	router.POST("/workspaces/", handlers.WorkspaceCreate)
+	router.GET("/workspaces/invites/", handlers.WorkspaceInvitesList)
+	router.PATCH("/workspaces/:workspace_id/", handlers.WorkspaceModify)
+	router.DELETE("/workspaces/:workspace_id/", handlers.WorkspaceDelete)
+	router.POST("/workspaces/:workspace_id/join/", handlers.WorkspaceJoin)
+	router.GET("/workspaces/:workspace_id/members/", handlers.WorkspaceMembersList)
+	router.POST("/workspaces/:workspace_id/members/", handlers.WorkspaceMemberInvite)
+	router.PATCH("/workspaces/:workspace_id/members/:member_id/", handlers.WorkspaceMemberModify)
+	router.DELETE("/workspaces/:workspace_id/members/:member_id/", handlers.WorkspaceMemberDelete)
+	router.GET("/workspaces/:workspace_id/sections/", handlers.WorkspaceSectionsList)
+	router.POST("/workspaces/:workspace_id/sections/", handlers.WorkspaceSectionAdd)
+	router.PATCH("/workspaces/:workspace_id/sections/:section_id/", handlers.WorkspaceSectionModify)
+	router.DELETE("/workspaces/:workspace_id/sections/:section_id/", handlers.WorkspaceSectionDelete)
+	router.GET("/workspaces/:workspace_id/tasks/", handlers.WorkspaceTasksList)
+	router.POST("/workspaces/:workspace_id/tasks/", handlers.WorkspaceTaskCreate)
+	router.PATCH("/workspaces/:workspace_id/tasks/:task_id/assignee/", handlers.WorkspaceTaskAssign)
+
 	// Currently frontend is using endpoint with trailing slash, so we need to support both
 	router.GET("/overview/views", handlers.OverviewViewsList)
 	router.GET("/overview/views/", handlers.OverviewViewsList)
 
 	router.GET("/meeting_preparation_tasks/", handlers.MeetingPreparationTasksList)
+	router.GET("/meeting_preparation_tasks/config/", handlers.MeetingPreparationConfigGet)
+	router.PATCH("/meeting_preparation_tasks/config/", handlers.MeetingPreparationConfigUpdate)
 
 	router.POST("/overview/views/", handlers.OverviewViewAdd)
 	router.PATCH("/overview/views/bulk_modify/", handlers.OverviewViewBulkModify)
@@ -140,9 +219,13 @@ func GetRouter(handlers *API) *gin.Engine {
 	// Add business middleware. Endpoints below this require business mode to be enabled
 	router.Use(BusinessMiddleware(handlers.DB))
 	router.GET("/dashboard/data/", handlers.DashboardData)
+	router.GET("/dashboard/data/export/", handlers.DashboardDataExport)
 	router.GET("/dashboard/team_members/", handlers.DashboardTeamMembersList)
 	router.POST("/dashboard/team_members/", handlers.DashboardTeamMemberCreate)
 	router.DELETE("/dashboard/team_members/:team_member_id/", handlers.DashboardTeamMemberDelete)
+	router.GET("/dashboard/github_rosters/", handlers.DashboardGithubRostersList)
+	router.PATCH("/dashboard/github_roster/", handlers.DashboardGithubRosterModify)
+	router.DELETE("/dashboard/github_roster/", handlers.DashboardGithubRosterDelete)
 	router.GET("/dashboard/data/fetch/", handlers.DashboardFetch)
 	router.GET("/ping_business/", handlers.Ping)
 
@@ -0,0 +1,393 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/config"
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SchedulingLinkCreateParams struct {
+	Title                string  `json:"title" binding:"required"`
+	AccountID            string  `json:"account_id" binding:"required"`
+	CalendarID           string  `json:"calendar_id"`
+	DurationMinutes      int     `json:"duration_minutes" binding:"required"`
+	BufferMinutes        int     `json:"buffer_minutes"`
+	MinimumNoticeMinutes int     `json:"minimum_notice_minutes"`
+	DaysAhead            *int    `json:"days_ahead"`
+	Timezone             *string `json:"timezone"`
+	AddConferenceCall    bool    `json:"add_conference_call"`
+}
+
+type SchedulingLinkResult struct {
+	ID                   primitive.ObjectID `json:"id"`
+	URL                  string             `json:"url"`
+	Title                string             `json:"title"`
+	AccountID            string             `json:"account_id"`
+	CalendarID           string             `json:"calendar_id"`
+	Timezone             string             `json:"timezone"`
+	DurationMinutes      int                `json:"duration_minutes"`
+	BufferMinutes        int                `json:"buffer_minutes"`
+	MinimumNoticeMinutes int                `json:"minimum_notice_minutes"`
+	DaysAhead            int                `json:"days_ahead"`
+	AddConferenceCall    bool               `json:"add_conference_call"`
+}
+
+type SchedulingLinkSlotsParams struct {
+	DatetimeStart *time.Time `form:"datetime_start"`
+	DatetimeEnd   *time.Time `form:"datetime_end"`
+}
+
+// only includes what a guest needs to pick a time
+type SchedulingLinkPageResult struct {
+	Title           string         `json:"title"`
+	OwnerName       string         `json:"owner_name"`
+	Timezone        string         `json:"timezone"`
+	DurationMinutes int            `json:"duration_minutes"`
+	Slots           []FreeBusySlot `json:"slots"`
+}
+
+type SchedulingLinkBookParams struct {
+	Name          string     `json:"name" binding:"required"`
+	Email         string     `json:"email" binding:"required"`
+	DatetimeStart *time.Time `json:"datetime_start" binding:"required"`
+	Message       string     `json:"message"`
+}
+
+func (api *API) SchedulingLinksList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	var links []database.SchedulingLink
+	err := database.FindWithCollection(database.GetSchedulingLinkCollection(api.DB), userID, &[]bson.M{}, &links, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch scheduling links")
+		Handle500(c)
+		return
+	}
+	results := []SchedulingLinkResult{}
+	for _, link := range links {
+		results = append(results, getSchedulingLinkResult(link))
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) SchedulingLinkCreate(c *gin.Context) {
+	var params SchedulingLinkCreateParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	link, err := api.getSchedulingLinkFromParams(user, params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	count, err := database.GetSchedulingLinkCollection(api.DB).CountDocuments(context.Background(), bson.M{"user_id": userID})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to count scheduling links")
+		Handle500(c)
+		return
+	}
+	if count >= constants.MAX_SCHEDULING_LINKS {
+		c.JSON(400, gin.H{"detail": "too many scheduling links"})
+		return
+	}
+
+	insertResult, err := database.GetSchedulingLinkCollection(api.DB).InsertOne(context.Background(), link)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create scheduling link")
+		Handle500(c)
+		return
+	}
+	link.ID = insertResult.InsertedID.(primitive.ObjectID)
+	c.JSON(201, getSchedulingLinkResult(*link))
+}
+
+func (api *API) getSchedulingLinkFromParams(user *database.User, params SchedulingLinkCreateParams) (*database.SchedulingLink, error) {
+	if params.DurationMinutes < constants.SCHEDULING_LINK_MIN_DURATION || params.DurationMinutes > constants.SCHEDULING_LINK_MAX_DURATION {
+		return nil, fmt.Errorf("'duration_minutes' must be between %d and %d", constants.SCHEDULING_LINK_MIN_DURATION, constants.SCHEDULING_LINK_MAX_DURATION)
+	}
+	if params.BufferMinutes < 0 || params.BufferMinutes > constants.SCHEDULING_LINK_MAX_BUFFER {
+		return nil, fmt.Errorf("'buffer_minutes' must be between 0 and %d", constants.SCHEDULING_LINK_MAX_BUFFER)
+	}
+	if params.MinimumNoticeMinutes < 0 {
+		return nil, errors.New("'minimum_notice_minutes' must not be negative")
+	}
+	daysAhead := constants.SCHEDULING_LINK_DEFAULT_DAYS_AHEAD
+	if params.DaysAhead != nil {
+		daysAhead = *params.DaysAhead
+	}
+	if daysAhead < 1 || daysAhead > constants.SCHEDULING_LINK_MAX_DAYS_AHEAD {
+		return nil, fmt.Errorf("'days_ahead' must be between 1 and %d", constants.SCHEDULING_LINK_MAX_DAYS_AHEAD)
+	}
+	// guests see times in the link's timezone, so it cannot depend on a request header
+	timezone := user.Timezone
+	if params.Timezone != nil {
+		timezone = *params.Timezone
+	}
+	if timezone == "" {
+		return nil, errors.New("'timezone' is required when no timezone is set in availability")
+	}
+	_, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errors.New("invalid timezone")
+	}
+	if !api.isLinkedSourceAccount(user.ID, external.TASK_SOURCE_ID_GCAL, params.AccountID) {
+		return nil, errors.New("account ID not found")
+	}
+	return &database.SchedulingLink{
+		UserID:               user.ID,
+		Token:                uuid.New().String(),
+		Title:                params.Title,
+		SourceAccountID:      params.AccountID,
+		CalendarID:           params.CalendarID,
+		Timezone:             timezone,
+		DurationMinutes:      params.DurationMinutes,
+		BufferMinutes:        params.BufferMinutes,
+		MinimumNoticeMinutes: params.MinimumNoticeMinutes,
+		DaysAhead:            daysAhead,
+		AddConferenceCall:    params.AddConferenceCall,
+		CreatedAt:            primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	}, nil
+}
+
+func (api *API) SchedulingLinkDelete(c *gin.Context) {
+	linkID, err := primitive.ObjectIDFromHex(c.Param("link_id"))
+	if err != nil {
+		// This means the link ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	deleteResult, err := database.GetSchedulingLinkCollection(api.DB).DeleteOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": linkID},
+			{"user_id": userID},
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete scheduling link")
+		Handle500(c)
+		return
+	}
+	if deleteResult.DeletedCount == 0 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// SchedulingLinkPage is public and returns the open meeting times for a scheduling link
+func (api *API) SchedulingLinkPage(c *gin.Context) {
+	var params SchedulingLinkSlotsParams
+	err := c.BindQuery(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter."})
+		return
+	}
+	link, err := api.getSchedulingLinkByToken(c.Param("token"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	owner, err := database.GetUser(api.DB, link.UserID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	start, end := api.getSchedulingLinkBookingWindow(link)
+	if params.DatetimeStart != nil && params.DatetimeStart.After(start) {
+		start = *params.DatetimeStart
+	}
+	if params.DatetimeEnd != nil && params.DatetimeEnd.Before(end) {
+		end = *params.DatetimeEnd
+	}
+	slots, err := api.getSchedulingLinkOpenSlots(link, owner, start, end)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to compute scheduling link slots")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, SchedulingLinkPageResult{
+		Title:           link.Title,
+		OwnerName:       owner.Name,
+		Timezone:        link.Timezone,
+		DurationMinutes: link.DurationMinutes,
+		Slots:           getFreeBusySlotResults(slots),
+	})
+}
+
+// SchedulingLinkBook is public and creates the meeting on the link owner's calendar with the guest invited
+func (api *API) SchedulingLinkBook(c *gin.Context) {
+	var params SchedulingLinkBookParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	address, err := mail.ParseAddress(params.Email)
+	if err != nil || address.Address != params.Email {
+		c.JSON(400, gin.H{"detail": "invalid email"})
+		return
+	}
+	link, err := api.getSchedulingLinkByToken(c.Param("token"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	owner, err := database.GetUser(api.DB, link.UserID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+
+	// only one guest books time on the owner's calendar at once, so the time cannot be taken between the check and the event being created
+	currentTime := api.GetCurrentTime()
+	lockID, err := database.AcquireSchedulingBookingLock(api.DB, link.UserID, currentTime, currentTime.Add(constants.SCHEDULING_BOOKING_LOCK_SECONDS*time.Second))
+	if mongo.IsDuplicateKeyError(err) {
+		c.JSON(400, gin.H{"detail": "another booking is in progress, please try again"})
+		return
+	}
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	defer database.ReleaseSchedulingBookingLock(api.DB, link.UserID, lockID)
+
+	// the requested time must still be one of the open slots, as the calendar may have changed since it was offered
+	duration := time.Duration(link.DurationMinutes) * time.Minute
+	datetimeStart := *params.DatetimeStart
+	datetimeEnd := datetimeStart.Add(duration)
+	windowStart, windowEnd := api.getSchedulingLinkBookingWindow(link)
+	isOpen := false
+	if !datetimeStart.Before(windowStart) && !datetimeEnd.After(windowEnd) {
+		slots, err := api.getSchedulingLinkOpenSlots(link, owner, datetimeStart, datetimeEnd)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to compute scheduling link slots")
+			Handle500(c)
+			return
+		}
+		isOpen = len(slots) > 0 && slots[0].Start.Equal(datetimeStart)
+	}
+	if !isOpen {
+		c.JSON(400, gin.H{"detail": "time is no longer available"})
+		return
+	}
+
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(external.TASK_SOURCE_ID_GCAL)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	_, err = api.createCalendarEvent(link.UserID, external.TASK_SOURCE_ID_GCAL, taskSourceResult, external.EventCreateObject{
+		AccountID:         link.SourceAccountID,
+		CalendarID:        link.CalendarID,
+		Summary:           fmt.Sprintf("%s with %s", link.Title, params.Name),
+		Description:       params.Message,
+		TimeZone:          link.Timezone,
+		DatetimeStart:     &datetimeStart,
+		DatetimeEnd:       &datetimeEnd,
+		Attendees:         []external.Attendee{{Name: params.Name, Email: params.Email}},
+		AddConferenceCall: link.AddConferenceCall,
+	}, "")
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(201, FreeBusySlot{DatetimeStart: datetimeStart, DatetimeEnd: datetimeEnd})
+}
+
+func (api *API) getSchedulingLinkByToken(token string) (*database.SchedulingLink, error) {
+	if token == "" {
+		return nil, errors.New("missing token")
+	}
+	var link database.SchedulingLink
+	err := database.GetSchedulingLinkCollection(api.DB).FindOne(context.Background(), bson.M{"token": token}).Decode(&link)
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// guests can book from the minimum notice until the number of days ahead set on the link
+func (api *API) getSchedulingLinkBookingWindow(link *database.SchedulingLink) (time.Time, time.Time) {
+	currentTime := api.GetCurrentTime()
+	return currentTime.Add(time.Duration(link.MinimumNoticeMinutes) * time.Minute), currentTime.AddDate(0, 0, link.DaysAhead)
+}
+
+func (api *API) getSchedulingLinkOpenSlots(link *database.SchedulingLink, owner *database.User, start time.Time, end time.Time) ([]utils.TimeSlot, error) {
+	if !end.After(start) {
+		return []utils.TimeSlot{}, nil
+	}
+	location, err := time.LoadLocation(link.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	buffer := time.Duration(link.BufferMinutes) * time.Minute
+	busySlots, err := api.getBusyTimeSlots(link.UserID, start.Add(-buffer), end.Add(buffer))
+	if err != nil {
+		return nil, err
+	}
+	return getSchedulingLinkSlots(*link, utils.GetUserAvailability(owner), location, busySlots, start, end), nil
+}
+
+// splits the free time into meetings of the link's duration, keeping the buffer clear around existing events
+func getSchedulingLinkSlots(link database.SchedulingLink, availability database.UserAvailability, location *time.Location, busySlots []utils.TimeSlot, start time.Time, end time.Time) []utils.TimeSlot {
+	buffer := time.Duration(link.BufferMinutes) * time.Minute
+	bufferedBusySlots := []utils.TimeSlot{}
+	for _, busy := range busySlots {
+		bufferedBusySlots = append(bufferedBusySlots, utils.TimeSlot{Start: busy.Start.Add(-buffer), End: busy.End.Add(buffer)})
+	}
+	freeSlots := getMeetingFreeTimeSlots(availability, location, bufferedBusySlots, start, end)
+
+	duration := time.Duration(link.DurationMinutes) * time.Minute
+	interval := time.Duration(constants.SCHEDULING_LINK_SLOT_INTERVAL) * time.Minute
+	slots := []utils.TimeSlot{}
+	for _, free := range freeSlots {
+		slotStart := free.Start.Truncate(interval)
+		if slotStart.Before(free.Start) {
+			slotStart = slotStart.Add(interval)
+		}
+		for ; !slotStart.Add(duration).After(free.End); slotStart = slotStart.Add(interval) {
+			slots = append(slots, utils.TimeSlot{Start: slotStart, End: slotStart.Add(duration)})
+		}
+	}
+	return slots
+}
+
+func getSchedulingLinkResult(link database.SchedulingLink) SchedulingLinkResult {
+	return SchedulingLinkResult{
+		ID:                   link.ID,
+		URL:                  config.GetConfigValue("HOME_URL") + "schedule/" + link.Token,
+		Title:                link.Title,
+		AccountID:            link.SourceAccountID,
+		CalendarID:           link.CalendarID,
+		Timezone:             link.Timezone,
+		DurationMinutes:      link.DurationMinutes,
+		BufferMinutes:        link.BufferMinutes,
+		MinimumNoticeMinutes: link.MinimumNoticeMinutes,
+		DaysAhead:            link.DaysAhead,
+		AddConferenceCall:    link.AddConferenceCall,
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/config"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetSchedulingLinkSlots(t *testing.T) {
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2022, time.November, 14, hour, minute, 0, 0, time.UTC)
+	}
+	availability := database.UserAvailability{
+		WorkingHours: []database.WorkingHours{{Weekday: 1, StartTime: "09:00", EndTime: "12:00"}},
+		Blocks:       []database.AvailabilityBlock{{Type: "no_meetings", Weekdays: []int{1}, StartTime: "11:00", EndTime: "12:00"}},
+	}
+	link := database.SchedulingLink{DurationMinutes: 30, BufferMinutes: 10}
+	slots := getSchedulingLinkSlots(link, availability, time.UTC, []utils.TimeSlot{{Start: at(9, 40), End: at(10, 0)}}, at(9, 5), at(17, 0))
+	assert.Equal(t, []utils.TimeSlot{
+		{Start: at(10, 15), End: at(10, 45)},
+		{Start: at(10, 30), End: at(11, 0)},
+	}, slots)
+}
+
+func TestSchedulingLinks(t *testing.T) {
+	authToken := login("test_scheduling_links@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	// Monday November 14, 2022 at 8:00
+	currentTime := time.Date(2022, time.November, 14, 8, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+
+	_, err := database.GetExternalTokenCollection(api.DB).InsertOne(context.Background(), database.ExternalAPIToken{
+		UserID:    userID,
+		ServiceID: external.TASK_SERVICE_ID_GOOGLE,
+		AccountID: "test_scheduling_links@generaltask.com",
+	})
+	assert.NoError(t, err)
+
+	UnauthorizedTest(t, "GET", "/scheduling_links/", nil)
+	UnauthorizedTest(t, "POST", "/scheduling_links/create/", nil)
+	t.Run("CreateInvalidDuration", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/scheduling_links/create/", bytes.NewBuffer([]byte(`{"title":"chat","account_id":"test_scheduling_links@generaltask.com","duration_minutes":1000,"timezone":"UTC"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'duration_minutes' must be between 5 and 480"}`, string(body))
+	})
+	t.Run("CreateMissingTimezone", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/scheduling_links/create/", bytes.NewBuffer([]byte(`{"title":"chat","account_id":"test_scheduling_links@generaltask.com","duration_minutes":30}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'timezone' is required when no timezone is set in availability"}`, string(body))
+	})
+	t.Run("CreateAccountNotFound", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/scheduling_links/create/", bytes.NewBuffer([]byte(`{"title":"chat","account_id":"other@generaltask.com","duration_minutes":30,"timezone":"UTC"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"account ID not found"}`, string(body))
+	})
+
+	var link SchedulingLinkResult
+	t.Run("CreateSuccess", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/scheduling_links/create/", bytes.NewBuffer([]byte(`{"title":"chat","account_id":"test_scheduling_links@generaltask.com","duration_minutes":30,"days_ahead":1,"timezone":"UTC"}`)), http.StatusCreated, api)
+		assert.NoError(t, json.Unmarshal(body, &link))
+		assert.Equal(t, "chat", link.Title)
+		assert.Equal(t, 1, link.DaysAhead)
+
+		body = ServeRequest(t, authToken, "GET", "/scheduling_links/", nil, http.StatusOK, api)
+		var links []SchedulingLinkResult
+		assert.NoError(t, json.Unmarshal(body, &links))
+		assert.Equal(t, []SchedulingLinkResult{link}, links)
+	})
+	token := strings.TrimPrefix(link.URL, config.GetConfigValue("HOME_URL")+"schedule/")
+	t.Run("PageNotFound", func(t *testing.T) {
+		ServeRequest(t, "", "GET", "/scheduling/not_a_token/", nil, http.StatusNotFound, api)
+	})
+	t.Run("PageSuccess", func(t *testing.T) {
+		_, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+			UserID:        userID,
+			DatetimeStart: primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 9, 30, 0, 0, time.UTC)),
+			DatetimeEnd:   primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 17, 0, 0, 0, time.UTC)),
+		})
+		assert.NoError(t, err)
+		body := ServeRequest(t, "", "GET", "/scheduling/"+token+"/", nil, http.StatusOK, api)
+		var page SchedulingLinkPageResult
+		assert.NoError(t, json.Unmarshal(body, &page))
+		assert.Equal(t, "chat", page.Title)
+		assert.Equal(t, 1, len(page.Slots))
+		assert.Equal(t, time.Date(2022, time.November, 14, 9, 0, 0, 0, time.UTC), page.Slots[0].DatetimeStart.UTC())
+	})
+	t.Run("BookInvalidEmail", func(t *testing.T) {
+		body := ServeRequest(t, "", "POST", "/scheduling/"+token+"/book/", bytes.NewBuffer([]byte(`{"name":"guest","email":"not an email","datetime_start":"2022-11-14T09:00:00Z"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid email"}`, string(body))
+	})
+	t.Run("BookInProgress", func(t *testing.T) {
+		_, err := database.GetSchedulingBookingLockCollection(api.DB).InsertOne(context.Background(), database.SchedulingBookingLock{
+			UserID:    userID,
+			LockID:    primitive.NewObjectID(),
+			ExpiresAt: primitive.NewDateTimeFromTime(currentTime.Add(time.Minute)),
+		})
+		assert.NoError(t, err)
+		body := ServeRequest(t, "", "POST", "/scheduling/"+token+"/book/", bytes.NewBuffer([]byte(`{"name":"guest","email":"guest@example.com","datetime_start":"2022-11-14T09:00:00Z"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"another booking is in progress, please try again"}`, string(body))
+
+		// an expired lock does not block bookings
+		_, err = database.GetSchedulingBookingLockCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"_id": userID},
+			bson.M{"$set": bson.M{"expires_at": primitive.NewDateTimeFromTime(currentTime.Add(-time.Minute))}},
+		)
+		assert.NoError(t, err)
+	})
+	t.Run("BookUnavailable", func(t *testing.T) {
+		body := ServeRequest(t, "", "POST", "/scheduling/"+token+"/book/", bytes.NewBuffer([]byte(`{"name":"guest","email":"guest@example.com","datetime_start":"2022-11-14T10:00:00Z"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"time is no longer available"}`, string(body))
+		// the lock is released once the booking finishes
+		count, err := database.GetSchedulingBookingLockCollection(api.DB).CountDocuments(context.Background(), bson.M{"_id": userID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", "/scheduling_links/delete/"+link.ID.Hex()+"/", nil, http.StatusOK, api)
+		ServeRequest(t, authToken, "DELETE", "/scheduling_links/delete/"+link.ID.Hex()+"/", nil, http.StatusNotFound, api)
+		ServeRequest(t, "", "GET", "/scheduling/"+token+"/", nil, http.StatusNotFound, api)
+	})
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 
+	"github.com/GeneralTask/task-manager/backend/constants"
 	"github.com/GeneralTask/task-manager/backend/database"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -27,9 +28,11 @@ func (api *API) ShareableTaskDetails(c *gin.Context) {
 	 * This is the case when an unauthenticated user hits this endpoint.
 	 */
 	var userID *primitive.ObjectID
+	viewerID := primitive.NilObjectID
 	if userIDRaw, exists := c.Get("user"); exists {
 		userIDValue := userIDRaw.(primitive.ObjectID)
 		userID = &userIDValue
+		viewerID = userIDValue
 	}
 
 	task, err := database.GetSharedTask(api.DB, taskID, userID)
@@ -41,6 +44,7 @@ func (api *API) ShareableTaskDetails(c *gin.Context) {
 		Handle404(c)
 		return
 	}
+	api.recordSharedItemView(constants.SharedItemTypeTask, task.ID, task.UserID, userID)
 
 	// Get subtasks for the shared task
 	subtasks, err := database.GetSubtasksFromTask(api.DB, task)
@@ -50,7 +54,7 @@ func (api *API) ShareableTaskDetails(c *gin.Context) {
 	}
 	subtaskResults := []*TaskResultV4{}
 	for _, subtask := range *subtasks {
-		subtaskResult := api.taskToTaskResultV4(&subtask)
+		subtaskResult := api.taskToTaskResultV4(&subtask, viewerID)
 		subtaskResults = append(subtaskResults, subtaskResult)
 	}
 
@@ -66,7 +70,7 @@ func (api *API) ShareableTaskDetails(c *gin.Context) {
 		return
 	}
 
-	taskResult := api.taskToTaskResultV4(task)
+	taskResult := api.taskToTaskResultV4(task, viewerID)
 	result := ShareableTaskDetailsResponse{
 		Task:     taskResult,
 		Domain:   fmt.Sprintf(`@%s`, taskOwnerDomain),
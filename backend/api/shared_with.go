@@ -0,0 +1,353 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type SharedWithParams struct {
+	Email string `json:"email" binding:"required"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type SharedItemViewResult struct {
+	ViewerEmail   string `json:"viewer_email"`
+	FirstViewedAt string `json:"first_viewed_at"`
+	LastViewedAt  string `json:"last_viewed_at"`
+	ViewCount     int    `json:"view_count"`
+}
+
+type SharedWithMeResult struct {
+	ID         primitive.ObjectID `json:"id"`
+	ItemType   string             `json:"item_type"`
+	Title      string             `json:"title"`
+	OwnerName  string             `json:"owner_name"`
+	OwnerEmail string             `json:"owner_email"`
+	Role       string             `json:"role"`
+	SharedAt   string             `json:"shared_at"`
+}
+
+func (api *API) TaskSharedWithModify(c *gin.Context) {
+	task, ok := api.getSharedWithTask(c)
+	if !ok {
+		return
+	}
+	api.modifySharedWith(c, database.GetTaskCollection(api.DB), task.ID, task.UserID)
+}
+
+func (api *API) TaskSharedWithDelete(c *gin.Context) {
+	task, ok := api.getSharedWithTask(c)
+	if !ok {
+		return
+	}
+	api.deleteSharedWith(c, database.GetTaskCollection(api.DB), task.ID, task.UserID)
+}
+
+func (api *API) TaskViewsList(c *gin.Context) {
+	task, ok := api.getSharedWithTask(c)
+	if !ok {
+		return
+	}
+	api.listSharedItemViews(c, task.ID)
+}
+
+func (api *API) NoteSharedWithModify(c *gin.Context) {
+	note, ok := api.getSharedWithNote(c)
+	if !ok {
+		return
+	}
+	api.modifySharedWith(c, database.GetNoteCollection(api.DB), note.ID, note.UserID)
+}
+
+func (api *API) NoteSharedWithDelete(c *gin.Context) {
+	note, ok := api.getSharedWithNote(c)
+	if !ok {
+		return
+	}
+	api.deleteSharedWith(c, database.GetNoteCollection(api.DB), note.ID, note.UserID)
+}
+
+func (api *API) NoteViewsList(c *gin.Context) {
+	note, ok := api.getSharedWithNote(c)
+	if !ok {
+		return
+	}
+	api.listSharedItemViews(c, note.ID)
+}
+
+func (api *API) SharedWithMeList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	email := strings.ToLower(user.Email)
+	filter := bson.M{"$and": []bson.M{
+		{"shared_with.email": email},
+		{"is_deleted": bson.M{"$ne": true}},
+	}}
+
+	var tasks []database.Task
+	cursor, err := database.GetTaskCollection(api.DB).Find(context.Background(), filter)
+	if err == nil {
+		err = cursor.All(context.Background(), &tasks)
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch tasks shared with user")
+		Handle500(c)
+		return
+	}
+	var notes []database.Note
+	cursor, err = database.GetNoteCollection(api.DB).Find(context.Background(), filter)
+	if err == nil {
+		err = cursor.All(context.Background(), &notes)
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch notes shared with user")
+		Handle500(c)
+		return
+	}
+
+	owners := make(map[primitive.ObjectID]*database.User)
+	results := []SharedWithMeResult{}
+	addResult := func(itemID primitive.ObjectID, itemType string, title *string, ownerID primitive.ObjectID, sharedWith []database.SharedWithEntry) {
+		owner, ok := owners[ownerID]
+		if !ok {
+			owner, err = database.GetUser(api.DB, ownerID)
+			if err != nil {
+				api.Logger.Error().Err(err).Msgf("failed to find owner of shared %s", itemType)
+				return
+			}
+			owners[ownerID] = owner
+		}
+		result := SharedWithMeResult{
+			ID:         itemID,
+			ItemType:   itemType,
+			OwnerName:  owner.Name,
+			OwnerEmail: owner.Email,
+		}
+		if title != nil {
+			result.Title = *title
+		}
+		for _, entry := range sharedWith {
+			if entry.Email == email {
+				result.Role = entry.Role
+				result.SharedAt = entry.SharedAt.Time().UTC().Format(time.RFC3339)
+			}
+		}
+		results = append(results, result)
+	}
+	for _, task := range tasks {
+		addResult(task.ID, constants.SharedItemTypeTask, task.Title, task.UserID, task.SharedWith)
+	}
+	for _, note := range notes {
+		addResult(note.ID, constants.SharedItemTypeNote, note.Title, note.UserID, note.SharedWith)
+	}
+	// most recently shared first
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].SharedAt > results[j].SharedAt
+	})
+	c.JSON(200, results)
+}
+
+func (api *API) getSharedWithTask(c *gin.Context) (*database.Task, bool) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	task, err := database.GetTask(api.DB, taskID, getUserIDFromContext(c))
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "task not found.", "taskId": taskID})
+		return nil, false
+	}
+	if task.SourceID != external.TASK_SOURCE_ID_GT_TASK {
+		c.JSON(400, gin.H{"detail": "only General Task tasks can be shared"})
+		return nil, false
+	}
+	return task, true
+}
+
+func (api *API) getSharedWithNote(c *gin.Context) (*database.Note, bool) {
+	noteID, err := primitive.ObjectIDFromHex(c.Param("note_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	note, err := database.GetNote(api.DB, noteID, getUserIDFromContext(c))
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "note not found.", "noteId": noteID})
+		return nil, false
+	}
+	return note, true
+}
+
+// adds the person to the item, or changes their role if it is already shared with them
+func (api *API) modifySharedWith(c *gin.Context, collection *mongo.Collection, itemID primitive.ObjectID, ownerID primitive.ObjectID) {
+	var params SharedWithParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	owner, err := database.GetUser(api.DB, ownerID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	email, err := validateSharedWithParams(params, owner.Email)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+
+	itemFilter := []bson.M{
+		{"_id": itemID},
+		{"user_id": ownerID},
+	}
+	res, err := collection.UpdateOne(
+		context.Background(),
+		bson.M{"$and": append(itemFilter, bson.M{"shared_with.email": email})},
+		bson.M{"$set": bson.M{"shared_with.$.role": params.Role}},
+	)
+	if err == nil && res.MatchedCount == 0 {
+		// the item only matches while it has room for another person and isn't shared with them yet
+		res, err = collection.UpdateOne(
+			context.Background(),
+			bson.M{"$and": append(itemFilter,
+				bson.M{"shared_with.email": bson.M{"$ne": email}},
+				bson.M{fmt.Sprintf("shared_with.%d", constants.MAX_SHARED_WITH-1): bson.M{"$exists": false}},
+			)},
+			bson.M{"$push": bson.M{"shared_with": database.SharedWithEntry{
+				Email:    email,
+				Role:     params.Role,
+				SharedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+			}}},
+		)
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update shared with")
+		Handle500(c)
+		return
+	}
+	if res.MatchedCount == 0 {
+		c.JSON(400, gin.H{"detail": "item is shared with too many people"})
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) deleteSharedWith(c *gin.Context, collection *mongo.Collection, itemID primitive.ObjectID, ownerID primitive.ObjectID) {
+	email := strings.ToLower(strings.TrimSpace(c.Query("email")))
+	res, err := collection.UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": itemID},
+			{"user_id": ownerID},
+		}},
+		bson.M{"$pull": bson.M{"shared_with": bson.M{"email": email}}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update shared with")
+		Handle500(c)
+		return
+	}
+	if res.ModifiedCount == 0 {
+		c.JSON(404, gin.H{"detail": "item is not shared with this email"})
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// returns the normalized email
+func validateSharedWithParams(params SharedWithParams, ownerEmail string) (string, error) {
+	address, err := mail.ParseAddress(params.Email)
+	if err != nil {
+		return "", errors.New("invalid email address")
+	}
+	email := strings.ToLower(address.Address)
+	if email == strings.ToLower(ownerEmail) {
+		return "", errors.New("cannot share an item with its owner")
+	}
+	if params.Role != constants.AccessControlReader && params.Role != constants.AccessControlCommenter {
+		return "", errors.New("invalid role, must be one of 'reader' or 'commenter'")
+	}
+	return email, nil
+}
+
+func (api *API) listSharedItemViews(c *gin.Context, itemID primitive.ObjectID) {
+	var views []database.SharedItemView
+	cursor, err := database.GetSharedItemViewCollection(api.DB).Find(
+		context.Background(),
+		bson.M{"item_id": itemID},
+		options.Find().SetSort(bson.M{"last_viewed_at": -1}),
+	)
+	if err == nil {
+		err = cursor.All(context.Background(), &views)
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch shared item views")
+		Handle500(c)
+		return
+	}
+	results := []SharedItemViewResult{}
+	for _, view := range views {
+		results = append(results, SharedItemViewResult{
+			ViewerEmail:   view.ViewerEmail,
+			FirstViewedAt: view.FirstViewedAt.Time().UTC().Format(time.RFC3339),
+			LastViewedAt:  view.LastViewedAt.Time().UTC().Format(time.RFC3339),
+			ViewCount:     view.ViewCount,
+		})
+	}
+	c.JSON(200, results)
+}
+
+// views by the owner and by signed out users are not recorded
+func (api *API) recordSharedItemView(itemType string, itemID primitive.ObjectID, ownerID primitive.ObjectID, viewerID *primitive.ObjectID) {
+	if viewerID == nil || *viewerID == ownerID {
+		return
+	}
+	viewer, err := database.GetUser(api.DB, *viewerID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find viewer of shared item")
+		return
+	}
+	now := primitive.NewDateTimeFromTime(api.GetCurrentTime())
+	_, err = database.GetSharedItemViewCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"item_id": itemID},
+			{"viewer_id": *viewerID},
+		}},
+		bson.M{
+			"$setOnInsert": bson.M{
+				"item_type":       itemType,
+				"viewer_email":    strings.ToLower(viewer.Email),
+				"first_viewed_at": now,
+			},
+			"$set": bson.M{"last_viewed_at": now},
+			"$inc": bson.M{"view_count": 1},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to record shared item view")
+	}
+}
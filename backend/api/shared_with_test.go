@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateSharedWithParams(t *testing.T) {
+	t.Run("InvalidEmail", func(t *testing.T) {
+		_, err := validateSharedWithParams(SharedWithParams{Email: "nope", Role: constants.AccessControlReader}, "owner@generaltask.com")
+		assert.EqualError(t, err, "invalid email address")
+	})
+	t.Run("Owner", func(t *testing.T) {
+		_, err := validateSharedWithParams(SharedWithParams{Email: "Owner@GeneralTask.com", Role: constants.AccessControlReader}, "owner@generaltask.com")
+		assert.EqualError(t, err, "cannot share an item with its owner")
+	})
+	t.Run("InvalidRole", func(t *testing.T) {
+		_, err := validateSharedWithParams(SharedWithParams{Email: "friend@generaltask.com", Role: constants.AccessControlOwner}, "owner@generaltask.com")
+		assert.EqualError(t, err, "invalid role, must be one of 'reader' or 'commenter'")
+	})
+	t.Run("Success", func(t *testing.T) {
+		email, err := validateSharedWithParams(SharedWithParams{Email: "Friend <Friend@GeneralTask.com>", Role: constants.AccessControlCommenter}, "owner@generaltask.com")
+		assert.NoError(t, err)
+		assert.Equal(t, "friend@generaltask.com", email)
+	})
+}
+
+func TestSharedWith(t *testing.T) {
+	ownerToken := login("test_shared_with_owner@generaltask.com", "Owner")
+	friendToken := login("test_shared_with_friend@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	ownerID := getUserIDFromAuthToken(t, api.DB, ownerToken)
+	friendID := getUserIDFromAuthToken(t, api.DB, friendToken)
+	currentTime := time.Date(2022, time.November, 14, 9, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+
+	title := "shared task"
+	taskResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+		UserID:   ownerID,
+		Title:    &title,
+		SourceID: external.TASK_SOURCE_ID_GT_TASK,
+	})
+	assert.NoError(t, err)
+	taskID := taskResult.InsertedID.(primitive.ObjectID)
+	linearTaskResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+		UserID:   ownerID,
+		SourceID: external.TASK_SOURCE_ID_LINEAR,
+	})
+	assert.NoError(t, err)
+	linearTaskID := linearTaskResult.InsertedID.(primitive.ObjectID)
+	noteTitle := "shared note"
+	noteResult, err := database.GetNoteCollection(api.DB).InsertOne(context.Background(), database.Note{
+		UserID: ownerID,
+		Title:  &noteTitle,
+	})
+	assert.NoError(t, err)
+	noteID := noteResult.InsertedID.(primitive.ObjectID)
+
+	UnauthorizedTest(t, "PATCH", "/tasks/"+taskID.Hex()+"/shared_with/", nil)
+	UnauthorizedTest(t, "GET", "/shared_with_me/", nil)
+	t.Run("NotOwner", func(t *testing.T) {
+		ServeRequest(t, friendToken, "PATCH", "/tasks/"+taskID.Hex()+"/shared_with/", bytes.NewBuffer([]byte(`{"email":"other@generaltask.com","role":"reader"}`)), http.StatusNotFound, api)
+		ServeRequest(t, friendToken, "GET", "/notes/"+noteID.Hex()+"/views/", nil, http.StatusNotFound, api)
+	})
+	t.Run("NotGeneralTaskTask", func(t *testing.T) {
+		body := ServeRequest(t, ownerToken, "PATCH", "/tasks/"+linearTaskID.Hex()+"/shared_with/", bytes.NewBuffer([]byte(`{"email":"other@generaltask.com","role":"reader"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"only General Task tasks can be shared"}`, string(body))
+	})
+	t.Run("InvalidRole", func(t *testing.T) {
+		body := ServeRequest(t, ownerToken, "PATCH", "/tasks/"+taskID.Hex()+"/shared_with/", bytes.NewBuffer([]byte(`{"email":"other@generaltask.com","role":"owner"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid role, must be one of 'reader' or 'commenter'"}`, string(body))
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, ownerToken, "PATCH", "/tasks/"+taskID.Hex()+"/shared_with/", bytes.NewBuffer([]byte(`{"email":"Test_Shared_With_Friend@generaltask.com","role":"reader"}`)), http.StatusOK, api)
+		// sharing again updates the role
+		ServeRequest(t, ownerToken, "PATCH", "/tasks/"+taskID.Hex()+"/shared_with/", bytes.NewBuffer([]byte(`{"email":"test_shared_with_friend@generaltask.com","role":"commenter"}`)), http.StatusOK, api)
+		ServeRequest(t, ownerToken, "PATCH", "/notes/"+noteID.Hex()+"/shared_with/", bytes.NewBuffer([]byte(`{"email":"test_shared_with_friend@generaltask.com","role":"reader"}`)), http.StatusOK, api)
+
+		task, err := database.GetTask(api.DB, taskID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, []database.SharedWithEntry{{
+			Email:    "test_shared_with_friend@generaltask.com",
+			Role:     constants.AccessControlCommenter,
+			SharedAt: primitive.NewDateTimeFromTime(currentTime),
+		}}, task.SharedWith)
+	})
+	t.Run("SharedWithMe", func(t *testing.T) {
+		body := ServeRequest(t, friendToken, "GET", "/shared_with_me/", nil, http.StatusOK, api)
+		var results []SharedWithMeResult
+		err := json.Unmarshal(body, &results)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(results))
+		assert.Equal(t, SharedWithMeResult{
+			ID:         taskID,
+			ItemType:   constants.SharedItemTypeTask,
+			Title:      "shared task",
+			OwnerName:  "Owner",
+			OwnerEmail: "test_shared_with_owner@generaltask.com",
+			Role:       constants.AccessControlCommenter,
+			SharedAt:   "2022-11-14T09:00:00Z",
+		}, results[0])
+		assert.Equal(t, noteID, results[1].ID)
+		assert.Equal(t, constants.AccessControlReader, results[1].Role)
+	})
+	t.Run("Views", func(t *testing.T) {
+		ServeRequest(t, friendToken, "GET", "/notes/detail/"+noteID.Hex()+"/", nil, http.StatusOK, api)
+		ServeRequest(t, friendToken, "GET", "/notes/detail/"+noteID.Hex()+"/", nil, http.StatusOK, api)
+		// views by the owner are not recorded
+		api.recordSharedItemView(constants.SharedItemTypeNote, noteID, ownerID, &ownerID)
+
+		body := ServeRequest(t, ownerToken, "GET", "/notes/"+noteID.Hex()+"/views/", nil, http.StatusOK, api)
+		assert.Equal(t, `[{"viewer_email":"test_shared_with_friend@generaltask.com","first_viewed_at":"2022-11-14T09:00:00Z","last_viewed_at":"2022-11-14T09:00:00Z","view_count":2}]`, string(body))
+
+		var view database.SharedItemView
+		err := database.GetSharedItemViewCollection(api.DB).FindOne(context.Background(), bson.M{"item_id": noteID}).Decode(&view)
+		assert.NoError(t, err)
+		assert.Equal(t, friendID, view.ViewerID)
+	})
+	t.Run("Revoke", func(t *testing.T) {
+		body := ServeRequest(t, ownerToken, "DELETE", "/tasks/"+taskID.Hex()+"/shared_with/?email=other@generaltask.com", nil, http.StatusNotFound, api)
+		assert.Equal(t, `{"detail":"item is not shared with this email"}`, string(body))
+		ServeRequest(t, ownerToken, "DELETE", "/tasks/"+taskID.Hex()+"/shared_with/?email=test_shared_with_friend@generaltask.com", nil, http.StatusOK, api)
+		ServeRequest(t, friendToken, "GET", "/shareable_tasks/detail/"+taskID.Hex()+"/", nil, http.StatusNotFound, api)
+
+		body = ServeRequest(t, friendToken, "GET", "/shared_with_me/", nil, http.StatusOK, api)
+		var results []SharedWithMeResult
+		err := json.Unmarshal(body, &results)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, noteID, results[0].ID)
+	})
+	t.Run("TooManyPeople", func(t *testing.T) {
+		sharedWith := []database.SharedWithEntry{}
+		for i := 0; i < constants.MAX_SHARED_WITH; i++ {
+			sharedWith = append(sharedWith, database.SharedWithEntry{Email: fmt.Sprintf("person%d@generaltask.com", i), Role: constants.AccessControlReader})
+		}
+		_, err := database.GetTaskCollection(api.DB).UpdateOne(context.Background(), bson.M{"_id": taskID}, bson.M{"$set": bson.M{"shared_with": sharedWith}})
+		assert.NoError(t, err)
+
+		body := ServeRequest(t, ownerToken, "PATCH", "/tasks/"+taskID.Hex()+"/shared_with/", bytes.NewBuffer([]byte(`{"email":"other@generaltask.com","role":"reader"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"item is shared with too many people"}`, string(body))
+		// people it is already shared with can still have their role changed
+		ServeRequest(t, ownerToken, "PATCH", "/tasks/"+taskID.Hex()+"/shared_with/", bytes.NewBuffer([]byte(`{"email":"person0@generaltask.com","role":"commenter"}`)), http.StatusOK, api)
+		task, err := database.GetTask(api.DB, taskID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.MAX_SHARED_WITH, len(task.SharedWith))
+		assert.Equal(t, constants.AccessControlCommenter, task.SharedWith[0].Role)
+	})
+}
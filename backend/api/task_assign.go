@@ -0,0 +1,280 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/jobs"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TaskAssignParams struct {
+	Email string `json:"email" binding:"required"`
+}
+
+type TaskAssignmentModifyParams struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type TaskAssignmentResult struct {
+	AssigneeName    string `json:"assignee_name"`
+	AssigneeEmail   string `json:"assignee_email"`
+	AssignedByName  string `json:"assigned_by_name"`
+	AssignedByEmail string `json:"assigned_by_email"`
+	Status          string `json:"status"`
+	AssignedAt      string `json:"assigned_at"`
+}
+
+// delegates one of the user's General Task tasks, the assignee becomes the owner of the task and its subtasks
+func (api *API) TaskAssign(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params TaskAssignParams
+	err = c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing 'email' parameter"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	task, err := database.GetTask(api.DB, taskID, userID)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "task not found.", "taskId": taskID})
+		return
+	}
+	if task.SourceID != external.TASK_SOURCE_ID_GT_TASK {
+		c.JSON(400, gin.H{"detail": "only General Task tasks can be assigned"})
+		return
+	}
+	if task.ParentTaskID != primitive.NilObjectID {
+		c.JSON(400, gin.H{"detail": "subtasks are assigned with their parent task"})
+		return
+	}
+	if task.WorkspaceID != primitive.NilObjectID {
+		c.JSON(400, gin.H{"detail": "workspace tasks are assigned from their workspace"})
+		return
+	}
+	assigner, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	assignee, err := database.GetUserByEmail(api.DB, strings.TrimSpace(params.Email))
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "assignee not found"})
+		return
+	}
+	if assignee.ID == userID {
+		c.JSON(400, gin.H{"detail": "cannot assign a task to yourself"})
+		return
+	}
+	if !api.canAssignTask(assigner, assignee) {
+		c.JSON(400, gin.H{"detail": "tasks can only be assigned to people in your email domain or workspaces"})
+		return
+	}
+	err = api.transferTask(task, userID, assignee.ID, constants.IDTaskSectionDefault, constants.DefaultTaskIDOrdering, bson.M{
+		"assignee_id":           assignee.ID,
+		"assigned_by":           userID,
+		"assignment_status":     constants.TaskAssignmentStatusPending,
+		"assigned_at":           primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+		"assigner_task_section": task.IDTaskSection,
+		"assigner_id_ordering":  task.IDOrdering,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to assign task")
+		Handle500(c)
+		return
+	}
+	api.notifyTaskAssigned(task, assignee.ID, assigner)
+	c.JSON(200, gin.H{})
+}
+
+// lets the assignee accept or decline a pending assignment, declined delegated tasks go back to the assigner
+func (api *API) TaskAssignmentModify(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params TaskAssignmentModifyParams
+	err = c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing 'status' parameter"})
+		return
+	}
+	if params.Status != constants.TaskAssignmentStatusAccepted && params.Status != constants.TaskAssignmentStatusDeclined {
+		c.JSON(400, gin.H{"detail": "invalid status, must be one of 'accepted' or 'declined'"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	task, err := api.getModifiableTask(taskID, userID)
+	if err != nil || task.AssigneeID != userID {
+		c.JSON(404, gin.H{"detail": "task not found.", "taskId": taskID})
+		return
+	}
+	if task.AssignmentStatus != constants.TaskAssignmentStatusPending {
+		c.JSON(400, gin.H{"detail": "the assignment has already been " + task.AssignmentStatus})
+		return
+	}
+	update := bson.M{"assignment_status": params.Status}
+	if params.Status == constants.TaskAssignmentStatusDeclined && task.UserID == userID && task.AssignedBy != primitive.NilObjectID {
+		taskSectionID, idOrdering := api.getAssignerTaskPosition(task)
+		err = api.transferTask(task, userID, task.AssignedBy, taskSectionID, idOrdering, update)
+	} else {
+		_, err = database.GetTaskCollection(api.DB).UpdateOne(context.Background(), bson.M{"_id": task.ID}, bson.M{"$set": update})
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update task assignment")
+		Handle500(c)
+		return
+	}
+	if params.Status == constants.TaskAssignmentStatusDeclined && task.AssignedBy != primitive.NilObjectID {
+		assignee, err := database.GetUser(api.DB, userID)
+		if err == nil {
+			api.notifyTaskAssignment(task, task.AssignedBy, assignee, constants.NotificationTypeTaskAssignmentDeclined, "%s declined \"%s\"")
+		}
+	}
+	c.JSON(200, gin.H{})
+}
+
+// people can assign tasks to others with the same company email domain, or who are in one of their workspaces
+func (api *API) canAssignTask(assigner *database.User, assignee *database.User) bool {
+	assignerDomain, err := database.GetEmailDomain(strings.ToLower(assigner.Email))
+	if err == nil && !utils.IsOpenEmailAddress(assignerDomain) {
+		assigneeDomain, err := database.GetEmailDomain(strings.ToLower(assignee.Email))
+		if err == nil && assignerDomain == assigneeDomain {
+			return true
+		}
+	}
+	hasSharedWorkspace, err := database.HasSharedWorkspace(api.DB, assigner.ID, assignee.ID)
+	return err == nil && hasSharedWorkspace
+}
+
+// declined tasks go back to the section and position they were assigned from, unless that section was deleted
+func (api *API) getAssignerTaskPosition(task *database.Task) (primitive.ObjectID, int) {
+	if task.AssignerTaskSection == primitive.NilObjectID {
+		return constants.IDTaskSectionDefault, constants.DefaultTaskIDOrdering
+	}
+	_, err := database.GetTaskSectionName(api.DB, task.AssignerTaskSection, task.AssignedBy)
+	if err != nil {
+		return constants.IDTaskSectionDefault, constants.DefaultTaskIDOrdering
+	}
+	return task.AssignerTaskSection, task.AssignerIDOrdering
+}
+
+// moves the task and its subtasks to a section of the new owner
+func (api *API) transferTask(task *database.Task, fromUserID primitive.ObjectID, toUserID primitive.ObjectID, taskSectionID primitive.ObjectID, idOrdering int, assignmentFields bson.M) error {
+	setFields := bson.M{
+		"user_id":         toUserID,
+		"id_task_section": taskSectionID,
+		"id_ordering":     idOrdering,
+		"updated_at":      primitive.NewDateTimeFromTime(time.Now()),
+	}
+	for key, value := range assignmentFields {
+		setFields[key] = value
+	}
+	taskCollection := database.GetTaskCollection(api.DB)
+	result, err := taskCollection.UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": task.ID},
+			{"user_id": fromUserID},
+		}},
+		bson.M{"$set": setFields, "$unset": bson.M{"snoozed_until": ""}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount != 1 {
+		return errors.New("failed to transfer task")
+	}
+	_, err = taskCollection.UpdateMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"parent_task_id": task.ID},
+			{"user_id": fromUserID},
+		}},
+		bson.M{"$set": bson.M{"user_id": toUserID}},
+	)
+	return err
+}
+
+func (api *API) notifyTaskAssignment(task *database.Task, recipientID primitive.ObjectID, actor *database.User, notificationType string, titleFormat string) {
+	actorName := actor.Name
+	if actorName == "" {
+		actorName = actor.Email
+	}
+	taskTitle := ""
+	if task.Title != nil {
+		taskTitle = *task.Title
+	}
+	err := jobs.SendNotification(api.DB, &database.Notification{
+		UserID:    recipientID,
+		TaskID:    task.ID,
+		Type:      notificationType,
+		Title:     fmt.Sprintf(titleFormat, actorName, taskTitle),
+		CreatedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to send task assignment notification")
+	}
+}
+
+func (api *API) notifyTaskAssigned(task *database.Task, assigneeID primitive.ObjectID, assigner *database.User) {
+	api.notifyTaskAssignment(task, assigneeID, assigner, constants.NotificationTypeTaskAssigned, "%s assigned you \"%s\"")
+}
+
+// lets the assigner know when someone else completes a task they assigned
+func (api *API) notifyAssignedTaskCompleted(task *database.Task, completedByID primitive.ObjectID) {
+	if task.AssignedBy == primitive.NilObjectID || task.AssignedBy == completedByID || task.AssignmentStatus == constants.TaskAssignmentStatusDeclined {
+		return
+	}
+	if task.IsCompleted != nil && *task.IsCompleted {
+		return
+	}
+	completedBy, err := database.GetUser(api.DB, completedByID)
+	if err != nil {
+		return
+	}
+	api.notifyTaskAssignment(task, task.AssignedBy, completedBy, constants.NotificationTypeAssignedTaskCompleted, "%s completed \"%s\"")
+}
+
+func (api *API) getTaskAssignmentResult(task *database.Task) *TaskAssignmentResult {
+	if task.AssignedBy == primitive.NilObjectID || task.AssigneeID == primitive.NilObjectID {
+		return nil
+	}
+	result := &TaskAssignmentResult{
+		Status:     task.AssignmentStatus,
+		AssignedAt: task.AssignedAt.Time().UTC().Format(time.RFC3339),
+	}
+	assignee, err := database.GetUser(api.DB, task.AssigneeID)
+	if err == nil {
+		result.AssigneeName = assignee.Name
+		result.AssigneeEmail = assignee.Email
+	}
+	assigner, err := database.GetUser(api.DB, task.AssignedBy)
+	if err == nil {
+		result.AssignedByName = assigner.Name
+		result.AssignedByEmail = assigner.Email
+	}
+	return result
+}
+
+// assigning a task to yourself needs no acceptance
+func newTaskAssignmentStatus(assignedBy primitive.ObjectID, assigneeID primitive.ObjectID) string {
+	if assignedBy == assigneeID {
+		return constants.TaskAssignmentStatusAccepted
+	}
+	return constants.TaskAssignmentStatusPending
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskAssign(t *testing.T) {
+	assigneeEmail := createRandomGTEmail()
+	assignerToken := login(createRandomGTEmail(), "Assigner")
+	assigneeToken := login(assigneeEmail, "Assignee")
+	login("assign_outsider@aol.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	assignerID := getUserIDFromAuthToken(t, api.DB, assignerToken)
+	assigneeID := getUserIDFromAuthToken(t, api.DB, assigneeToken)
+	taskCollection := database.GetTaskCollection(api.DB)
+
+	sectionResult, err := database.GetTaskSectionCollection(api.DB).InsertOne(context.Background(), database.TaskSection{
+		UserID: assignerID,
+		Name:   "Launch",
+	})
+	assert.NoError(t, err)
+	sectionID := sectionResult.InsertedID.(primitive.ObjectID)
+	notCompleted := false
+	title := "write the launch post"
+	insertResult, err := taskCollection.InsertOne(context.Background(), database.Task{
+		UserID:        assignerID,
+		Title:         &title,
+		IDTaskSection: sectionID,
+		IDOrdering:    3,
+		SourceID:      external.TASK_SOURCE_ID_GT_TASK,
+		IsCompleted:   &notCompleted,
+	})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	subtaskResult, err := taskCollection.InsertOne(context.Background(), database.Task{
+		UserID:       assignerID,
+		ParentTaskID: taskID,
+		SourceID:     external.TASK_SOURCE_ID_GT_TASK,
+		IsCompleted:  &notCompleted,
+	})
+	assert.NoError(t, err)
+	subtaskID := subtaskResult.InsertedID.(primitive.ObjectID)
+	assignURL := "/tasks/" + taskID.Hex() + "/assign/"
+	assignmentURL := "/tasks/" + taskID.Hex() + "/assignment/"
+	getTask := func() database.Task {
+		var task database.Task
+		assert.NoError(t, taskCollection.FindOne(context.Background(), bson.M{"_id": taskID}).Decode(&task))
+		return task
+	}
+	countNotifications := func(userID primitive.ObjectID, notificationType string) int64 {
+		count, err := database.GetNotificationCollection(api.DB).CountDocuments(context.Background(), bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"task_id": taskID},
+			{"type": notificationType},
+		}})
+		assert.NoError(t, err)
+		return count
+	}
+
+	UnauthorizedTest(t, "POST", assignURL, nil)
+	t.Run("InvalidAssignee", func(t *testing.T) {
+		ServeRequest(t, assignerToken, "POST", assignURL, bytes.NewBuffer([]byte(`{"email":"nobody@generaltask.com"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, assignerToken, "POST", assignURL, bytes.NewBuffer([]byte(`{"email":"assign_outsider@aol.com"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, assigneeToken, "POST", assignURL, bytes.NewBuffer([]byte(`{"email":"`+assigneeEmail+`"}`)), http.StatusNotFound, api)
+		ServeRequest(t, assignerToken, "POST", "/tasks/"+subtaskID.Hex()+"/assign/", bytes.NewBuffer([]byte(`{"email":"`+assigneeEmail+`"}`)), http.StatusBadRequest, api)
+	})
+	t.Run("Decline", func(t *testing.T) {
+		ServeRequest(t, assignerToken, "POST", assignURL, bytes.NewBuffer([]byte(`{"email":"`+assigneeEmail+`"}`)), http.StatusOK, api)
+		task := getTask()
+		assert.Equal(t, assigneeID, task.UserID)
+		assert.Equal(t, assigneeID, task.AssigneeID)
+		assert.Equal(t, assignerID, task.AssignedBy)
+		assert.Equal(t, constants.TaskAssignmentStatusPending, task.AssignmentStatus)
+		assert.Equal(t, constants.IDTaskSectionDefault, task.IDTaskSection)
+		subtask, err := database.GetTask(api.DB, subtaskID, assigneeID)
+		assert.NoError(t, err)
+		assert.Equal(t, taskID, subtask.ParentTaskID)
+		assert.Equal(t, int64(1), countNotifications(assigneeID, constants.NotificationTypeTaskAssigned))
+
+		// only the assignee responds to the assignment
+		ServeRequest(t, assignerToken, "PATCH", assignmentURL, bytes.NewBuffer([]byte(`{"status":"declined"}`)), http.StatusNotFound, api)
+		ServeRequest(t, assigneeToken, "PATCH", assignmentURL, bytes.NewBuffer([]byte(`{"status":"maybe"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, assigneeToken, "PATCH", assignmentURL, bytes.NewBuffer([]byte(`{"status":"declined"}`)), http.StatusOK, api)
+		task = getTask()
+		assert.Equal(t, assignerID, task.UserID)
+		assert.Equal(t, constants.TaskAssignmentStatusDeclined, task.AssignmentStatus)
+		// the task goes back to where it was in the assigner's list
+		assert.Equal(t, sectionID, task.IDTaskSection)
+		assert.Equal(t, 3, task.IDOrdering)
+		_, err = database.GetTask(api.DB, subtaskID, assignerID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), countNotifications(assignerID, constants.NotificationTypeTaskAssignmentDeclined))
+	})
+	t.Run("AcceptAndComplete", func(t *testing.T) {
+		ServeRequest(t, assignerToken, "POST", assignURL, bytes.NewBuffer([]byte(`{"email":"`+assigneeEmail+`"}`)), http.StatusOK, api)
+		ServeRequest(t, assigneeToken, "PATCH", assignmentURL, bytes.NewBuffer([]byte(`{"status":"accepted"}`)), http.StatusOK, api)
+		ServeRequest(t, assigneeToken, "PATCH", assignmentURL, bytes.NewBuffer([]byte(`{"status":"declined"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, constants.TaskAssignmentStatusAccepted, getTask().AssignmentStatus)
+
+		ServeRequest(t, assigneeToken, "PATCH", "/tasks/modify/"+taskID.Hex()+"/", bytes.NewBuffer([]byte(`{"is_completed":true}`)), http.StatusOK, api)
+		assert.True(t, *getTask().IsCompleted)
+		assert.Equal(t, int64(1), countNotifications(assignerID, constants.NotificationTypeAssignedTaskCompleted))
+	})
+	t.Run("BulkComplete", func(t *testing.T) {
+		ServeRequest(t, assigneeToken, "PATCH", "/tasks/modify/"+taskID.Hex()+"/", bytes.NewBuffer([]byte(`{"is_completed":false}`)), http.StatusOK, api)
+		ServeRequest(t, assigneeToken, "POST", "/tasks/bulk_modify/", bytes.NewBuffer([]byte(`{"operation":"complete","task_ids":["`+taskID.Hex()+`"]}`)), http.StatusOK, api)
+		assert.True(t, *getTask().IsCompleted)
+		assert.Equal(t, int64(2), countNotifications(assignerID, constants.NotificationTypeAssignedTaskCompleted))
+	})
+}
+
+func TestGetDelegatedOverviewResult(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	currentTime := time.Date(2023, time.January, 4, 20, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+
+	userID := primitive.NewObjectID()
+	assigneeID := primitive.NewObjectID()
+	view := database.View{
+		UserID:     userID,
+		IDOrdering: 1,
+		Type:       "delegated",
+		IsLinked:   true,
+	}
+	expectedViewResult := OverviewResult[TaskResult]{
+		ID:            view.ID,
+		Name:          "Delegated by me",
+		Type:          constants.ViewDelegated,
+		Logo:          external.TaskServiceGeneralTask.LogoV2,
+		IsLinked:      true,
+		Sources:       []SourcesResult{},
+		IsReorderable: false,
+		IDOrdering:    1,
+		TaskSectionID: primitive.NilObjectID,
+	}
+
+	t.Run("EmptyViewItems", func(t *testing.T) {
+		result, err := api.GetDelegatedOverviewResult(view, userID)
+		assert.NoError(t, err)
+		expectedViewResult.ViewItems = []*TaskResult{}
+		assertOverviewViewResultEqual(t, expectedViewResult, *result)
+	})
+	t.Run("SuccessTaskViewItems", func(t *testing.T) {
+		notCompleted := false
+		completed := true
+		items := []interface{}{
+			// assigned earlier
+			database.Task{
+				UserID:      assigneeID,
+				IsCompleted: &notCompleted,
+				SourceID:    external.TASK_SOURCE_ID_GT_TASK,
+				AssigneeID:  assigneeID,
+				AssignedBy:  userID,
+				AssignedAt:  primitive.NewDateTimeFromTime(currentTime.Add(-2 * time.Hour)),
+			},
+			// assigned later, then declined
+			database.Task{
+				UserID:           userID,
+				IsCompleted:      &notCompleted,
+				SourceID:         external.TASK_SOURCE_ID_GT_TASK,
+				AssigneeID:       assigneeID,
+				AssignedBy:       userID,
+				AssignmentStatus: constants.TaskAssignmentStatusDeclined,
+				AssignedAt:       primitive.NewDateTimeFromTime(currentTime.Add(-time.Hour)),
+			},
+			// completed too long ago
+			database.Task{
+				UserID:      assigneeID,
+				IsCompleted: &completed,
+				CompletedAt: primitive.NewDateTimeFromTime(currentTime.Add(-30 * 24 * time.Hour)),
+				SourceID:    external.TASK_SOURCE_ID_GT_TASK,
+				AssigneeID:  assigneeID,
+				AssignedBy:  userID,
+			},
+			// assigned by someone else
+			database.Task{
+				UserID:      assigneeID,
+				IsCompleted: &notCompleted,
+				SourceID:    external.TASK_SOURCE_ID_GT_TASK,
+				AssigneeID:  assigneeID,
+				AssignedBy:  primitive.NewObjectID(),
+			},
+		}
+		taskResult, err := database.GetTaskCollection(api.DB).InsertMany(context.Background(), items)
+		assert.NoError(t, err)
+		earlierTaskID := taskResult.InsertedIDs[0].(primitive.ObjectID)
+		laterTaskID := taskResult.InsertedIDs[1].(primitive.ObjectID)
+
+		result, err := api.GetDelegatedOverviewResult(view, userID)
+		assert.NoError(t, err)
+		expectedViewResult.ViewItems = []*TaskResult{
+			{ID: laterTaskID},
+			{ID: earlierTaskID},
+		}
+		expectedViewResult.ViewItemIDs = []string{laterTaskID.Hex(), earlierTaskID.Hex()}
+		assertOverviewViewResultEqual(t, expectedViewResult, *result)
+		assert.Equal(t, constants.TaskAssignmentStatusDeclined, result.ViewItems[0].Assignment.Status)
+	})
+	t.Run("InvalidUser", func(t *testing.T) {
+		result, err := api.GetDelegatedOverviewResult(view, primitive.NewObjectID())
+		assert.EqualError(t, err, "invalid user")
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,211 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TaskBulkModifyParams struct {
+	TaskIDs            []string `json:"task_ids" binding:"required"`
+	Operation          string   `json:"operation" binding:"required"`
+	IDTaskSection      *string  `json:"id_task_section"`
+	DueDate            *string  `json:"due_date"`
+	PriorityNormalized *float64 `json:"priority_normalized"`
+	Label              *string  `json:"label"`
+}
+
+type TaskBulkModifyResult struct {
+	TaskID  string `json:"task_id"`
+	Success bool   `json:"success"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// bulkModifyChange holds the validated, task-independent values of a bulk modify request
+type bulkModifyChange struct {
+	operation          string
+	idTaskSection      primitive.ObjectID
+	dueDate            *primitive.DateTime
+	priorityNormalized float64
+	label              string
+}
+
+func (api *API) TaskBulkModify(c *gin.Context) {
+	var params TaskBulkModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "parameter missing or malformatted"})
+		return
+	}
+	if len(params.TaskIDs) == 0 {
+		c.JSON(400, gin.H{"detail": "'task_ids' cannot be empty"})
+		return
+	}
+	if len(params.TaskIDs) > constants.MAX_BULK_MODIFY_TASKS {
+		c.JSON(400, gin.H{"detail": "too many tasks in request"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	change := bulkModifyChange{operation: params.Operation}
+	switch params.Operation {
+	case constants.BulkOperationComplete, constants.BulkOperationDelete:
+	case constants.BulkOperationMoveToSection:
+		if params.IDTaskSection == nil {
+			c.JSON(400, gin.H{"detail": "'id_task_section' is required for this operation"})
+			return
+		}
+		change.idTaskSection, err = getValidTaskSection(*params.IDTaskSection, userID, api.DB)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": "'id_task_section' is not a valid ID"})
+			return
+		}
+	case constants.BulkOperationSetDueDate:
+		if params.DueDate == nil {
+			c.JSON(400, gin.H{"detail": "'due_date' is required for this operation"})
+			return
+		}
+		change.dueDate, err = parseDueDate(*params.DueDate)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": "due_date is not a valid date"})
+			return
+		}
+	case constants.BulkOperationSetPriority:
+		if params.PriorityNormalized == nil {
+			c.JSON(400, gin.H{"detail": "'priority_normalized' is required for this operation"})
+			return
+		}
+		change.priorityNormalized = *params.PriorityNormalized
+	case constants.BulkOperationAddLabel:
+		if params.Label == nil || strings.TrimSpace(*params.Label) == "" {
+			c.JSON(400, gin.H{"detail": "'label' is required for this operation"})
+			return
+		}
+		change.label = strings.TrimSpace(*params.Label)
+	default:
+		c.JSON(400, gin.H{"detail": "unsupported 'operation'"})
+		return
+	}
+
+	results := make([]TaskBulkModifyResult, len(params.TaskIDs))
+	tasks := make([]*database.Task, len(params.TaskIDs))
+	sourceToIndexes := make(map[string][]int)
+	for index, taskIDHex := range params.TaskIDs {
+		results[index] = TaskBulkModifyResult{TaskID: taskIDHex}
+		taskID, err := primitive.ObjectIDFromHex(taskIDHex)
+		if err != nil {
+			results[index].Detail = "task not found"
+			continue
+		}
+		task, err := database.GetTask(api.DB, taskID, userID)
+		if err != nil {
+			results[index].Detail = "task not found"
+			continue
+		}
+		tasks[index] = task
+		sourceToIndexes[task.SourceID] = append(sourceToIndexes[task.SourceID], index)
+	}
+
+	// each task is written independently, so a failure on one task does not affect the others
+	var wg sync.WaitGroup
+	for _, indexes := range sourceToIndexes {
+		semaphore := make(chan struct{}, constants.MAX_BULK_MODIFY_CONCURRENCY_PER_SOURCE)
+		for _, index := range indexes {
+			wg.Add(1)
+			go func(index int, semaphore chan struct{}) {
+				defer wg.Done()
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+				err := api.bulkModifyTask(userID, tasks[index], change)
+				if err != nil {
+					results[index].Detail = err.Error()
+					return
+				}
+				results[index].Success = true
+			}(index, semaphore)
+		}
+	}
+	wg.Wait()
+
+	c.JSON(200, results)
+}
+
+func (api *API) bulkModifyTask(userID primitive.ObjectID, task *database.Task, change bulkModifyChange) error {
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(task.SourceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load external task source")
+		return errors.New("internal server error")
+	}
+
+	updateTask := database.Task{UpdatedAt: primitive.NewDateTimeFromTime(time.Now())}
+	// sections and labels only exist in General Task, so they never need to be written to the task source
+	requiresExternalWrite := true
+	switch change.operation {
+	case constants.BulkOperationComplete:
+		if !taskSourceResult.Details.IsCompletable || (task.IsDeleted != nil && *task.IsDeleted) {
+			return errors.New("cannot be marked done")
+		}
+		isCompleted := true
+		updateTask.IsCompleted = &isCompleted
+		updateTask.CompletedAt = primitive.NewDateTimeFromTime(time.Now())
+	case constants.BulkOperationDelete:
+		isDeleted := true
+		updateTask.IsDeleted = &isDeleted
+		updateTask.DeletedAt = primitive.NewDateTimeFromTime(time.Now())
+	case constants.BulkOperationMoveToSection:
+		if task.ParentTaskID != primitive.NilObjectID {
+			return errors.New("subtasks cannot be moved to a section")
+		}
+		updateTask.IDTaskSection = change.idTaskSection
+		updateTask.HasBeenReordered = true
+		requiresExternalWrite = false
+	case constants.BulkOperationSetDueDate:
+		updateTask.DueDate = change.dueDate
+	case constants.BulkOperationSetPriority:
+		priorityNormalized := change.priorityNormalized
+		updateTask.PriorityNormalized = &priorityNormalized
+		if len(task.AllExternalPriorities) > 0 {
+			for _, priority := range task.AllExternalPriorities {
+				if priority.PriorityNormalized == change.priorityNormalized {
+					updateTask.ExternalPriority = priority
+				}
+			}
+			if updateTask.ExternalPriority == nil {
+				return errors.New("priority value not valid for task")
+			}
+		}
+	case constants.BulkOperationAddLabel:
+		labels := []string{}
+		if task.Labels != nil {
+			labels = *task.Labels
+		}
+		for _, label := range labels {
+			if label == change.label {
+				return nil
+			}
+		}
+		labels = append(labels, change.label)
+		updateTask.Labels = &labels
+		requiresExternalWrite = false
+	}
+
+	if requiresExternalWrite {
+		err = taskSourceResult.Source.ModifyTask(api.DB, userID, task.SourceAccountID, task.IDExternal, &updateTask, task)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to update external task source")
+			return errors.New("failed to update external task source")
+		}
+	}
+
+	err = api.UpdateTaskInDBWithError(task, userID, &updateTask)
+	if err != nil {
+		return errors.New("failed to update task")
+	}
+	return nil
+}
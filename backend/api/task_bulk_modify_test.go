@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestTaskBulkModify(t *testing.T) {
+	authToken := login("test_task_bulk_modify@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	taskCollection := database.GetTaskCollection(api.DB)
+
+	createTask := func(sourceID string) primitive.ObjectID {
+		completed := false
+		title := "bulk task"
+		insertResult, err := taskCollection.InsertOne(context.Background(), database.Task{
+			UserID:        userID,
+			IDExternal:    primitive.NewObjectID().Hex(),
+			IDTaskSection: constants.IDTaskSectionDefault,
+			SourceID:      sourceID,
+			Title:         &title,
+			IsCompleted:   &completed,
+		})
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	getTask := func(taskID primitive.ObjectID) database.Task {
+		var task database.Task
+		err := taskCollection.FindOne(context.Background(), bson.M{"_id": taskID}).Decode(&task)
+		assert.NoError(t, err)
+		return task
+	}
+	bulkModify := func(body string, expectedCode int) []TaskBulkModifyResult {
+		response := ServeRequest(t, authToken, "POST", "/tasks/bulk_modify/", bytes.NewBuffer([]byte(body)), expectedCode, api)
+		var results []TaskBulkModifyResult
+		if expectedCode == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(response, &results))
+		}
+		return results
+	}
+
+	UnauthorizedTest(t, "POST", "/tasks/bulk_modify/", nil)
+	t.Run("MissingTaskIDs", func(t *testing.T) {
+		bulkModify(`{"operation": "complete"}`, http.StatusBadRequest)
+		bulkModify(`{"operation": "complete", "task_ids": []}`, http.StatusBadRequest)
+	})
+	t.Run("InvalidOperation", func(t *testing.T) {
+		taskID := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		bulkModify(fmt.Sprintf(`{"operation": "explode", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusBadRequest)
+	})
+	t.Run("MissingOperationParameter", func(t *testing.T) {
+		taskID := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		bulkModify(fmt.Sprintf(`{"operation": "move_to_section", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusBadRequest)
+		bulkModify(fmt.Sprintf(`{"operation": "set_due_date", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusBadRequest)
+		bulkModify(fmt.Sprintf(`{"operation": "set_due_date", "due_date": "tomorrow", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusBadRequest)
+		bulkModify(fmt.Sprintf(`{"operation": "set_priority", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusBadRequest)
+		bulkModify(fmt.Sprintf(`{"operation": "add_label", "label": " ", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusBadRequest)
+	})
+	t.Run("CompleteSuccess", func(t *testing.T) {
+		taskID1 := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		taskID2 := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		results := bulkModify(fmt.Sprintf(`{"operation": "complete", "task_ids": ["%s", "%s"]}`, taskID1.Hex(), taskID2.Hex()), http.StatusOK)
+		assert.Equal(t, []TaskBulkModifyResult{
+			{TaskID: taskID1.Hex(), Success: true},
+			{TaskID: taskID2.Hex(), Success: true},
+		}, results)
+		for _, taskID := range []primitive.ObjectID{taskID1, taskID2} {
+			task := getTask(taskID)
+			assert.True(t, *task.IsCompleted)
+			assert.NotEqual(t, primitive.DateTime(0), task.CompletedAt)
+		}
+	})
+	t.Run("DeleteSuccess", func(t *testing.T) {
+		taskID := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		results := bulkModify(fmt.Sprintf(`{"operation": "delete", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusOK)
+		assert.Equal(t, []TaskBulkModifyResult{{TaskID: taskID.Hex(), Success: true}}, results)
+		task := getTask(taskID)
+		assert.True(t, *task.IsDeleted)
+	})
+	t.Run("MoveToSectionSuccess", func(t *testing.T) {
+		insertResult, err := database.GetTaskSectionCollection(api.DB).InsertOne(context.Background(), database.TaskSection{UserID: userID, Name: "bulk section"})
+		assert.NoError(t, err)
+		sectionID := insertResult.InsertedID.(primitive.ObjectID)
+		taskID := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		results := bulkModify(fmt.Sprintf(`{"operation": "move_to_section", "id_task_section": "%s", "task_ids": ["%s"]}`, sectionID.Hex(), taskID.Hex()), http.StatusOK)
+		assert.Equal(t, []TaskBulkModifyResult{{TaskID: taskID.Hex(), Success: true}}, results)
+		assert.Equal(t, sectionID, getTask(taskID).IDTaskSection)
+	})
+	t.Run("SetDueDateSuccess", func(t *testing.T) {
+		taskID := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		results := bulkModify(fmt.Sprintf(`{"operation": "set_due_date", "due_date": "2021-12-06", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusOK)
+		assert.Equal(t, []TaskBulkModifyResult{{TaskID: taskID.Hex(), Success: true}}, results)
+		assert.Equal(t, "2021-12-06", getTask(taskID).DueDate.Time().UTC().Format(constants.YEAR_MONTH_DAY_FORMAT))
+	})
+	t.Run("SetPrioritySuccess", func(t *testing.T) {
+		taskID := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		results := bulkModify(fmt.Sprintf(`{"operation": "set_priority", "priority_normalized": 2, "task_ids": ["%s"]}`, taskID.Hex()), http.StatusOK)
+		assert.Equal(t, []TaskBulkModifyResult{{TaskID: taskID.Hex(), Success: true}}, results)
+		assert.Equal(t, 2.0, *getTask(taskID).PriorityNormalized)
+	})
+	t.Run("AddLabelSuccess", func(t *testing.T) {
+		taskID := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		bulkModify(fmt.Sprintf(`{"operation": "add_label", "label": "triage", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusOK)
+		// adding the same label twice should not duplicate it
+		results := bulkModify(fmt.Sprintf(`{"operation": "add_label", "label": "triage", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusOK)
+		assert.Equal(t, []TaskBulkModifyResult{{TaskID: taskID.Hex(), Success: true}}, results)
+		bulkModify(fmt.Sprintf(`{"operation": "add_label", "label": "later", "task_ids": ["%s"]}`, taskID.Hex()), http.StatusOK)
+		assert.Equal(t, []string{"triage", "later"}, *getTask(taskID).Labels)
+	})
+	t.Run("PartialFailure", func(t *testing.T) {
+		_, err := database.GetExternalTokenCollection(api.DB).UpdateOne(
+			context.Background(),
+			bson.M{"$and": []bson.M{{"user_id": userID}, {"service_id": external.TASK_SERVICE_ID_LINEAR}}},
+			bson.M{"$set": &database.ExternalAPIToken{
+				ServiceID: external.TASK_SERVICE_ID_LINEAR,
+				Token:     `{"access_token":"sample-token","refresh_token":"sample-token","scope":"sample-scope","expires_in":3600,"token_type":"Bearer"}`,
+				UserID:    userID,
+			}},
+			options.Update().SetUpsert(true),
+		)
+		assert.NoError(t, err)
+		taskUpdateServer := testutils.GetMockAPIServer(t, 400, "")
+		api.ExternalConfig.Linear.ConfigValues.TaskUpdateURL = &taskUpdateServer.URL
+
+		gtTaskID := createTask(external.TASK_SOURCE_ID_GT_TASK)
+		linearTaskID := createTask(external.TASK_SOURCE_ID_LINEAR)
+		otherUserTaskID := primitive.NewObjectID()
+		results := bulkModify(fmt.Sprintf(`{"operation": "delete", "task_ids": ["%s", "%s", "%s", "invalid"]}`, gtTaskID.Hex(), linearTaskID.Hex(), otherUserTaskID.Hex()), http.StatusOK)
+		assert.Equal(t, []TaskBulkModifyResult{
+			{TaskID: gtTaskID.Hex(), Success: true},
+			{TaskID: linearTaskID.Hex(), Success: false, Detail: "failed to update external task source"},
+			{TaskID: otherUserTaskID.Hex(), Success: false, Detail: "task not found"},
+			{TaskID: "invalid", Success: false, Detail: "task not found"},
+		}, results)
+		// the failed external write does not roll back the other local changes
+		assert.True(t, *getTask(gtTaskID).IsDeleted)
+		assert.Nil(t, getTask(linearTaskID).IsDeleted)
+	})
+}
@@ -1,13 +1,38 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
 	"github.com/GeneralTask/task-manager/backend/database"
 	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/jobs"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
+type CommentModifyParams struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// the task or note holding the comments, changes only touch the affected comment so concurrent changes are all kept
+type commentedItem struct {
+	collection             *mongo.Collection
+	filter                 []bson.M
+	comments               *[]database.Comment
+	getMentionNotification func(*database.User) *database.Notification
+}
+
+// mentions are written as @ followed by the teammate's email, e.g. @jane@generaltask.com
+var commentMentionRegex = regexp.MustCompile(`(?:^|\s)@([a-zA-Z0-9._%+\-]+@[a-zA-Z0-9\-]+(?:\.[a-zA-Z0-9\-]+)+)`)
+
 func (api *API) TaskAddComment(c *gin.Context) {
 	taskIDHex := c.Param("task_id")
 	taskID, err := primitive.ObjectIDFromHex(taskIDHex)
@@ -19,7 +44,7 @@ func (api *API) TaskAddComment(c *gin.Context) {
 
 	userID := getUserIDFromContext(c)
 
-	task, err := database.GetTask(api.DB, taskID, userID)
+	task, err := api.getCommentableTask(taskID, userID)
 	if err != nil {
 		c.JSON(404, gin.H{"detail": "task not found.", "taskId": taskID})
 		return
@@ -37,6 +62,11 @@ func (api *API) TaskAddComment(c *gin.Context) {
 		return
 	}
 
+	if task.SourceID == external.TASK_SOURCE_ID_GT_TASK {
+		api.addComment(c, userID, commentParams.Body, api.getTaskCommentedItem(task))
+		return
+	}
+
 	taskSourceResult, err := api.ExternalConfig.GetSourceResult(task.SourceID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to load external task source")
@@ -55,16 +85,285 @@ func (api *API) TaskAddComment(c *gin.Context) {
 		return
 	}
 
-	commentsPointer := task.Comments
-	comments := []database.Comment{}
-	if commentsPointer != nil {
-		comments = *task.Comments
+	_, err = database.GetTaskCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": task.ID},
+			{"user_id": task.UserID},
+		}},
+		bson.M{"$push": bson.M{"comments": commentParams}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update internal DB")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) TaskCommentModify(c *gin.Context) {
+	task, ok := api.getTaskForCommentChange(c)
+	if !ok {
+		return
+	}
+	api.modifyComment(c, getUserIDFromContext(c), c.Param("comment_id"), api.getTaskCommentedItem(task))
+}
+
+func (api *API) TaskCommentDelete(c *gin.Context) {
+	task, ok := api.getTaskForCommentChange(c)
+	if !ok {
+		return
 	}
-	comments = append(comments, commentParams)
+	api.deleteComment(c, getUserIDFromContext(c), c.Param("comment_id"), api.getTaskCommentedItem(task))
+}
 
-	updateTask := database.Task{
-		Comments: &comments,
+// owners and workspace members can comment on any of their tasks, other users only on General Task tasks shared
+// with them as commenters
+func (api *API) getCommentableTask(taskID primitive.ObjectID, userID primitive.ObjectID) (*database.Task, error) {
+	task, err := api.getModifiableTask(taskID, userID)
+	if err == nil {
+		return task, nil
+	}
+	task, err = database.GetSharedTask(api.DB, taskID, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if task.SourceID != external.TASK_SOURCE_ID_GT_TASK || !api.isCommenter(userID, task.SharedWith) {
+		return nil, errors.New("user cannot comment on this task")
+	}
+	return task, nil
+}
+
+func (api *API) getTaskForCommentChange(c *gin.Context) (*database.Task, bool) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	task, err := api.getCommentableTask(taskID, getUserIDFromContext(c))
+	if err != nil || task.SourceID != external.TASK_SOURCE_ID_GT_TASK {
+		c.JSON(404, gin.H{"detail": "task not found.", "taskId": taskID})
+		return nil, false
+	}
+	return task, true
+}
+
+func (api *API) getTaskCommentedItem(task *database.Task) *commentedItem {
+	return &commentedItem{
+		collection: database.GetTaskCollection(api.DB),
+		filter: []bson.M{
+			{"_id": task.ID},
+			{"user_id": task.UserID},
+		},
+		comments:               task.Comments,
+		getMentionNotification: api.getTaskMentionNotifier(task),
+	}
+}
+
+func (api *API) getTaskMentionNotifier(task *database.Task) func(*database.User) *database.Notification {
+	return func(mentionedUser *database.User) *database.Notification {
+		if mentionedUser.ID != task.UserID {
+			_, err := api.getModifiableTask(task.ID, mentionedUser.ID)
+			if err != nil {
+				_, err = database.GetSharedTask(api.DB, task.ID, &mentionedUser.ID)
+			}
+			if err != nil {
+				return nil
+			}
+		}
+		title := ""
+		if task.Title != nil {
+			title = *task.Title
+		}
+		return &database.Notification{TaskID: task.ID, Title: title}
+	}
+}
+
+func (api *API) isCommenter(userID primitive.ObjectID, sharedWith []database.SharedWithEntry) bool {
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		return false
+	}
+	return database.GetSharedWithRole(sharedWith, user.Email) == constants.AccessControlCommenter
+}
+
+func (api *API) addComment(c *gin.Context, userID primitive.ObjectID, body string, item *commentedItem) {
+	err := validateCommentBody(body)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	author, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	comment := database.Comment{
+		ExternalID: uuid.New().String(),
+		Body:       body,
+		User: database.ExternalUser{
+			ExternalID:  userID.Hex(),
+			Name:        author.Name,
+			DisplayName: author.Name,
+			Email:       author.Email,
+		},
+		CreatedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+		AuthorID:  userID,
+	}
+	// the item only matches while it has room for another comment
+	res, err := item.collection.UpdateOne(
+		context.Background(),
+		bson.M{"$and": append(item.filter, bson.M{fmt.Sprintf("comments.%d", constants.MAX_COMMENTS_PER_ITEM-1): bson.M{"$exists": false}})},
+		bson.M{"$push": bson.M{"comments": comment}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to add comment")
+		Handle500(c)
+		return
+	}
+	if res.MatchedCount == 0 {
+		c.JSON(400, gin.H{"detail": "too many comments"})
+		return
+	}
+	go api.notifyCommentMentions(author, body, "", item.getMentionNotification)
+	c.JSON(200, gin.H{"id": comment.ExternalID})
+}
+
+// only the author of a comment can edit it
+func (api *API) modifyComment(c *gin.Context, userID primitive.ObjectID, commentID string, item *commentedItem) {
+	var params CommentModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	err = validateCommentBody(params.Body)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	res, err := item.collection.UpdateOne(
+		context.Background(),
+		bson.M{"$and": append(item.filter, bson.M{"comments": bson.M{"$elemMatch": bson.M{
+			"external_id": commentID,
+			"author_id":   userID,
+		}}})},
+		bson.M{"$set": bson.M{
+			"comments.$.body":       params.Body,
+			"comments.$.updated_at": primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to modify comment")
+		Handle500(c)
+		return
+	}
+	if res.MatchedCount == 0 {
+		c.JSON(404, gin.H{"detail": "comment not found"})
+		return
+	}
+	// mentions which were already in the comment when it was loaded are not notified again
+	previousBody := ""
+	if item.comments != nil {
+		for _, comment := range *item.comments {
+			if comment.ExternalID == commentID {
+				previousBody = comment.Body
+			}
+		}
+	}
+	author, err := database.GetUser(api.DB, userID)
+	if err == nil {
+		go api.notifyCommentMentions(author, params.Body, previousBody, item.getMentionNotification)
 	}
-	api.UpdateTaskInDB(c, task, userID, &updateTask)
 	c.JSON(200, gin.H{})
 }
+
+// only the author of a comment can delete it
+func (api *API) deleteComment(c *gin.Context, userID primitive.ObjectID, commentID string, item *commentedItem) {
+	res, err := item.collection.UpdateOne(
+		context.Background(),
+		bson.M{"$and": item.filter},
+		bson.M{"$pull": bson.M{"comments": bson.M{
+			"external_id": commentID,
+			"author_id":   userID,
+		}}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete comment")
+		Handle500(c)
+		return
+	}
+	if res.ModifiedCount == 0 {
+		c.JSON(404, gin.H{"detail": "comment not found"})
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func validateCommentBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return errors.New("comment body must not be empty")
+	}
+	if len(body) > constants.MAX_COMMENT_LENGTH {
+		return errors.New("comment body is too long")
+	}
+	return nil
+}
+
+// returns the lowercased emails mentioned in the comment, without duplicates
+func getCommentMentions(body string) []string {
+	mentions := []string{}
+	for _, match := range commentMentionRegex.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(match[1])
+		isDuplicate := false
+		for _, mention := range mentions {
+			if mention == email {
+				isDuplicate = true
+			}
+		}
+		if !isDuplicate && len(mentions) < constants.MAX_COMMENT_MENTIONS {
+			mentions = append(mentions, email)
+		}
+	}
+	return mentions
+}
+
+// notifies the General Task users mentioned in the comment who can access the item, skipping mentions which
+// were already in the previous version of the comment. runs in the background, as sending emails and Slack
+// messages is slow
+func (api *API) notifyCommentMentions(author *database.User, body string, previousBody string, getMentionNotification func(*database.User) *database.Notification) {
+	previousMentions := getCommentMentions(previousBody)
+	for _, email := range getCommentMentions(body) {
+		isPreviousMention := false
+		for _, previousMention := range previousMentions {
+			if previousMention == email {
+				isPreviousMention = true
+			}
+		}
+		if isPreviousMention || email == strings.ToLower(author.Email) {
+			continue
+		}
+		mentionedUser, err := database.GetUserByEmail(api.DB, email)
+		if err != nil {
+			continue
+		}
+		notification := getMentionNotification(mentionedUser)
+		if notification == nil {
+			continue
+		}
+		itemTitle := notification.Title
+		if itemTitle == "" {
+			itemTitle = "Untitled"
+		}
+		notification.UserID = mentionedUser.ID
+		notification.Type = constants.NotificationTypeCommentMention
+		notification.Title = fmt.Sprintf("%s mentioned you on \"%s\"", author.Name, itemTitle)
+		notification.Body = body
+		notification.CreatedAt = primitive.NewDateTimeFromTime(api.GetCurrentTime())
+		err = jobs.SendNotification(api.DB, notification)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to send comment mention notification")
+		}
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -245,3 +246,101 @@ func TestTaskAddComment(t *testing.T) {
 		assert.Equal(t, "Hello there!", (*task.Comments)[1].Body)
 	})
 }
+
+func TestGetCommentMentions(t *testing.T) {
+	assert.Equal(t, []string{}, getCommentMentions(""))
+	assert.Equal(t, []string{}, getCommentMentions("email me at jane@generaltask.com"))
+	assert.Equal(t,
+		[]string{"jane@generaltask.com", "john@example.co.uk"},
+		getCommentMentions("@Jane@GeneralTask.com can you check with @john@example.co.uk? cc @jane@generaltask.com"),
+	)
+}
+
+func TestGeneralTaskTaskComments(t *testing.T) {
+	ownerToken := login("test_gt_comments_owner@generaltask.com", "Owner")
+	commenterToken := login("test_gt_comments_commenter@generaltask.com", "Commenter")
+	readerToken := login("test_gt_comments_reader@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	ownerID := getUserIDFromAuthToken(t, api.DB, ownerToken)
+	commenterID := getUserIDFromAuthToken(t, api.DB, commenterToken)
+	readerID := getUserIDFromAuthToken(t, api.DB, readerToken)
+
+	title := "plan offsite"
+	insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+		UserID:   ownerID,
+		Title:    &title,
+		SourceID: external.TASK_SOURCE_ID_GT_TASK,
+		SharedWith: []database.SharedWithEntry{
+			{Email: "test_gt_comments_commenter@generaltask.com", Role: constants.AccessControlCommenter},
+			{Email: "test_gt_comments_reader@generaltask.com", Role: constants.AccessControlReader},
+		},
+	})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	commentsURL := "/tasks/" + taskID.Hex() + "/comments/"
+
+	t.Run("Reader", func(t *testing.T) {
+		ServeRequest(t, readerToken, "POST", commentsURL+"add/", bytes.NewBuffer([]byte(`{"body":"hi"}`)), http.StatusNotFound, api)
+	})
+	t.Run("EmptyBody", func(t *testing.T) {
+		body := ServeRequest(t, commenterToken, "POST", commentsURL+"add/", bytes.NewBuffer([]byte(`{"body":"  "}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"comment body must not be empty"}`, string(body))
+	})
+
+	var commentID string
+	t.Run("AddWithMentions", func(t *testing.T) {
+		body := ServeRequest(t, commenterToken, "POST", commentsURL+"add/", bytes.NewBuffer([]byte(`{"body":"@test_gt_comments_owner@generaltask.com and @test_gt_comments_reader@generaltask.com, thoughts? @someone@elsewhere.com"}`)), http.StatusOK, api)
+		var result map[string]string
+		assert.NoError(t, json.Unmarshal(body, &result))
+		commentID = result["id"]
+
+		task, err := database.GetTask(api.DB, taskID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*task.Comments))
+		assert.Equal(t, commentID, (*task.Comments)[0].ExternalID)
+		assert.Equal(t, commenterID, (*task.Comments)[0].AuthorID)
+		assert.Equal(t, "test_gt_comments_commenter@generaltask.com", (*task.Comments)[0].User.Email)
+
+		for _, userID := range []primitive.ObjectID{ownerID, readerID} {
+			waitForInboxNotifications(t, api, userID, 1)
+			notifications, err := database.GetInboxNotifications(api.DB, userID)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(*notifications))
+			assert.Equal(t, constants.NotificationTypeCommentMention, (*notifications)[0].Type)
+			assert.Equal(t, taskID, (*notifications)[0].TaskID)
+			assert.Equal(t, `Commenter mentioned you on "plan offsite"`, (*notifications)[0].Title)
+		}
+	})
+	t.Run("ModifyNotAuthor", func(t *testing.T) {
+		ServeRequest(t, ownerToken, "PATCH", commentsURL+commentID+"/", bytes.NewBuffer([]byte(`{"body":"edited"}`)), http.StatusNotFound, api)
+		ServeRequest(t, ownerToken, "DELETE", commentsURL+commentID+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("Modify", func(t *testing.T) {
+		ServeRequest(t, commenterToken, "PATCH", commentsURL+commentID+"/", bytes.NewBuffer([]byte(`{"body":"edited, @test_gt_comments_owner@generaltask.com"}`)), http.StatusOK, api)
+		task, err := database.GetTask(api.DB, taskID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, "edited, @test_gt_comments_owner@generaltask.com", (*task.Comments)[0].Body)
+		assert.NotEqual(t, primitive.DateTime(0), (*task.Comments)[0].UpdatedAt)
+
+		// the owner was already mentioned, so is not notified again
+		notifications, err := database.GetInboxNotifications(api.DB, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*notifications))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		ServeRequest(t, commenterToken, "DELETE", commentsURL+commentID+"/", nil, http.StatusOK, api)
+		ServeRequest(t, commenterToken, "DELETE", commentsURL+commentID+"/", nil, http.StatusNotFound, api)
+		task, err := database.GetTask(api.DB, taskID, ownerID)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(*task.Comments))
+	})
+}
+
+// mention notifications are sent in the background
+func waitForInboxNotifications(t *testing.T, api *API, userID primitive.ObjectID, count int) {
+	assert.Eventually(t, func() bool {
+		notifications, err := database.GetInboxNotifications(api.DB, userID)
+		return err == nil && len(*notifications) == count
+	}, time.Second, 10*time.Millisecond)
+}
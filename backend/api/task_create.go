@@ -50,6 +50,7 @@ func (api *API) TaskCreate(c *gin.Context) {
 		}
 	}
 
+	var assignedBy primitive.ObjectID
 	if sourceID != external.TASK_SOURCE_ID_GT_TASK {
 		externalAPICollection := database.GetExternalTokenCollection(api.DB)
 		count, err := externalAPICollection.CountDocuments(
@@ -71,6 +72,7 @@ func (api *API) TaskCreate(c *gin.Context) {
 		var tempTitle string
 		assignedUser, tempTitle, err = getValidExternalOwnerAssignedTask(api.DB, userID, taskCreateParams.Title)
 		if err == nil {
+			assignedBy = userID
 			userID = assignedUser.ID
 			IDTaskSection = constants.IDTaskSectionDefault
 			taskCreateParams.Title = tempTitle
@@ -100,6 +102,11 @@ func (api *API) TaskCreate(c *gin.Context) {
 		IDTaskSection:  IDTaskSection,
 		ParentTaskID:   parentID,
 	}
+	if assignedBy != primitive.NilObjectID {
+		taskCreationObject.AssigneeID = userID
+		taskCreationObject.AssignedBy = assignedBy
+		taskCreationObject.AssignmentStatus = newTaskAssignmentStatus(assignedBy, userID)
+	}
 	taskID, err := taskSourceResult.Source.CreateNewTask(api.DB, userID, taskCreateParams.AccountID, taskCreationObject)
 	if err != nil {
 		c.JSON(503, gin.H{"detail": "failed to create task"})
@@ -115,6 +122,12 @@ func (api *API) TaskCreate(c *gin.Context) {
 		c.JSON(500, gin.H{"detail": "failed to move task to front of folder"})
 		return
 	}
+	if assignedBy != primitive.NilObjectID && assignedBy != userID {
+		assigner, err := database.GetUser(api.DB, assignedBy)
+		if err == nil {
+			api.notifyTaskAssigned(&database.Task{ID: taskID, Title: &taskCreateParams.Title}, userID, assigner)
+		}
+	}
 	c.JSON(200, gin.H{"task_id": taskID})
 }
 
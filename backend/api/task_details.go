@@ -1,7 +1,6 @@
 package api
 
 import (
-	"github.com/GeneralTask/task-manager/backend/database"
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -17,12 +16,16 @@ func (api *API) TaskDetail(c *gin.Context) {
 
 	userID := getUserIDFromContext(c)
 
-	task, err := database.GetTask(api.DB, taskID, userID)
+	task, err := api.getModifiableTask(taskID, userID)
 	if err != nil {
 		Handle404(c)
 		return
 	}
 
-	taskResult := api.taskToTaskResultV4(task)
+	taskResult := api.taskToTaskResultV4(task, userID)
+	err = api.populateTaskBacklinks(userID, []*TaskResultV4{taskResult})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch task backlinks")
+	}
 	c.JSON(200, taskResult)
 }
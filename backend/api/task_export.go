@@ -0,0 +1,115 @@
+package api
+
+import (
+	"sort"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var taskExportFiles = map[string]struct {
+	FileName    string
+	ContentType string
+}{
+	constants.TaskTransferFormatJSON:    {FileName: "tasks.json", ContentType: "application/json; charset=utf-8"},
+	constants.TaskTransferFormatCSV:     {FileName: "tasks.csv", ContentType: "text/csv; charset=utf-8"},
+	constants.TaskTransferFormatTodoTxt: {FileName: "todo.txt", ContentType: "text/plain; charset=utf-8"},
+}
+
+// TaskExport exports all of the user's tasks with their subtasks and comments as a file download
+func (api *API) TaskExport(c *gin.Context) {
+	format := c.DefaultQuery("format", constants.TaskTransferFormatJSON)
+	if !isValidTaskTransferFormat(format) {
+		c.JSON(400, gin.H{"detail": "'format' must be json, csv or todotxt"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	tasks, err := database.GetTasks(api.DB, userID, &[]bson.M{{"is_deleted": bson.M{"$ne": true}}}, nil)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	sections, err := database.GetTaskSections(api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	body, err := encodeTaskTransferItems(format, getTaskTransferItems(*tasks, *sections))
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to encode task export")
+		Handle500(c)
+		return
+	}
+	exportFile := taskExportFiles[format]
+	c.Header("Content-Disposition", "attachment; filename="+exportFile.FileName)
+	c.Data(200, exportFile.ContentType, body)
+}
+
+func getTaskTransferItems(tasks []database.Task, sections []database.TaskSection) []*TaskTransferItem {
+	sectionNames := map[primitive.ObjectID]string{constants.IDTaskSectionDefault: constants.TaskSectionNameDefault}
+	for _, section := range sections {
+		sectionNames[section.ID] = section.Name
+	}
+	// sort so that exports are stable between runs
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].ID.Hex() < tasks[j].ID.Hex()
+	})
+
+	itemsByID := make(map[primitive.ObjectID]*TaskTransferItem)
+	for _, task := range tasks {
+		itemsByID[task.ID] = getTaskTransferItem(task, sectionNames)
+	}
+	roots := []*TaskTransferItem{}
+	for _, task := range tasks {
+		item := itemsByID[task.ID]
+		// subtasks of deleted tasks are exported as top level tasks
+		parent, exists := itemsByID[task.ParentTaskID]
+		if task.ParentTaskID == primitive.NilObjectID || !exists || task.ParentTaskID == task.ID {
+			roots = append(roots, item)
+			continue
+		}
+		// subtasks live in their parent's section
+		item.Section = ""
+		parent.Subtasks = append(parent.Subtasks, item)
+	}
+	return roots
+}
+
+func getTaskTransferItem(task database.Task, sectionNames map[primitive.ObjectID]string) *TaskTransferItem {
+	item := &TaskTransferItem{
+		ID:      task.ID.Hex(),
+		Section: sectionNames[task.IDTaskSection],
+	}
+	if item.Section == "" {
+		item.Section = constants.TaskSectionNameDefault
+	}
+	if task.Title != nil {
+		item.Title = *task.Title
+	}
+	if task.Body != nil {
+		item.Body = *task.Body
+	}
+	if task.DueDate != nil && task.DueDate.Time().Unix() > 0 {
+		item.DueDate = task.DueDate.Time().UTC().Format(constants.YEAR_MONTH_DAY_FORMAT)
+	}
+	if task.PriorityNormalized != nil {
+		item.Priority = *task.PriorityNormalized
+	}
+	if task.IsCompleted != nil {
+		item.IsCompleted = *task.IsCompleted
+	}
+	if task.Comments != nil {
+		for _, comment := range *task.Comments {
+			author := comment.User.DisplayName
+			if author == "" {
+				author = comment.User.Name
+			}
+			item.Comments = append(item.Comments, TaskTransferComment{Author: author, Body: comment.Body})
+		}
+	}
+	return item
+}
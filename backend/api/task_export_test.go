@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskExport(t *testing.T) {
+	authToken := login("test_task_export@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	sectionResult, err := database.GetTaskSectionCollection(api.DB).InsertOne(context.Background(), database.TaskSection{UserID: userID, Name: "Work Stuff"})
+	assert.NoError(t, err)
+	sectionID := sectionResult.InsertedID.(primitive.ObjectID)
+	insertTask := func(task database.Task) primitive.ObjectID {
+		task.UserID = userID
+		insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), task)
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	parentTitle := "plan launch"
+	body := "with the whole team"
+	priority := 1.0
+	dueDate := primitive.NewDateTimeFromTime(time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC))
+	parentID := insertTask(database.Task{
+		Title:              &parentTitle,
+		Body:               &body,
+		IDTaskSection:      sectionID,
+		DueDate:            &dueDate,
+		PriorityNormalized: &priority,
+		Comments:           &[]database.Comment{{Body: "sounds good", User: database.ExternalUser{DisplayName: "John"}}},
+	})
+	subtaskTitle := "write announcement"
+	completed := true
+	subtaskID := insertTask(database.Task{Title: &subtaskTitle, IsCompleted: &completed, ParentTaskID: parentID, IDTaskSection: sectionID})
+	deletedTitle := "deleted task"
+	deleted := true
+	insertTask(database.Task{Title: &deletedTitle, IsDeleted: &deleted})
+
+	UnauthorizedTest(t, "GET", "/tasks/export/", nil)
+	t.Run("InvalidFormat", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/tasks/export/?format=xml", nil, http.StatusBadRequest, api)
+	})
+	t.Run("SuccessJSON", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "GET", "/tasks/export/", nil, http.StatusOK, api)
+		var items []*TaskTransferItem
+		assert.NoError(t, json.Unmarshal(response, &items))
+		// login creates onboarding tasks in the task inbox
+		var exportedParent *TaskTransferItem
+		for _, item := range items {
+			assert.NotEqual(t, deletedTitle, item.Title)
+			if item.ID == parentID.Hex() {
+				exportedParent = item
+			}
+		}
+		assert.Equal(t, &TaskTransferItem{
+			ID:       parentID.Hex(),
+			Title:    parentTitle,
+			Body:     body,
+			Section:  "Work Stuff",
+			DueDate:  "2023-03-10",
+			Priority: 1,
+			Comments: []TaskTransferComment{{Author: "John", Body: "sounds good"}},
+			Subtasks: []*TaskTransferItem{{ID: subtaskID.Hex(), Title: subtaskTitle, IsCompleted: true}},
+		}, exportedParent)
+	})
+	t.Run("SuccessTodoTxt", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "GET", "/tasks/export/?format="+constants.TaskTransferFormatTodoTxt, nil, http.StatusOK, api)
+		assert.Contains(t, string(response), "(A) plan launch +Work_Stuff due:2023-03-10 id:"+parentID.Hex()+"\n")
+		assert.Contains(t, string(response), "x write announcement parent:"+parentID.Hex()+"\n")
+	})
+}
@@ -0,0 +1,301 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TaskImportParams struct {
+	Format string `json:"format" binding:"required"`
+	Data   string `json:"data" binding:"required"`
+	DryRun bool   `json:"dry_run"`
+	// maps section names in the imported data to the IDs of existing task sections
+	SectionMapping map[string]string `json:"section_mapping"`
+}
+
+type TaskImportResult struct {
+	DryRun          bool                   `json:"dry_run"`
+	Tasks           []TaskImportResultItem `json:"tasks"`
+	SectionsCreated []string               `json:"sections_created"`
+}
+
+type TaskImportResultItem struct {
+	Title       string `json:"title"`
+	SectionName string `json:"section_name"`
+	ParentTitle string `json:"parent_title,omitempty"`
+	IsDuplicate bool   `json:"is_duplicate"`
+	// not set for tasks which would be created by a dry run
+	TaskID *primitive.ObjectID `json:"task_id,omitempty"`
+}
+
+type taskImporter struct {
+	api             *API
+	userID          primitive.ObjectID
+	dryRun          bool
+	sectionMapping  map[string]primitive.ObjectID
+	sectionIDs      map[string]primitive.ObjectID
+	sectionNames    map[primitive.ObjectID]string
+	existingTaskIDs map[string]primitive.ObjectID
+	result          *TaskImportResult
+	// inserted together once every item has been imported
+	newSections []database.TaskSection
+	newTasks    []database.Task
+}
+
+// TaskImport creates General Task tasks from an export, skipping tasks which already exist
+func (api *API) TaskImport(c *gin.Context) {
+	var params TaskImportParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	if !isValidTaskTransferFormat(params.Format) {
+		c.JSON(400, gin.H{"detail": "'format' must be json, csv or todotxt"})
+		return
+	}
+	items, err := decodeTaskTransferItems(params.Format, params.Data)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	if countTaskTransferItems(items) > constants.MAX_IMPORT_TASKS {
+		c.JSON(400, gin.H{"detail": "too many tasks to import"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	sectionMapping := make(map[string]primitive.ObjectID)
+	for name, sectionIDHex := range params.SectionMapping {
+		sectionID, err := getValidTaskSection(sectionIDHex, userID, api.DB)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": "'section_mapping' contains an invalid section ID"})
+			return
+		}
+		sectionMapping[strings.ToLower(strings.TrimSpace(name))] = sectionID
+	}
+	importer, err := api.newTaskImporter(userID, params.DryRun, sectionMapping)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	err = importer.importItems(items, primitive.NilObjectID, "", constants.IDTaskSectionDefault)
+	if err == nil {
+		err = importer.insertNewItems()
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to import tasks")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, importer.result)
+}
+
+func (api *API) newTaskImporter(userID primitive.ObjectID, dryRun bool, sectionMapping map[string]primitive.ObjectID) (*taskImporter, error) {
+	importer := &taskImporter{
+		api:             api,
+		userID:          userID,
+		dryRun:          dryRun,
+		sectionMapping:  sectionMapping,
+		sectionIDs:      map[string]primitive.ObjectID{strings.ToLower(constants.TaskSectionNameDefault): constants.IDTaskSectionDefault},
+		sectionNames:    map[primitive.ObjectID]string{constants.IDTaskSectionDefault: constants.TaskSectionNameDefault},
+		existingTaskIDs: make(map[string]primitive.ObjectID),
+		result:          &TaskImportResult{DryRun: dryRun, Tasks: []TaskImportResultItem{}, SectionsCreated: []string{}},
+	}
+	sections, err := database.GetTaskSections(api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, section := range *sections {
+		importer.sectionIDs[strings.ToLower(section.Name)] = section.ID
+		importer.sectionNames[section.ID] = section.Name
+	}
+	tasks, err := database.GetTasks(api.DB, userID, &[]bson.M{{"is_deleted": bson.M{"$ne": true}}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range *tasks {
+		if task.Title != nil {
+			importer.existingTaskIDs[getTaskImportKey(task.ParentTaskID, *task.Title)] = task.ID
+		}
+	}
+	return importer, nil
+}
+
+// tasks are duplicates if they have the same title and parent task
+func getTaskImportKey(parentID primitive.ObjectID, title string) string {
+	return parentID.Hex() + "_" + strings.ToLower(strings.TrimSpace(title))
+}
+
+// subtasks are always created in their parent's section
+func (importer *taskImporter) importItems(items []*TaskTransferItem, parentID primitive.ObjectID, parentTitle string, parentSectionID primitive.ObjectID) error {
+	for _, item := range items {
+		sectionID := parentSectionID
+		var err error
+		if parentID == primitive.NilObjectID {
+			sectionID, err = importer.getSectionID(item.Section)
+			if err != nil {
+				return err
+			}
+		}
+		resultItem := TaskImportResultItem{
+			Title:       strings.TrimSpace(item.Title),
+			SectionName: importer.sectionNames[sectionID],
+			ParentTitle: parentTitle,
+		}
+
+		key := getTaskImportKey(parentID, item.Title)
+		taskID, exists := importer.existingTaskIDs[key]
+		if exists {
+			resultItem.IsDuplicate = true
+		} else {
+			// the ID is assigned here so that subtasks and later duplicates in the file can refer to this task
+			taskID = primitive.NewObjectID()
+			err = importer.addNewTask(taskID, item, parentID, sectionID)
+			if err != nil {
+				return err
+			}
+		}
+		importer.existingTaskIDs[key] = taskID
+		if exists || !importer.dryRun {
+			resultItem.TaskID = &taskID
+		}
+		importer.result.Tasks = append(importer.result.Tasks, resultItem)
+
+		err = importer.importItems(item.Subtasks, taskID, resultItem.Title, sectionID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uses the section mapping, then an existing section with the same name, and otherwise creates a new section
+func (importer *taskImporter) getSectionID(name string) (primitive.ObjectID, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		return constants.IDTaskSectionDefault, nil
+	}
+	if sectionID, exists := importer.sectionMapping[key]; exists {
+		return sectionID, nil
+	}
+	if sectionID, exists := importer.sectionIDs[key]; exists {
+		return sectionID, nil
+	}
+	sectionID := primitive.NewObjectID()
+	importer.newSections = append(importer.newSections, database.TaskSection{
+		ID:     sectionID,
+		UserID: importer.userID,
+		Name:   strings.TrimSpace(name),
+	})
+	importer.sectionIDs[key] = sectionID
+	importer.sectionNames[sectionID] = strings.TrimSpace(name)
+	importer.result.SectionsCreated = append(importer.result.SectionsCreated, strings.TrimSpace(name))
+	return sectionID, nil
+}
+
+func (importer *taskImporter) addNewTask(taskID primitive.ObjectID, item *TaskTransferItem, parentID primitive.ObjectID, sectionID primitive.ObjectID) error {
+	taskCreationObject := external.TaskCreationObject{
+		Title:         strings.TrimSpace(item.Title),
+		Body:          item.Body,
+		IDTaskSection: sectionID,
+		ParentTaskID:  parentID,
+	}
+	if item.DueDate != "" {
+		// due dates are validated when the import is decoded
+		dueDate, err := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, item.DueDate)
+		if err != nil {
+			return err
+		}
+		taskCreationObject.DueDate = &dueDate
+	}
+	newTask := external.GetNewGeneralTask(importer.userID, external.GeneralTaskDefaultAccountID, taskCreationObject)
+	newTask.ID = taskID
+	if item.Priority != 0 {
+		priority := item.Priority
+		newTask.PriorityNormalized = &priority
+	}
+	if item.IsCompleted {
+		completed := true
+		newTask.IsCompleted = &completed
+		newTask.CompletedAt = primitive.NewDateTimeFromTime(importer.api.GetCurrentTime())
+	}
+	if len(item.Comments) > 0 {
+		comments := []database.Comment{}
+		for _, comment := range item.Comments {
+			comments = append(comments, database.Comment{
+				ExternalID: uuid.New().String(),
+				Body:       comment.Body,
+				User:       database.ExternalUser{DisplayName: comment.Author},
+				CreatedAt:  primitive.NewDateTimeFromTime(importer.api.GetCurrentTime()),
+			})
+		}
+		newTask.Comments = &comments
+	}
+	importer.newTasks = append(importer.newTasks, newTask)
+	return nil
+}
+
+// inserts the new sections and tasks at once, and removes them again if an insert fails so an import is never partially applied
+func (importer *taskImporter) insertNewItems() error {
+	if importer.dryRun {
+		return nil
+	}
+	sections := []interface{}{}
+	for _, section := range importer.newSections {
+		sections = append(sections, section)
+	}
+	if len(sections) > 0 {
+		_, err := database.GetTaskSectionCollection(importer.api.DB).InsertMany(context.Background(), sections)
+		if err != nil {
+			importer.removeNewItems()
+			return err
+		}
+	}
+	tasks := []interface{}{}
+	for _, task := range importer.newTasks {
+		tasks = append(tasks, task)
+	}
+	if len(tasks) > 0 {
+		_, err := database.GetTaskCollection(importer.api.DB).InsertMany(context.Background(), tasks)
+		if err != nil {
+			importer.removeNewItems()
+			return err
+		}
+	}
+	return nil
+}
+
+func (importer *taskImporter) removeNewItems() {
+	sectionIDs := []primitive.ObjectID{}
+	for _, section := range importer.newSections {
+		sectionIDs = append(sectionIDs, section.ID)
+	}
+	_, err := database.GetTaskSectionCollection(importer.api.DB).DeleteMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{{"_id": bson.M{"$in": sectionIDs}}, {"user_id": importer.userID}}},
+	)
+	if err != nil {
+		importer.api.Logger.Error().Err(err).Msg("failed to remove sections of failed import")
+	}
+	taskIDs := []primitive.ObjectID{}
+	for _, task := range importer.newTasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	_, err = database.GetTaskCollection(importer.api.DB).DeleteMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{{"_id": bson.M{"$in": taskIDs}}, {"user_id": importer.userID}}},
+	)
+	if err != nil {
+		importer.api.Logger.Error().Err(err).Msg("failed to remove tasks of failed import")
+	}
+}
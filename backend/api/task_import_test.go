@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskImport(t *testing.T) {
+	authToken := login("test_task_import@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	importData := "id,parent_id,title,section,priority,is_completed,comments\n" +
+		"1,,plan launch,Work Stuff,1,false,John: sounds good\n" +
+		"2,1,write announcement,,2,true,\n" +
+		"3,,Plan Launch,work stuff,,false,\n" +
+		"4,,buy milk,,,false,\n"
+	getRequestBody := func(params TaskImportParams) *bytes.Buffer {
+		body, err := json.Marshal(params)
+		assert.NoError(t, err)
+		return bytes.NewBuffer(body)
+	}
+
+	UnauthorizedTest(t, "POST", "/tasks/import/", nil)
+	t.Run("InvalidParams", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/tasks/import/", bytes.NewBuffer([]byte(`{"format": "csv"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "POST", "/tasks/import/", getRequestBody(TaskImportParams{Format: "xml", Data: importData}), http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "POST", "/tasks/import/", getRequestBody(TaskImportParams{Format: constants.TaskTransferFormatCSV, Data: "id,parent_id,title\n1,2,orphan\n"}), http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "POST", "/tasks/import/", getRequestBody(TaskImportParams{Format: constants.TaskTransferFormatJSON, Data: "[null]"}), http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "POST", "/tasks/import/", getRequestBody(TaskImportParams{
+			Format:         constants.TaskTransferFormatCSV,
+			Data:           importData,
+			SectionMapping: map[string]string{"Work Stuff": primitive.NewObjectID().Hex()},
+		}), http.StatusBadRequest, api)
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "POST", "/tasks/import/", getRequestBody(TaskImportParams{Format: constants.TaskTransferFormatCSV, Data: importData, DryRun: true}), http.StatusOK, api)
+		var result TaskImportResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.Equal(t, TaskImportResult{
+			DryRun: true,
+			Tasks: []TaskImportResultItem{
+				{Title: "plan launch", SectionName: "Work Stuff"},
+				{Title: "write announcement", SectionName: "Work Stuff", ParentTitle: "plan launch"},
+				{Title: "Plan Launch", SectionName: "Work Stuff", IsDuplicate: true, TaskID: result.Tasks[2].TaskID},
+				{Title: "buy milk", SectionName: constants.TaskSectionNameDefault},
+			},
+			SectionsCreated: []string{"Work Stuff"},
+		}, result)
+
+		tasks, err := database.GetTasks(api.DB, userID, &[]bson.M{{"title": "plan launch"}}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(*tasks))
+		sections, err := database.GetTaskSections(api.DB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, len(*sections))
+	})
+	t.Run("Success", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "POST", "/tasks/import/", getRequestBody(TaskImportParams{Format: constants.TaskTransferFormatCSV, Data: importData}), http.StatusOK, api)
+		var result TaskImportResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.False(t, result.DryRun)
+		assert.Equal(t, []string{"Work Stuff"}, result.SectionsCreated)
+		assert.Equal(t, 4, len(result.Tasks))
+		assert.Equal(t, result.Tasks[0].TaskID, result.Tasks[2].TaskID)
+
+		sections, err := database.GetTaskSections(api.DB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*sections))
+		parent, err := database.GetTask(api.DB, *result.Tasks[0].TaskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "plan launch", *parent.Title)
+		assert.Equal(t, (*sections)[0].ID, parent.IDTaskSection)
+		assert.Equal(t, 1.0, *parent.PriorityNormalized)
+		assert.Equal(t, 1, len(*parent.Comments))
+		assert.Equal(t, "sounds good", (*parent.Comments)[0].Body)
+		assert.Equal(t, "John", (*parent.Comments)[0].User.DisplayName)
+
+		subtask, err := database.GetTask(api.DB, *result.Tasks[1].TaskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, parent.ID, subtask.ParentTaskID)
+		assert.Equal(t, (*sections)[0].ID, subtask.IDTaskSection)
+		assert.True(t, *subtask.IsCompleted)
+	})
+	t.Run("SkipsExistingTasks", func(t *testing.T) {
+		response := ServeRequest(t, authToken, "POST", "/tasks/import/", getRequestBody(TaskImportParams{Format: constants.TaskTransferFormatCSV, Data: importData}), http.StatusOK, api)
+		var result TaskImportResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.Equal(t, []string{}, result.SectionsCreated)
+		for _, item := range result.Tasks {
+			assert.True(t, item.IsDuplicate)
+		}
+	})
+	t.Run("SectionMapping", func(t *testing.T) {
+		sections, err := database.GetTaskSections(api.DB, userID)
+		assert.NoError(t, err)
+		response := ServeRequest(t, authToken, "POST", "/tasks/import/", getRequestBody(TaskImportParams{
+			Format:         constants.TaskTransferFormatTodoTxt,
+			Data:           "walk dog +Home\n",
+			SectionMapping: map[string]string{"home": (*sections)[0].ID.Hex()},
+		}), http.StatusOK, api)
+		var result TaskImportResult
+		assert.NoError(t, json.Unmarshal(response, &result))
+		assert.Equal(t, []string{}, result.SectionsCreated)
+		task, err := database.GetTask(api.DB, *result.Tasks[0].TaskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, (*sections)[0].ID, task.IDTaskSection)
+	})
+}
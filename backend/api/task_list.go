@@ -54,6 +54,7 @@ type TaskResult struct {
 	DueDate                  string                       `json:"due_date"`
 	PriorityNormalized       float64                      `json:"priority_normalized"`
 	TimeAllocation           int64                        `json:"time_allocated"`
+	TimeTracked              int64                        `json:"time_tracked,omitempty"`
 	SentAt                   string                       `json:"sent_at"`
 	IsDone                   bool                         `json:"is_done"`
 	IsDeleted                bool                         `json:"is_deleted"`
@@ -71,6 +72,9 @@ type TaskResult struct {
 	CreatedAt                string                       `json:"created_at,omitempty"`
 	UpdatedAt                string                       `json:"updated_at,omitempty"`
 	CompletedAt              primitive.DateTime           `json:"completed_at,omitempty"`
+	SnoozedUntil             string                       `json:"snoozed_until,omitempty"`
+	// set for tasks which were assigned by another user
+	Assignment *TaskAssignmentResult `json:"assignment,omitempty"`
 }
 
 type TaskSection struct {
@@ -312,6 +316,10 @@ func (api *API) taskBaseToTaskResult(t *database.Task, userID primitive.ObjectID
 	if t.TimeAllocation != nil {
 		timeAllocation = *t.TimeAllocation
 	}
+	timeTracked := int64(0)
+	if t.TimeTracked != nil {
+		timeTracked = *t.TimeTracked
+	}
 	completed := false
 	if t.IsCompleted != nil {
 		completed = *t.IsCompleted
@@ -340,6 +348,7 @@ func (api *API) taskBaseToTaskResult(t *database.Task, userID primitive.ObjectID
 		Title:                    title,
 		Body:                     body,
 		TimeAllocation:           timeAllocation,
+		TimeTracked:              timeTracked,
 		Sender:                   t.Sender,
 		SentAt:                   t.CreatedAtExternal.Time().UTC().Format(time.RFC3339),
 		DueDate:                  dueDate,
@@ -419,6 +428,14 @@ func (api *API) taskBaseToTaskResult(t *database.Task, userID primitive.ObjectID
 		taskResult.RecurringTaskTemplateID = t.RecurringTaskTemplateID
 	}
 
+	if t.SnoozedUntil != 0 {
+		taskResult.SnoozedUntil = t.SnoozedUntil.Time().UTC().Format(time.RFC3339)
+	}
+
+	if t.AssigneeID != primitive.NilObjectID {
+		taskResult.Assignment = api.getTaskAssignmentResult(t)
+	}
+
 	if t.CompletedAt != primitive.DateTime(0) {
 		taskResult.CompletedAt = t.CompletedAt
 	}
@@ -25,7 +25,7 @@ func (api *API) TasksListV3(c *gin.Context) {
 		return
 	}
 
-	activeTasks, err := database.GetActiveTasks(api.DB, userID)
+	activeTasks, err := database.GetActiveUnsnoozedTasks(api.DB, userID, api.GetCurrentTime())
 	if err != nil {
 		Handle500(c)
 		return
@@ -28,6 +28,8 @@ type TaskResultV4 struct {
 	Body                     string                       `json:"body"`
 	DueDate                  string                       `json:"due_date"`
 	PriorityNormalized       float64                      `json:"priority_normalized"`
+	TimeAllocation           int64                        `json:"time_allocated,omitempty"`
+	TimeTracked              int64                        `json:"time_tracked,omitempty"`
 	IsDone                   bool                         `json:"is_done"`
 	IsDeleted                bool                         `json:"is_deleted"`
 	RecurringTaskTemplateID  primitive.ObjectID           `json:"recurring_task_template_id,omitempty"`
@@ -36,6 +38,8 @@ type TaskResultV4 struct {
 	ExternalPriority         *externalPriority            `json:"priority,omitempty"`
 	AllExternalPriorities    []*externalPriority          `json:"all_priorities,omitempty"`
 	Comments                 *[]database.Comment          `json:"comments,omitempty"`
+	Labels                   []string                     `json:"labels,omitempty"`
+	Reminders                *[]database.ReminderRule     `json:"reminders,omitempty"`
 	SlackMessageParams       *database.SlackMessageParams `json:"slack_message_params,omitempty"`
 	MeetingPreparationParams *MeetingPreparationParams    `json:"meeting_preparation_params,omitempty"`
 	SubTaskIDs               []primitive.ObjectID         `json:"subtask_ids,omitempty"`
@@ -47,6 +51,16 @@ type TaskResultV4 struct {
 	DeletedAt                string                       `json:"deleted_at,omitempty"`
 	SharedAccess             string                       `json:"shared_access,omitempty"`
 	SharedUntil              string                       `json:"shared_until,omitempty"`
+	SnoozedUntil             string                       `json:"snoozed_until,omitempty"`
+	// only returned to the owner of the task
+	SharedWith []database.SharedWithEntry `json:"shared_with,omitempty"`
+	// the user's notes which link to the task
+	Backlinks []NoteLinkResult `json:"backlinks,omitempty"`
+	// set for tasks in the shared sections of a workspace
+	WorkspaceID string `json:"workspace_id,omitempty"`
+	AssigneeID  string `json:"assignee_id,omitempty"`
+	// set for tasks which were assigned by another user
+	Assignment *TaskAssignmentResult `json:"assignment,omitempty"`
 }
 
 func (api *API) TasksListV4(c *gin.Context) {
@@ -60,7 +74,7 @@ func (api *API) TasksListV4(c *gin.Context) {
 		return
 	}
 
-	activeTasks, err := database.GetActiveTasks(api.DB, userID)
+	activeTasks, err := database.GetActiveUnsnoozedTasks(api.DB, userID, api.GetCurrentTime())
 	if err != nil {
 		Handle500(c)
 		return
@@ -109,12 +123,12 @@ func (api *API) mergeTasksV4(
 	allTasks = append(allTasks, *activeTasks...)
 	allTasks = append(allTasks, *completedTasks...)
 	allTasks = append(allTasks, *deletedTasks...)
-	return api.taskListToTaskResultListV4(&allTasks), nil
+	return api.taskListToTaskResultListV4(&allTasks, userID), nil
 }
 
 // shares a lot of duplicate code with taskListToTaskResultList
 // TODO: remove taskListToTaskResultList when frontend switches to new endpoint
-func (api *API) taskListToTaskResultListV4(tasks *[]database.Task) []*TaskResultV4 {
+func (api *API) taskListToTaskResultListV4(tasks *[]database.Task, userID primitive.ObjectID) []*TaskResultV4 {
 	parentToChildIDs := make(map[primitive.ObjectID][]primitive.ObjectID)
 	taskResults := []*TaskResultV4{}
 	taskIDMap := make(map[primitive.ObjectID]bool)
@@ -129,7 +143,7 @@ func (api *API) taskListToTaskResultListV4(tasks *[]database.Task) []*TaskResult
 		}
 		// for implicit memory aliasing
 		tempTask := task
-		taskResults = append(taskResults, api.taskToTaskResultV4(&tempTask))
+		taskResults = append(taskResults, api.taskToTaskResultV4(&tempTask, userID))
 		taskIDMap[task.ID] = true
 	}
 
@@ -151,12 +165,16 @@ func (api *API) taskListToTaskResultListV4(tasks *[]database.Task) []*TaskResult
 		}
 		taskResultsWithoutOrphans = append(taskResultsWithoutOrphans, node)
 	}
+	err := api.populateTaskBacklinks(userID, taskResultsWithoutOrphans)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch task backlinks")
+	}
 	return taskResultsWithoutOrphans
 }
 
 // shares a lot of duplicate code with taskBaseToTaskResult
 // TODO: remove taskBaseToTaskResult when frontend switches to new endpoint
-func (api *API) taskToTaskResultV4(t *database.Task) *TaskResultV4 {
+func (api *API) taskToTaskResultV4(t *database.Task, userID primitive.ObjectID) *TaskResultV4 {
 	var dueDate string
 	if t.DueDate != nil {
 		if t.DueDate.Time().UTC().Year() <= 1971 {
@@ -204,6 +222,8 @@ func (api *API) taskToTaskResultV4(t *database.Task) *TaskResultV4 {
 			sharedAccess = constants.StringSharedAccessPublic
 		} else if *t.SharedAccess == database.SharedAccessDomain {
 			sharedAccess = constants.StringSharedAccessDomain
+		} else if *t.SharedAccess == database.SharedAccessMeetingAttendees {
+			sharedAccess = constants.StringSharedAccessMeetingAttendees
 		}
 	}
 	taskResult := &TaskResultV4{
@@ -227,12 +247,23 @@ func (api *API) taskToTaskResultV4(t *database.Task) *TaskResultV4 {
 		SharedUntil:        t.SharedUntil.Time().UTC().Format(time.RFC3339),
 		SharedAccess:       sharedAccess,
 	}
+	// only the owner can see who the task is shared with
+	if t.UserID == userID {
+		taskResult.SharedWith = t.SharedWith
+	}
 
 	if t.ParentTaskID != primitive.NilObjectID {
 		taskResult.IDParent = t.ParentTaskID.Hex()
 		// we want to make folder ID blank if the task is a subtask
 		taskResult.IDFolder = ""
 	}
+	if t.WorkspaceID != primitive.NilObjectID {
+		taskResult.WorkspaceID = t.WorkspaceID.Hex()
+	}
+	if t.AssigneeID != primitive.NilObjectID {
+		taskResult.AssigneeID = t.AssigneeID.Hex()
+		taskResult.Assignment = api.getTaskAssignmentResult(t)
+	}
 
 	if t.Status != nil && *t.Status != (database.ExternalTaskStatus{}) {
 		taskResult.ExternalStatus = &externalStatus{
@@ -304,5 +335,24 @@ func (api *API) taskToTaskResultV4(t *database.Task) *TaskResultV4 {
 		taskResult.LinearCycle = &t.LinearCycle
 	}
 
+	if t.Labels != nil {
+		taskResult.Labels = *t.Labels
+	}
+
+	if t.TimeAllocation != nil {
+		taskResult.TimeAllocation = *t.TimeAllocation
+	}
+
+	if t.TimeTracked != nil {
+		taskResult.TimeTracked = *t.TimeTracked
+	}
+
+	// nil means the user's default reminder applies
+	taskResult.Reminders = t.Reminders
+
+	if t.SnoozedUntil != 0 {
+		taskResult.SnoozedUntil = t.SnoozedUntil.Time().UTC().Format(time.RFC3339)
+	}
+
 	return taskResult
 }
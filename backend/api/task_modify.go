@@ -46,6 +46,8 @@ type TaskItemChangeableFields struct {
 type TaskModifyParams struct {
 	IDOrdering    *int    `json:"id_ordering"`
 	IDTaskSection *string `json:"id_task_section"`
+	// an empty string clears the snooze
+	SnoozedUntil *string `json:"snoozed_until"`
 	TaskItemChangeableFields
 }
 
@@ -65,17 +67,28 @@ func (api *API) TaskModify(c *gin.Context) {
 		return
 	}
 
+	var IDTaskSection primitive.ObjectID
 	if modifyParams.IDTaskSection != nil {
-		_, err = primitive.ObjectIDFromHex(*modifyParams.IDTaskSection)
+		IDTaskSection, err = primitive.ObjectIDFromHex(*modifyParams.IDTaskSection)
 		if err != nil {
 			c.JSON(400, gin.H{"detail": "'id_task_section' is not a valid ID"})
 			return
 		}
 	}
 
+	var snoozedUntil primitive.DateTime
+	if modifyParams.SnoozedUntil != nil && *modifyParams.SnoozedUntil != "" {
+		snoozedUntilTime, err := time.Parse(time.RFC3339, *modifyParams.SnoozedUntil)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": "'snoozed_until' is not a valid date"})
+			return
+		}
+		snoozedUntil = primitive.NewDateTimeFromTime(snoozedUntilTime)
+	}
+
 	userID := getUserIDFromContext(c)
 
-	task, err := database.GetTask(api.DB, taskID, userID)
+	task, err := api.getModifiableTask(taskID, userID)
 	if err != nil {
 		c.JSON(404, gin.H{"detail": "task not found.", "taskId": taskID})
 		return
@@ -87,6 +100,21 @@ func (api *API) TaskModify(c *gin.Context) {
 		return
 	}
 
+	if task.UserID != userID {
+		err = validateWorkspaceTaskModify(&modifyParams)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": err.Error()})
+			return
+		}
+	}
+	if modifyParams.IDTaskSection != nil {
+		err = api.validateTaskSectionMove(task, userID, IDTaskSection)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": err.Error()})
+			return
+		}
+	}
+
 	taskSourceResult, err := api.ExternalConfig.GetSourceResult(task.SourceID)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to load external task source")
@@ -101,20 +129,11 @@ func (api *API) TaskModify(c *gin.Context) {
 
 	var dueDate *primitive.DateTime
 	if modifyParams.TaskItemChangeableFields.DueDate != nil {
-		yearMonthDayDate, yearMonthDayErr := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, *modifyParams.TaskItemChangeableFields.DueDate)
-		rfcDate, rfcErr := time.Parse(time.RFC3339, *modifyParams.TaskItemChangeableFields.DueDate)
-
-		if yearMonthDayErr != nil && rfcErr != nil {
+		dueDate, err = parseDueDate(*modifyParams.TaskItemChangeableFields.DueDate)
+		if err != nil {
 			c.JSON(400, gin.H{"detail": "due_date is not a valid date"})
 			return
 		}
-		if yearMonthDayErr == nil {
-			result := primitive.NewDateTimeFromTime(yearMonthDayDate)
-			dueDate = &result
-		} else {
-			result := primitive.NewDateTimeFromTime(rfcDate)
-			dueDate = &result
-		}
 	}
 	if modifyParams.TaskItemChangeableFields != (TaskItemChangeableFields{}) {
 		updateTask := database.Task{
@@ -159,13 +178,16 @@ func (api *API) TaskModify(c *gin.Context) {
 			} else if *modifyParams.TaskItemChangeableFields.SharedAccess == constants.StringSharedAccessDomain {
 				sharedAccessDomain := database.SharedAccessDomain
 				updateTask.SharedAccess = &sharedAccessDomain
+			} else if *modifyParams.TaskItemChangeableFields.SharedAccess == constants.StringSharedAccessMeetingAttendees {
+				sharedAccessMeetingAttendees := database.SharedAccessMeetingAttendees
+				updateTask.SharedAccess = &sharedAccessMeetingAttendees
 			} else {
 				c.JSON(400, gin.H{"detail": "invalid shared access token"})
 				return
 			}
 		}
 
-		err = taskSourceResult.Source.ModifyTask(api.DB, userID, task.SourceAccountID, task.IDExternal, &updateTask, task)
+		err = taskSourceResult.Source.ModifyTask(api.DB, task.UserID, task.SourceAccountID, task.IDExternal, &updateTask, task)
 		if err != nil {
 			api.Logger.Error().Err(err).Msg("failed to update external task source")
 			Handle500(c)
@@ -180,14 +202,37 @@ func (api *API) TaskModify(c *gin.Context) {
 				updateTask.UserID = assignedUser.ID
 				updateTask.IDTaskSection = constants.IDTaskSectionDefault
 				updateTask.Title = &tempTitle
+				updateTask.AssigneeID = assignedUser.ID
+				updateTask.AssignedBy = userID
+				updateTask.AssignmentStatus = newTaskAssignmentStatus(userID, assignedUser.ID)
+				updateTask.AssignedAt = primitive.NewDateTimeFromTime(api.GetCurrentTime())
+			}
+		}
+		err = api.updateTaskInDBForUser(task, task.UserID, userID, &updateTask)
+		if err != nil {
+			Handle500(c)
+			return
+		}
+		if updateTask.AssignmentStatus == constants.TaskAssignmentStatusPending {
+			assigner, err := database.GetUser(api.DB, userID)
+			if err == nil {
+				api.notifyTaskAssigned(&database.Task{ID: task.ID, Title: updateTask.Title}, updateTask.AssigneeID, assigner)
 			}
 		}
-		api.UpdateTaskInDB(c, task, userID, &updateTask)
+	}
+
+	// snoozing is local to General Task, so it is never sent to the task source
+	if modifyParams.SnoozedUntil != nil {
+		err = api.updateTaskSnooze(task, userID, snoozedUntil)
+		if err != nil {
+			Handle500(c)
+			return
+		}
 	}
 
 	// handle reorder task
 	if modifyParams.IDOrdering != nil || (modifyParams.IDTaskSection != nil || task.ParentTaskID != primitive.NilObjectID) {
-		err = api.ReOrderTask(c, taskID, userID, modifyParams.IDOrdering, modifyParams.IDTaskSection, task)
+		err = api.ReOrderTask(c, taskID, task.UserID, modifyParams.IDOrdering, modifyParams.IDTaskSection, task)
 		if err != nil {
 			return
 		}
@@ -196,6 +241,45 @@ func (api *API) TaskModify(c *gin.Context) {
 	c.JSON(200, gin.H{})
 }
 
+// a zero snoozedUntil clears the snooze
+func (api *API) updateTaskSnooze(task *database.Task, userID primitive.ObjectID, snoozedUntil primitive.DateTime) error {
+	update := bson.M{"$set": bson.M{"snoozed_until": snoozedUntil}}
+	if snoozedUntil == 0 {
+		update = bson.M{"$unset": bson.M{"snoozed_until": ""}}
+	}
+	res, err := database.GetTaskCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": task.ID},
+			{"user_id": userID},
+		}},
+		update,
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update task snooze")
+		return err
+	}
+	if res.MatchedCount != 1 {
+		return errors.New("failed to update task snooze")
+	}
+	return nil
+}
+
+// dueDate must be of form 2006-03-02 or 2006-03-02T15:04:05Z
+func parseDueDate(dueDate string) (*primitive.DateTime, error) {
+	yearMonthDayDate, yearMonthDayErr := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, dueDate)
+	if yearMonthDayErr == nil {
+		result := primitive.NewDateTimeFromTime(yearMonthDayDate)
+		return &result, nil
+	}
+	rfcDate, rfcErr := time.Parse(time.RFC3339, dueDate)
+	if rfcErr != nil {
+		return nil, errors.New("due_date is not a valid date")
+	}
+	result := primitive.NewDateTimeFromTime(rfcDate)
+	return &result, nil
+}
+
 func ValidateFields(c *gin.Context, updateFields *TaskItemChangeableFields, taskSourceResult *external.TaskSourceResult, task *database.Task) bool {
 	isTaskDeletedInRequest := updateFields.IsDeleted == nil || *updateFields.IsDeleted
 	isTaskDeletedInDb := task.IsDeleted != nil && *task.IsDeleted
@@ -274,6 +358,16 @@ func (api *API) ReOrderTask(c *gin.Context, taskID primitive.ObjectID, userID pr
 	} else {
 		IDTaskSection = task.IDTaskSection
 	}
+	// tasks in the shared sections of a workspace are ordered together with the other members' tasks
+	orderingFilter := bson.M{"user_id": userID}
+	update := bson.M{"$set": updateFields}
+	workspaceSection, err := database.GetWorkspaceSection(api.DB, IDTaskSection)
+	if err == nil {
+		orderingFilter = bson.M{"workspace_id": workspaceSection.WorkspaceID}
+		updateFields["workspace_id"] = workspaceSection.WorkspaceID
+	} else if IDTaskSectionHex != nil {
+		update["$unset"] = bson.M{"workspace_id": ""}
+	}
 
 	result, err := taskCollection.UpdateOne(
 		context.Background(),
@@ -281,7 +375,7 @@ func (api *API) ReOrderTask(c *gin.Context, taskID primitive.ObjectID, userID pr
 			{"_id": taskID},
 			{"user_id": userID},
 		}},
-		bson.M{"$set": updateFields},
+		update,
 	)
 	if err != nil {
 		api.Logger.Error().Err(err).Msg("failed to update task in db")
@@ -302,16 +396,18 @@ func (api *API) ReOrderTask(c *gin.Context, taskID primitive.ObjectID, userID pr
 		{"_id": bson.M{"$ne": taskID}},
 		{"is_deleted": bson.M{"$ne": true}},
 		{"id_ordering": bson.M{"$gte": *IDOrdering}},
-		{"user_id": userID},
 	}
 	taskQuery := []bson.M{
-		{"user_id": userID},
 		{"is_deleted": bson.M{"$ne": true}},
 	}
 	if task.ParentTaskID != primitive.NilObjectID {
+		dbQuery = append(dbQuery, bson.M{"user_id": userID})
 		dbQuery = append(dbQuery, bson.M{"parent_task_id": task.ParentTaskID})
+		taskQuery = append(taskQuery, bson.M{"user_id": userID})
 		taskQuery = append(taskQuery, bson.M{"parent_task_id": task.ParentTaskID})
 	} else {
+		dbQuery = append(dbQuery, orderingFilter)
+		taskQuery = append(taskQuery, orderingFilter)
 		dbQuery = append(dbQuery, bson.M{"id_task_section": IDTaskSection})
 		dbQuery = append(dbQuery, bson.M{"is_completed": bson.M{"$ne": true}})
 		taskQuery = append(taskQuery, bson.M{"id_task_section": IDTaskSection})
@@ -381,6 +477,11 @@ func (api *API) UpdateTaskInDB(c *gin.Context, task *database.Task, userID primi
 }
 
 func (api *API) UpdateTaskInDBWithError(task *database.Task, userID primitive.ObjectID, updateFields *database.Task) error {
+	return api.updateTaskInDBForUser(task, userID, userID, updateFields)
+}
+
+// workspace members update tasks owned by someone else, so the change is attributed to the member making it
+func (api *API) updateTaskInDBForUser(task *database.Task, ownerID primitive.ObjectID, userID primitive.ObjectID, updateFields *database.Task) error {
 	taskCollection := database.GetTaskCollection(api.DB)
 
 	if updateFields.IsCompleted != nil {
@@ -396,7 +497,7 @@ func (api *API) UpdateTaskInDBWithError(task *database.Task, userID primitive.Ob
 		context.Background(),
 		bson.M{"$and": []bson.M{
 			{"_id": task.ID},
-			{"user_id": userID},
+			{"user_id": ownerID},
 		}},
 		bson.M{"$set": updateFields},
 	)
@@ -408,6 +509,9 @@ func (api *API) UpdateTaskInDBWithError(task *database.Task, userID primitive.Ob
 		log.Print("failed to update task", res)
 		return errors.New("failed to update task")
 	}
+	if updateFields.IsCompleted != nil && *updateFields.IsCompleted {
+		api.notifyAssignedTaskCompleted(task, userID)
+	}
 
 	return nil
 }
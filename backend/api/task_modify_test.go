@@ -1441,6 +1441,18 @@ func TestModifyShareableTask(t *testing.T) {
 		assert.Equal(t, database.SharedAccess(1), *updatedTask.SharedAccess)
 		assert.Equal(t, expectedDateTime, updatedTask.SharedUntil)
 	})
+	t.Run("SuccessMeetingAttendees", func(t *testing.T) {
+		insertResult, err := taskCollection.InsertOne(context.Background(), sampleTask)
+		assert.NoError(t, err)
+		insertedTaskID := insertResult.InsertedID.(primitive.ObjectID)
+
+		body := bytes.NewBuffer([]byte(`{"shared_access": "meeting_attendees"}`))
+		ServeRequest(t, authToken, "PATCH", fmt.Sprintf("/tasks/modify/%s/", insertedTaskID.Hex()), body, http.StatusOK, api)
+
+		task, err := database.GetTask(api.DB, insertedTaskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, database.SharedAccessMeetingAttendees, *task.SharedAccess)
+	})
 	t.Run("SuccessPublic", func(t *testing.T) {
 		expectedTask := sampleTask
 		insertResult, err := taskCollection.InsertOne(
@@ -1522,3 +1534,51 @@ func TestModifyShareableTask(t *testing.T) {
 		assert.Equal(t, expectedBody, string(responseBody))
 	})
 }
+
+func TestSnoozeTask(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	taskCollection := database.GetTaskCollection(api.DB)
+
+	authToken := login("test_snooze_task@generaltask.com", "")
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	expectedDateTime := primitive.NewDateTimeFromTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	// jira tasks would fail to update externally without a token, so this also checks snoozing stays local
+	sampleTask := database.Task{
+		UserID:   userID,
+		SourceID: "jira",
+	}
+	insertTask := func(task database.Task) primitive.ObjectID {
+		insertResult, err := taskCollection.InsertOne(context.Background(), task)
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	getTask := func(taskID primitive.ObjectID) database.Task {
+		var task database.Task
+		err := taskCollection.FindOne(context.Background(), bson.M{"_id": taskID}).Decode(&task)
+		assert.NoError(t, err)
+		return task
+	}
+
+	t.Run("InvalidSnoozedUntil", func(t *testing.T) {
+		taskID := insertTask(sampleTask)
+		body := bytes.NewBuffer([]byte(`{"snoozed_until": "tomorrow"}`))
+		responseBody := ServeRequest(t, authToken, "PATCH", fmt.Sprintf("/tasks/modify/%s/", taskID.Hex()), body, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'snoozed_until' is not a valid date"}`, string(responseBody))
+	})
+	t.Run("SnoozeSuccess", func(t *testing.T) {
+		taskID := insertTask(sampleTask)
+		body := bytes.NewBuffer([]byte(`{"snoozed_until": "2021-01-01T00:00:00Z"}`))
+		ServeRequest(t, authToken, "PATCH", fmt.Sprintf("/tasks/modify/%s/", taskID.Hex()), body, http.StatusOK, api)
+		assert.Equal(t, expectedDateTime, getTask(taskID).SnoozedUntil)
+	})
+	t.Run("UnsnoozeSuccess", func(t *testing.T) {
+		snoozedTask := sampleTask
+		snoozedTask.SnoozedUntil = expectedDateTime
+		taskID := insertTask(snoozedTask)
+		body := bytes.NewBuffer([]byte(`{"snoozed_until": ""}`))
+		ServeRequest(t, authToken, "PATCH", fmt.Sprintf("/tasks/modify/%s/", taskID.Hex()), body, http.StatusOK, api)
+		assert.Equal(t, primitive.DateTime(0), getTask(taskID).SnoozedUntil)
+	})
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TaskRemindersParams struct {
+	Reminders *[]database.ReminderRule `json:"reminders"`
+}
+
+// TaskRemindersModify sets the reminders for a task, an empty list turns off reminders for the task
+func (api *API) TaskRemindersModify(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		// This means the task ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	var params TaskRemindersParams
+	err = c.BindJSON(&params)
+	if err != nil || params.Reminders == nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	err = validateReminderRules(*params.Reminders)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	task, err := database.GetTask(api.DB, taskID, userID)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	err = api.UpdateTaskInDBWithError(task, userID, &database.Task{Reminders: params.Reminders})
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// TaskRemindersDelete removes a task's own reminders so the user's default reminder applies again
+func (api *API) TaskRemindersDelete(c *gin.Context) {
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		// This means the task ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	result, err := database.GetTaskCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": taskID},
+			{"user_id": userID},
+		}},
+		bson.M{"$unset": bson.M{"reminders": ""}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to remove task reminders")
+		Handle500(c)
+		return
+	}
+	if result.MatchedCount != 1 {
+		Handle404(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+func validateReminderRules(rules []database.ReminderRule) error {
+	if len(rules) > constants.MAX_REMINDERS_PER_TASK {
+		return errors.New("too many reminders")
+	}
+	maxLead := time.Duration(constants.MAX_REMINDER_LEAD_SECONDS) * time.Second
+	for _, rule := range rules {
+		if rule.MinutesBeforeDue < 0 || rule.DaysBeforeDue < 0 {
+			return errors.New("reminders cannot be after the due date")
+		}
+		if rule.TimeOfDay == "" {
+			if rule.DaysBeforeDue != 0 {
+				return errors.New("'days_before_due' requires 'time_of_day'")
+			}
+			if time.Duration(rule.MinutesBeforeDue)*time.Minute > maxLead {
+				return errors.New("reminders cannot be more than a week before the due date")
+			}
+			continue
+		}
+		if rule.MinutesBeforeDue != 0 {
+			return errors.New("'minutes_before_due' cannot be used with 'time_of_day'")
+		}
+		_, err := time.Parse(constants.REMINDER_TIME_OF_DAY_FORMAT, rule.TimeOfDay)
+		if err != nil {
+			return errors.New("'time_of_day' must be formatted as HH:MM")
+		}
+		if time.Duration(rule.DaysBeforeDue)*24*time.Hour > maxLead {
+			return errors.New("reminders cannot be more than a week before the due date")
+		}
+	}
+	return nil
+}
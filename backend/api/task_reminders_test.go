@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTaskReminders(t *testing.T) {
+	authToken := login("test_task_reminders@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	taskCollection := database.GetTaskCollection(api.DB)
+
+	title := "reminder task"
+	insertResult, err := taskCollection.InsertOne(context.Background(), database.Task{
+		UserID:        userID,
+		IDTaskSection: constants.IDTaskSectionDefault,
+		Title:         &title,
+	})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	url := "/tasks/" + taskID.Hex() + "/reminders/"
+	getTask := func() database.Task {
+		var task database.Task
+		err := taskCollection.FindOne(context.Background(), bson.M{"_id": taskID}).Decode(&task)
+		assert.NoError(t, err)
+		return task
+	}
+
+	UnauthorizedTest(t, "PATCH", url, nil)
+	t.Run("InvalidTaskID", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/tasks/invalid/reminders/", bytes.NewBuffer([]byte(`{"reminders": []}`)), http.StatusNotFound, api)
+		ServeRequest(t, authToken, "PATCH", "/tasks/"+primitive.NewObjectID().Hex()+"/reminders/", bytes.NewBuffer([]byte(`{"reminders": []}`)), http.StatusNotFound, api)
+	})
+	t.Run("MissingReminders", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+	})
+	t.Run("InvalidReminders", func(t *testing.T) {
+		for _, body := range []string{
+			`{"reminders": [{"minutes_before_due": -5}]}`,
+			`{"reminders": [{"minutes_before_due": 20000}]}`,
+			`{"reminders": [{"days_before_due": 1}]}`,
+			`{"reminders": [{"minutes_before_due": 5, "time_of_day": "09:00"}]}`,
+			`{"reminders": [{"time_of_day": "9am"}]}`,
+			`{"reminders": [{"time_of_day": "09:00", "days_before_due": 8}]}`,
+			`{"reminders": [{"minutes_before_due": 1}, {"minutes_before_due": 2}, {"minutes_before_due": 3}, {"minutes_before_due": 4}, {"minutes_before_due": 5}, {"minutes_before_due": 6}]}`,
+		} {
+			ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(body)), http.StatusBadRequest, api)
+		}
+		assert.Nil(t, getTask().Reminders)
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"reminders": [{"minutes_before_due": 30}, {"time_of_day": "09:00", "days_before_due": 1}]}`)), http.StatusOK, api)
+		assert.Equal(t, []database.ReminderRule{
+			{MinutesBeforeDue: 30},
+			{TimeOfDay: "09:00", DaysBeforeDue: 1},
+		}, *getTask().Reminders)
+	})
+	t.Run("SuccessNoReminders", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", url, bytes.NewBuffer([]byte(`{"reminders": []}`)), http.StatusOK, api)
+		assert.Equal(t, []database.ReminderRule{}, *getTask().Reminders)
+	})
+	t.Run("DeleteSuccess", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", url, nil, http.StatusOK, api)
+		assert.Nil(t, getTask().Reminders)
+		ServeRequest(t, authToken, "DELETE", "/tasks/"+primitive.NewObjectID().Hex()+"/reminders/", nil, http.StatusNotFound, api)
+	})
+}
@@ -0,0 +1,522 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// overrides the user's working hours with the same hours on each of the weekdays
+type WorkingHoursParams struct {
+	StartTime string `json:"start_time"` // i.e. "09:00"
+	EndTime   string `json:"end_time"`
+	Weekdays  []int  `json:"weekdays"` // i.e. 0 = Sunday, 1 = Monday, etc.
+}
+
+type TaskScheduleParams struct {
+	TaskIDs       []string            `json:"task_ids"`
+	ViewID        *string             `json:"view_id"`
+	WorkingHours  *WorkingHoursParams `json:"working_hours"`
+	DatetimeStart *time.Time          `json:"datetime_start"`
+	DatetimeEnd   *time.Time          `json:"datetime_end"`
+}
+
+type TaskScheduleConfirmParams struct {
+	SourceID   string                   `json:"source_id"`
+	AccountID  string                   `json:"account_id" binding:"required"`
+	CalendarID string                   `json:"calendar_id"`
+	Blocks     []TaskScheduleBlockParam `json:"blocks" binding:"required"`
+}
+
+type TaskScheduleBlockParam struct {
+	TaskID        string     `json:"task_id" binding:"required"`
+	DatetimeStart *time.Time `json:"datetime_start" binding:"required"`
+	DatetimeEnd   *time.Time `json:"datetime_end" binding:"required"`
+}
+
+type TaskScheduleReflowParams struct {
+	WorkingHours *WorkingHoursParams `json:"working_hours"`
+	DatetimeEnd  *time.Time          `json:"datetime_end"`
+	DryRun       bool                `json:"dry_run"`
+}
+
+type TaskScheduleResult struct {
+	Blocks             []TaskScheduleBlock  `json:"blocks"`
+	UnscheduledTaskIDs []primitive.ObjectID `json:"unscheduled_task_ids"`
+}
+
+type TaskScheduleBlock struct {
+	TaskID        primitive.ObjectID `json:"task_id"`
+	EventID       primitive.ObjectID `json:"event_id,omitempty"`
+	Title         string             `json:"title"`
+	DatetimeStart time.Time          `json:"datetime_start"`
+	DatetimeEnd   time.Time          `json:"datetime_end"`
+}
+
+type taskScheduleItem struct {
+	Task     database.Task
+	Duration time.Duration
+	// only set when an existing block is being moved
+	EventID primitive.ObjectID
+}
+
+// TaskSchedule proposes non-overlapping calendar blocks for tasks during the user's working hours
+func (api *API) TaskSchedule(c *gin.Context) {
+	var params TaskScheduleParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	if len(params.TaskIDs) == 0 && params.ViewID == nil {
+		c.JSON(400, gin.H{"detail": "'task_ids' or 'view_id' is required"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	location, err := api.getUserLocation(c, user)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+
+	start := api.GetCurrentTime()
+	if params.DatetimeStart != nil && params.DatetimeStart.After(start) {
+		start = *params.DatetimeStart
+	}
+	end, err := getTaskScheduleEnd(start, params.DatetimeEnd)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+
+	var tasks *[]database.Task
+	if params.ViewID != nil {
+		tasks, err = api.getViewTasksToSchedule(userID, *params.ViewID, location)
+	} else {
+		tasks, err = api.getTasksToSchedule(userID, params.TaskIDs)
+	}
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	if len(*tasks) > constants.MAX_SCHEDULE_TASKS {
+		c.JSON(400, gin.H{"detail": "too many tasks to schedule"})
+		return
+	}
+
+	freeSlots, err := api.getFreeTimeSlots(user, params.WorkingHours, location, start, end, nil)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	items := []taskScheduleItem{}
+	for _, task := range sortTasksForScheduling(*tasks) {
+		items = append(items, taskScheduleItem{Task: task, Duration: getTaskScheduleDuration(task)})
+	}
+	c.JSON(200, scheduleTaskBlocks(items, freeSlots))
+}
+
+// TaskScheduleConfirm creates calendar events linked to each task for the proposed blocks
+func (api *API) TaskScheduleConfirm(c *gin.Context) {
+	var params TaskScheduleConfirmParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	if params.SourceID == "" {
+		params.SourceID = external.TASK_SOURCE_ID_GCAL
+	}
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(params.SourceID)
+	if err != nil || !taskSourceResult.Details.CanCreateCalendarEvent {
+		c.JSON(400, gin.H{"detail": "'source_id' cannot create events"})
+		return
+	}
+	if len(params.Blocks) > constants.MAX_SCHEDULE_TASKS {
+		c.JSON(400, gin.H{"detail": "too many blocks to schedule"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+
+	tasks := []*database.Task{}
+	for _, block := range params.Blocks {
+		if !block.DatetimeEnd.After(*block.DatetimeStart) {
+			c.JSON(400, gin.H{"detail": "'datetime_end' must be after 'datetime_start'"})
+			return
+		}
+		taskID, err := primitive.ObjectIDFromHex(block.TaskID)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": fmt.Sprintf("linked task not found: %s", block.TaskID)})
+			return
+		}
+		task, err := database.GetTask(api.DB, taskID, userID)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": fmt.Sprintf("linked task not found: %s", block.TaskID)})
+			return
+		}
+		tasks = append(tasks, task)
+	}
+
+	result := TaskScheduleResult{Blocks: []TaskScheduleBlock{}, UnscheduledTaskIDs: []primitive.ObjectID{}}
+	createdEvents := []*database.CalendarEvent{}
+	for index, block := range params.Blocks {
+		task := tasks[index]
+		title := ""
+		if task.Title != nil {
+			title = *task.Title
+		}
+		event, err := api.createCalendarEvent(userID, params.SourceID, taskSourceResult, external.EventCreateObject{
+			AccountID:     params.AccountID,
+			CalendarID:    params.CalendarID,
+			Summary:       title,
+			DatetimeStart: block.DatetimeStart,
+			DatetimeEnd:   block.DatetimeEnd,
+			LinkedTaskID:  task.ID,
+		}, task.SourceID)
+		if err != nil {
+			// blocks are confirmed together, so the events which were already created are removed again
+			api.deleteScheduledEvents(userID, taskSourceResult, createdEvents)
+			Handle500(c)
+			return
+		}
+		createdEvents = append(createdEvents, event)
+		result.Blocks = append(result.Blocks, TaskScheduleBlock{
+			TaskID:        task.ID,
+			EventID:       event.ID,
+			Title:         title,
+			DatetimeStart: *block.DatetimeStart,
+			DatetimeEnd:   *block.DatetimeEnd,
+		})
+	}
+	c.JSON(200, result)
+}
+
+func (api *API) deleteScheduledEvents(userID primitive.ObjectID, taskSourceResult *external.TaskSourceResult, events []*database.CalendarEvent) {
+	for _, event := range events {
+		err := taskSourceResult.Source.DeleteEvent(api.DB, userID, event.SourceAccountID, event.IDExternal, event.CalendarID, "")
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to delete scheduled event from external source")
+			continue
+		}
+		_, err = database.GetCalendarEventCollection(api.DB).DeleteOne(
+			context.Background(),
+			bson.M{"$and": []bson.M{
+				{"_id": event.ID},
+				{"user_id": userID},
+			}},
+		)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to delete scheduled event")
+		}
+	}
+}
+
+// TaskScheduleReflow moves today's blocks which have ended without their task being completed to the next free time
+func (api *API) TaskScheduleReflow(c *gin.Context) {
+	var params TaskScheduleReflowParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	location, err := api.getUserLocation(c, user)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+
+	currentTime := api.GetCurrentTime()
+	end, err := getTaskScheduleEnd(currentTime, params.DatetimeEnd)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	localTime := currentTime.In(location)
+	startOfDay := time.Date(localTime.Year(), localTime.Month(), localTime.Day(), 0, 0, 0, 0, location)
+	blocks, err := database.GetCalendarEvents(api.DB, userID, &[]bson.M{
+		{"linked_task_id": bson.M{"$exists": true}},
+		{"datetime_start": bson.M{"$gte": primitive.NewDateTimeFromTime(startOfDay)}},
+		{"datetime_start": bson.M{"$lt": primitive.NewDateTimeFromTime(end)}},
+	})
+	if err != nil {
+		Handle500(c)
+		return
+	}
+
+	// tasks which still have a block coming up do not need another one
+	upcomingTaskIDs := make(map[primitive.ObjectID]bool)
+	for _, block := range *blocks {
+		if !block.DatetimeEnd.Time().Before(currentTime) {
+			upcomingTaskIDs[block.LinkedTaskID] = true
+		}
+	}
+	items := []taskScheduleItem{}
+	events := make(map[primitive.ObjectID]database.CalendarEvent)
+	movedEventIDs := []primitive.ObjectID{}
+	for _, block := range *blocks {
+		if !block.DatetimeEnd.Time().Before(currentTime) || upcomingTaskIDs[block.LinkedTaskID] {
+			continue
+		}
+		task, err := database.GetTask(api.DB, block.LinkedTaskID, userID)
+		if err != nil || (task.IsCompleted != nil && *task.IsCompleted) || (task.IsDeleted != nil && *task.IsDeleted) {
+			continue
+		}
+		// each task is only moved once if it had several blocks today
+		upcomingTaskIDs[block.LinkedTaskID] = true
+		items = append(items, taskScheduleItem{
+			Task:     *task,
+			Duration: block.DatetimeEnd.Time().Sub(block.DatetimeStart.Time()),
+			EventID:  block.ID,
+		})
+		events[block.ID] = block
+		movedEventIDs = append(movedEventIDs, block.ID)
+	}
+
+	freeSlots, err := api.getFreeTimeSlots(user, params.WorkingHours, location, currentTime, end, movedEventIDs)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	result := scheduleTaskBlocks(items, freeSlots)
+	if params.DryRun {
+		c.JSON(200, result)
+		return
+	}
+	for _, block := range result.Blocks {
+		event := events[block.EventID]
+		taskSourceResult, err := api.ExternalConfig.GetSourceResult(event.SourceID)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to load external event source")
+			Handle500(c)
+			return
+		}
+		modifyParams := external.EventModifyObject{
+			AccountID:     event.SourceAccountID,
+			CalendarID:    event.CalendarID,
+			DatetimeStart: &block.DatetimeStart,
+			DatetimeEnd:   &block.DatetimeEnd,
+		}
+		err = taskSourceResult.Source.ModifyEvent(api.DB, userID, event.SourceAccountID, event.IDExternal, &modifyParams)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to update external task source")
+			Handle500(c)
+			return
+		}
+		err = api.updateEventInDB(modifyParams, &event, userID)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to update internal DB")
+			Handle500(c)
+			return
+		}
+	}
+	c.JSON(200, result)
+}
+
+func getTaskScheduleEnd(start time.Time, datetimeEnd *time.Time) (time.Time, error) {
+	if datetimeEnd == nil {
+		return start.AddDate(0, 0, constants.TASK_SCHEDULE_DEFAULT_DAYS), nil
+	}
+	if !datetimeEnd.After(start) {
+		return time.Time{}, errors.New("'datetime_end' must be in the future")
+	}
+	if datetimeEnd.After(start.AddDate(0, 0, constants.TASK_SCHEDULE_MAX_DAYS)) {
+		return time.Time{}, fmt.Errorf("tasks can only be scheduled %d days ahead", constants.TASK_SCHEDULE_MAX_DAYS)
+	}
+	return *datetimeEnd, nil
+}
+
+func (api *API) getTasksToSchedule(userID primitive.ObjectID, taskIDHexes []string) (*[]database.Task, error) {
+	taskIDs := []primitive.ObjectID{}
+	for _, taskIDHex := range taskIDHexes {
+		taskID, err := primitive.ObjectIDFromHex(taskIDHex)
+		if err != nil {
+			return nil, fmt.Errorf("task not found: %s", taskIDHex)
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+	tasks, err := database.GetTasks(api.DB, userID, &[]bson.M{
+		{"_id": bson.M{"$in": taskIDs}},
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// schedules the open top level tasks shown in a view
+func (api *API) getViewTasksToSchedule(userID primitive.ObjectID, viewIDHex string, location *time.Location) (*[]database.Task, error) {
+	viewID, err := primitive.ObjectIDFromHex(viewIDHex)
+	if err != nil {
+		return nil, errors.New("view not found")
+	}
+	view, err := database.GetView(api.DB, userID, viewID)
+	if err != nil {
+		return nil, errors.New("view not found")
+	}
+	filters := []bson.M{
+		{"is_completed": false},
+		{"is_deleted": bson.M{"$ne": true}},
+		{"parent_task_id": bson.M{"$exists": false}},
+		database.GetNotSnoozedFilter(api.GetCurrentTime()),
+	}
+	switch view.Type {
+	case string(constants.ViewTaskSection):
+		filters = append(filters, bson.M{"id_task_section": view.TaskSectionID})
+	case string(constants.ViewJira):
+		filters = append(filters, bson.M{"source_id": external.TASK_SOURCE_ID_JIRA})
+	case string(constants.ViewLinear):
+		filters = append(filters, bson.M{"source_id": external.TASK_SOURCE_ID_LINEAR})
+	case string(constants.ViewSlack):
+		filters = append(filters, bson.M{"source_id": external.TASK_SOURCE_ID_SLACK_SAVED})
+	case string(constants.ViewDueToday):
+		timeNow := api.GetCurrentTime().In(location)
+		timeEndOfDay := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), 23, 59, 59, 0, time.FixedZone("", 0))
+		filters = append(filters,
+			bson.M{"due_date": bson.M{"$lte": primitive.NewDateTimeFromTime(timeEndOfDay)}},
+			bson.M{"due_date": bson.M{"$gte": primitive.NewDateTimeFromTime(time.Unix(63090000, 0))}},
+		)
+	default:
+		return nil, errors.New("tasks in this view cannot be scheduled")
+	}
+	return database.GetTasks(api.DB, userID, &filters, nil)
+}
+
+// returns the free time within working hours between start and end, ignoring the given events
+func (api *API) getFreeTimeSlots(user *database.User, params *WorkingHoursParams, location *time.Location, start time.Time, end time.Time, ignoredEventIDs []primitive.ObjectID) ([]utils.TimeSlot, error) {
+	availability := utils.GetUserAvailability(user)
+	if params != nil {
+		weekdays := params.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = constants.DefaultWorkingWeekdays
+		}
+		availability.WorkingHours = []database.WorkingHours{}
+		for _, weekday := range weekdays {
+			availability.WorkingHours = append(availability.WorkingHours, database.WorkingHours{
+				Weekday:   weekday,
+				StartTime: params.StartTime,
+				EndTime:   params.EndTime,
+			})
+		}
+		err := utils.ValidateAvailability(availability)
+		if err != nil {
+			return nil, err
+		}
+	}
+	filters := []bson.M{
+		{"datetime_end": bson.M{"$gt": primitive.NewDateTimeFromTime(start)}},
+		{"datetime_start": bson.M{"$lt": primitive.NewDateTimeFromTime(end)}},
+		{"self_response_status": bson.M{"$ne": constants.RSVPStatusDeclined}},
+	}
+	if len(ignoredEventIDs) > 0 {
+		filters = append(filters, bson.M{"_id": bson.M{"$nin": ignoredEventIDs}})
+	}
+	events, err := database.GetCalendarEvents(api.DB, user.ID, &filters)
+	if err != nil {
+		return nil, err
+	}
+	busySlots := []utils.TimeSlot{}
+	for _, event := range *events {
+		busySlots = append(busySlots, utils.TimeSlot{Start: event.DatetimeStart.Time(), End: event.DatetimeEnd.Time()})
+	}
+	return utils.SubtractTimeSlots(utils.GetWorkingHourSlots(availability, location, start, end), busySlots), nil
+}
+
+// tasks without a time allocation get the same default as new General Task tasks
+func getTaskScheduleDuration(task database.Task) time.Duration {
+	if task.TimeAllocation == nil || *task.TimeAllocation <= 0 {
+		return time.Hour
+	}
+	return time.Duration(*task.TimeAllocation)
+}
+
+// orders tasks by due date, then priority, with tasks missing either going last
+func sortTasksForScheduling(tasks []database.Task) []database.Task {
+	sorted := make([]database.Task, len(tasks))
+	copy(sorted, tasks)
+	getDueDate := func(task database.Task) time.Time {
+		if task.DueDate == nil || task.DueDate.Time().Before(time.Unix(63090000, 0)) {
+			return time.Time{}
+		}
+		return task.DueDate.Time()
+	}
+	getPriority := func(task database.Task) float64 {
+		if task.PriorityNormalized == nil || *task.PriorityNormalized <= 0 {
+			return 0
+		}
+		return *task.PriorityNormalized
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		dueDateI, dueDateJ := getDueDate(sorted[i]), getDueDate(sorted[j])
+		if !dueDateI.Equal(dueDateJ) {
+			if dueDateI.IsZero() || dueDateJ.IsZero() {
+				return dueDateJ.IsZero()
+			}
+			return dueDateI.Before(dueDateJ)
+		}
+		priorityI, priorityJ := getPriority(sorted[i]), getPriority(sorted[j])
+		if priorityI != priorityJ {
+			if priorityI == 0 || priorityJ == 0 {
+				return priorityJ == 0
+			}
+			return priorityI < priorityJ
+		}
+		return sorted[i].IDOrdering < sorted[j].IDOrdering
+	})
+	return sorted
+}
+
+// places each task in the earliest free slot it fits in, in order
+func scheduleTaskBlocks(items []taskScheduleItem, freeSlots []utils.TimeSlot) TaskScheduleResult {
+	result := TaskScheduleResult{Blocks: []TaskScheduleBlock{}, UnscheduledTaskIDs: []primitive.ObjectID{}}
+	slots := make([]utils.TimeSlot, len(freeSlots))
+	copy(slots, freeSlots)
+	for _, item := range items {
+		scheduled := false
+		for index, slot := range slots {
+			if slot.End.Sub(slot.Start) < item.Duration {
+				continue
+			}
+			title := ""
+			if item.Task.Title != nil {
+				title = *item.Task.Title
+			}
+			result.Blocks = append(result.Blocks, TaskScheduleBlock{
+				TaskID:        item.Task.ID,
+				EventID:       item.EventID,
+				Title:         title,
+				DatetimeStart: slot.Start,
+				DatetimeEnd:   slot.Start.Add(item.Duration),
+			})
+			slots[index].Start = slot.Start.Add(item.Duration)
+			scheduled = true
+			break
+		}
+		if !scheduled {
+			result.UnscheduledTaskIDs = append(result.UnscheduledTaskIDs, item.Task.ID)
+		}
+	}
+	return result
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSortTasksForScheduling(t *testing.T) {
+	newTask := func(title string, dueDate *time.Time, priority float64, ordering int) database.Task {
+		task := database.Task{Title: &title, PriorityNormalized: &priority, IDOrdering: ordering}
+		if dueDate != nil {
+			primitiveDueDate := primitive.NewDateTimeFromTime(*dueDate)
+			task.DueDate = &primitiveDueDate
+		}
+		return task
+	}
+	soon := time.Date(2022, time.November, 15, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2022, time.November, 20, 0, 0, 0, 0, time.UTC)
+	tasks := sortTasksForScheduling([]database.Task{
+		newTask("no due date", nil, 1, 1),
+		newTask("later", &later, 1, 2),
+		newTask("soon no priority", &soon, 0, 3),
+		newTask("soon low priority", &soon, 4, 4),
+		newTask("soon high priority", &soon, 1, 5),
+	})
+	titles := []string{}
+	for _, task := range tasks {
+		titles = append(titles, *task.Title)
+	}
+	assert.Equal(t, []string{"soon high priority", "soon low priority", "soon no priority", "later", "no due date"}, titles)
+}
+
+func TestScheduleTaskBlocks(t *testing.T) {
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2022, time.November, 14, hour, minute, 0, 0, time.UTC)
+	}
+	newItem := func(title string, duration time.Duration) taskScheduleItem {
+		return taskScheduleItem{Task: database.Task{ID: primitive.NewObjectID(), Title: &title}, Duration: duration}
+	}
+	long := newItem("long", 2*time.Hour)
+	short := newItem("short", 30*time.Minute)
+	tooLong := newItem("too long", 5*time.Hour)
+	result := scheduleTaskBlocks(
+		[]taskScheduleItem{long, short, tooLong},
+		[]utils.TimeSlot{{Start: at(9, 0), End: at(10, 0)}, {Start: at(13, 0), End: at(17, 0)}},
+	)
+	assert.Equal(t, []TaskScheduleBlock{
+		{TaskID: long.Task.ID, Title: "long", DatetimeStart: at(13, 0), DatetimeEnd: at(15, 0)},
+		{TaskID: short.Task.ID, Title: "short", DatetimeStart: at(9, 0), DatetimeEnd: at(9, 30)},
+	}, result.Blocks)
+	assert.Equal(t, []primitive.ObjectID{tooLong.Task.ID}, result.UnscheduledTaskIDs)
+}
+
+func TestTaskSchedule(t *testing.T) {
+	authToken := login("test_task_schedule@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	// Monday November 14, 2022 at 9:00
+	currentTime := time.Date(2022, time.November, 14, 9, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+
+	title := "write design doc"
+	completed := false
+	timeAllocation := (90 * time.Minute).Nanoseconds()
+	insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{
+		UserID:         userID,
+		Title:          &title,
+		IsCompleted:    &completed,
+		TimeAllocation: &timeAllocation,
+	})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	_, err = database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+		UserID:        userID,
+		Title:         "standup",
+		DatetimeStart: primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 9, 30, 0, 0, time.UTC)),
+		DatetimeEnd:   primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 10, 0, 0, 0, time.UTC)),
+	})
+	assert.NoError(t, err)
+
+	serveScheduleRequest := func(url string, body string, expectedCode int) []byte {
+		router := GetRouter(api)
+		request, _ := http.NewRequest("POST", url, bytes.NewBuffer([]byte(body)))
+		request.Header.Add("Authorization", "Bearer "+authToken)
+		request.Header.Add("Timezone-Offset", "0")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assert.Equal(t, expectedCode, recorder.Code)
+		responseBody, err := io.ReadAll(recorder.Body)
+		assert.NoError(t, err)
+		return responseBody
+	}
+
+	UnauthorizedTest(t, "POST", "/tasks/schedule/", nil)
+	t.Run("MissingTasks", func(t *testing.T) {
+		body := serveScheduleRequest("/tasks/schedule/", `{}`, http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"'task_ids' or 'view_id' is required"}`, string(body))
+	})
+	t.Run("MissingTimezoneOffset", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/tasks/schedule/", bytes.NewBuffer([]byte(`{"task_ids":["`+taskID.Hex()+`"]}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"Timezone-Offset header is required"}`, string(body))
+	})
+	t.Run("InvalidWorkingHours", func(t *testing.T) {
+		body := serveScheduleRequest("/tasks/schedule/", `{"task_ids":["`+taskID.Hex()+`"],"working_hours":{"start_time":"17:00","end_time":"09:00"}}`, http.StatusBadRequest)
+		assert.Equal(t, `{"detail":"invalid working hours: end time must be after start time"}`, string(body))
+	})
+	t.Run("Success", func(t *testing.T) {
+		body := serveScheduleRequest("/tasks/schedule/", `{"task_ids":["`+taskID.Hex()+`"]}`, http.StatusOK)
+		var result TaskScheduleResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		// the block goes after the standup as it does not fit before it
+		assert.Equal(t, 1, len(result.Blocks))
+		assert.Equal(t, taskID, result.Blocks[0].TaskID)
+		assert.Equal(t, time.Date(2022, time.November, 14, 10, 0, 0, 0, time.UTC), result.Blocks[0].DatetimeStart.UTC())
+		assert.Equal(t, time.Date(2022, time.November, 14, 11, 30, 0, 0, time.UTC), result.Blocks[0].DatetimeEnd.UTC())
+		assert.Equal(t, 0, len(result.UnscheduledTaskIDs))
+	})
+	t.Run("ConfirmInvalidSource", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/tasks/schedule/confirm/", bytes.NewBuffer([]byte(`{"source_id":"asana_task","account_id":"a","blocks":[]}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"'source_id' cannot create events"}`, string(body))
+	})
+	t.Run("ConfirmInvalidTask", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "POST", "/tasks/schedule/confirm/", bytes.NewBuffer([]byte(`{"account_id":"a","blocks":[{"task_id":"`+primitive.NewObjectID().Hex()+`","datetime_start":"2022-11-14T10:00:00Z","datetime_end":"2022-11-14T11:00:00Z"}]}`)), http.StatusBadRequest, api)
+		assert.Contains(t, string(body), "linked task not found")
+	})
+	t.Run("ConfirmFailureDeletesCreatedEvents", func(t *testing.T) {
+		createCount := 0
+		createServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			createCount++
+			if createCount > 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Write([]byte("{}"))
+		}))
+		defer createServer.Close()
+		deleteCount := 0
+		deleteServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			deleteCount++
+			w.Write([]byte("{}"))
+		}))
+		defer deleteServer.Close()
+		api.ExternalConfig.GoogleOverrideURLs.CalendarCreateURL = &createServer.URL
+		api.ExternalConfig.GoogleOverrideURLs.CalendarDeleteURL = &deleteServer.URL
+
+		ServeRequest(t, authToken, "POST", "/tasks/schedule/confirm/", bytes.NewBuffer([]byte(`{"account_id":"a","blocks":[`+
+			`{"task_id":"`+taskID.Hex()+`","datetime_start":"2022-11-15T10:00:00Z","datetime_end":"2022-11-15T11:00:00Z"},`+
+			`{"task_id":"`+taskID.Hex()+`","datetime_start":"2022-11-15T12:00:00Z","datetime_end":"2022-11-15T13:00:00Z"}]}`)), http.StatusInternalServerError, api)
+		assert.Equal(t, 2, createCount)
+		assert.Equal(t, 1, deleteCount)
+		count, err := database.GetCalendarEventCollection(api.DB).CountDocuments(context.Background(), bson.M{"linked_task_id": taskID})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("ReflowDryRun", func(t *testing.T) {
+		// an unfinished block from earlier in the day is moved to the next free time
+		_, err := database.GetCalendarEventCollection(api.DB).InsertOne(context.Background(), database.CalendarEvent{
+			UserID:        userID,
+			Title:         title,
+			LinkedTaskID:  taskID,
+			DatetimeStart: primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 10, 0, 0, 0, time.UTC)),
+			DatetimeEnd:   primitive.NewDateTimeFromTime(time.Date(2022, time.November, 14, 11, 0, 0, 0, time.UTC)),
+		})
+		assert.NoError(t, err)
+		reflowTime := time.Date(2022, time.November, 14, 16, 30, 0, 0, time.UTC)
+		api.OverrideTime = &reflowTime
+		defer func() { api.OverrideTime = &currentTime }()
+
+		body := serveScheduleRequest("/tasks/schedule/reflow/", `{"dry_run":true}`, http.StatusOK)
+		var result TaskScheduleResult
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 1, len(result.Blocks))
+		assert.Equal(t, taskID, result.Blocks[0].TaskID)
+		assert.Equal(t, time.Date(2022, time.November, 15, 9, 0, 0, 0, time.UTC), result.Blocks[0].DatetimeStart.UTC())
+		assert.Equal(t, time.Date(2022, time.November, 15, 10, 0, 0, 0, time.UTC), result.Blocks[0].DatetimeEnd.UTC())
+	})
+}
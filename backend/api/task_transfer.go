@@ -0,0 +1,345 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+)
+
+// the format independent representation of a task used by task imports and exports
+type TaskTransferItem struct {
+	ID          string                `json:"id,omitempty"`
+	Title       string                `json:"title"`
+	Body        string                `json:"body,omitempty"`
+	Section     string                `json:"section,omitempty"`
+	DueDate     string                `json:"due_date,omitempty"`
+	Priority    float64               `json:"priority,omitempty"`
+	IsCompleted bool                  `json:"is_completed"`
+	Comments    []TaskTransferComment `json:"comments,omitempty"`
+	Subtasks    []*TaskTransferItem   `json:"subtasks,omitempty"`
+}
+
+type TaskTransferComment struct {
+	Author string `json:"author,omitempty"`
+	Body   string `json:"body"`
+}
+
+// used to rebuild subtask nesting for the flat formats
+type flatTaskTransferItem struct {
+	Item     *TaskTransferItem
+	ParentID string
+}
+
+var csvTaskHeader = []string{"id", "parent_id", "title", "body", "section", "due_date", "priority", "is_completed", "comments"}
+
+// todo.txt only supports letter priorities, these map to priority_normalized values 1 (highest) to 4
+const todoTxtPriorities = "ABCD"
+
+func isValidTaskTransferFormat(format string) bool {
+	return format == constants.TaskTransferFormatJSON || format == constants.TaskTransferFormatCSV || format == constants.TaskTransferFormatTodoTxt
+}
+
+func encodeTaskTransferItems(format string, items []*TaskTransferItem) ([]byte, error) {
+	switch format {
+	case constants.TaskTransferFormatJSON:
+		return json.MarshalIndent(items, "", "\t")
+	case constants.TaskTransferFormatCSV:
+		return encodeTaskTransferItemsCSV(items)
+	case constants.TaskTransferFormatTodoTxt:
+		return encodeTaskTransferItemsTodoTxt(items), nil
+	}
+	return nil, errors.New("invalid format")
+}
+
+func decodeTaskTransferItems(format string, data string) ([]*TaskTransferItem, error) {
+	var items []*TaskTransferItem
+	var err error
+	switch format {
+	case constants.TaskTransferFormatJSON:
+		err = json.Unmarshal([]byte(data), &items)
+		if err != nil {
+			return nil, errors.New("invalid JSON")
+		}
+	case constants.TaskTransferFormatCSV:
+		items, err = decodeTaskTransferItemsCSV(data)
+	case constants.TaskTransferFormatTodoTxt:
+		items, err = decodeTaskTransferItemsTodoTxt(data)
+	default:
+		err = errors.New("invalid format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return items, validateTaskTransferItems(items)
+}
+
+func validateTaskTransferItems(items []*TaskTransferItem) error {
+	for _, item := range items {
+		if item == nil || strings.TrimSpace(item.Title) == "" {
+			return errors.New("every task must have a title")
+		}
+		if item.DueDate != "" {
+			_, err := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, item.DueDate)
+			if err != nil {
+				return fmt.Errorf("invalid due date for '%s'", item.Title)
+			}
+		}
+		if item.Priority < 0 || item.Priority > float64(len(todoTxtPriorities)) {
+			return fmt.Errorf("invalid priority for '%s'", item.Title)
+		}
+		err := validateTaskTransferItems(item.Subtasks)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func countTaskTransferItems(items []*TaskTransferItem) int {
+	count := 0
+	for _, item := range items {
+		count += 1 + countTaskTransferItems(item.Subtasks)
+	}
+	return count
+}
+
+func flattenTaskTransferItems(items []*TaskTransferItem, parentID string) []flatTaskTransferItem {
+	flatItems := []flatTaskTransferItem{}
+	for _, item := range items {
+		flatItems = append(flatItems, flatTaskTransferItem{Item: item, ParentID: parentID})
+		flatItems = append(flatItems, flattenTaskTransferItems(item.Subtasks, item.ID)...)
+	}
+	return flatItems
+}
+
+func nestTaskTransferItems(flatItems []flatTaskTransferItem) ([]*TaskTransferItem, error) {
+	itemsByID := make(map[string]*TaskTransferItem)
+	for _, flatItem := range flatItems {
+		if flatItem.Item.ID == "" {
+			continue
+		}
+		if _, exists := itemsByID[flatItem.Item.ID]; exists {
+			return nil, fmt.Errorf("duplicate task id '%s'", flatItem.Item.ID)
+		}
+		itemsByID[flatItem.Item.ID] = flatItem.Item
+	}
+	roots := []*TaskTransferItem{}
+	for _, flatItem := range flatItems {
+		if flatItem.ParentID == "" {
+			roots = append(roots, flatItem.Item)
+			continue
+		}
+		parent, exists := itemsByID[flatItem.ParentID]
+		if !exists {
+			return nil, fmt.Errorf("parent task '%s' not found", flatItem.ParentID)
+		}
+		parent.Subtasks = append(parent.Subtasks, flatItem.Item)
+	}
+	// tasks which are only reachable through each other are never attached to a root
+	if countTaskTransferItems(roots) != len(flatItems) {
+		return nil, errors.New("subtasks cannot form a cycle")
+	}
+	return roots, nil
+}
+
+func encodeTaskTransferItemsCSV(items []*TaskTransferItem) ([]byte, error) {
+	rows := [][]string{csvTaskHeader}
+	for _, flatItem := range flattenTaskTransferItems(items, "") {
+		item := flatItem.Item
+		comments := []string{}
+		for _, comment := range item.Comments {
+			comments = append(comments, comment.Author+": "+comment.Body)
+		}
+		priority := ""
+		if item.Priority != 0 {
+			priority = strconv.FormatFloat(item.Priority, 'f', -1, 64)
+		}
+		rows = append(rows, []string{
+			item.ID,
+			flatItem.ParentID,
+			item.Title,
+			item.Body,
+			item.Section,
+			item.DueDate,
+			priority,
+			strconv.FormatBool(item.IsCompleted),
+			strings.Join(comments, "\n"),
+		})
+	}
+	var buffer bytes.Buffer
+	err := csv.NewWriter(&buffer).WriteAll(rows)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func decodeTaskTransferItemsCSV(data string) ([]*TaskTransferItem, error) {
+	rows, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, errors.New("invalid CSV")
+	}
+	if len(rows) == 0 {
+		return []*TaskTransferItem{}, nil
+	}
+	columns := make(map[string]int)
+	for index, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = index
+	}
+	if _, exists := columns["title"]; !exists {
+		return nil, errors.New("CSV must have a 'title' column")
+	}
+	flatItems := []flatTaskTransferItem{}
+	for rowIndex, row := range rows[1:] {
+		getValue := func(column string) string {
+			index, exists := columns[column]
+			if !exists {
+				return ""
+			}
+			return strings.TrimSpace(row[index])
+		}
+		item := &TaskTransferItem{
+			ID:      getValue("id"),
+			Title:   getValue("title"),
+			Body:    getValue("body"),
+			Section: getValue("section"),
+			DueDate: getValue("due_date"),
+		}
+		if getValue("priority") != "" {
+			item.Priority, err = strconv.ParseFloat(getValue("priority"), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid priority on row %d", rowIndex+2)
+			}
+		}
+		if getValue("is_completed") != "" {
+			item.IsCompleted, err = strconv.ParseBool(getValue("is_completed"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid is_completed on row %d", rowIndex+2)
+			}
+		}
+		for _, line := range strings.Split(getValue("comments"), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			comment := TaskTransferComment{Body: line}
+			if author, body, found := strings.Cut(line, ": "); found {
+				comment = TaskTransferComment{Author: author, Body: body}
+			}
+			item.Comments = append(item.Comments, comment)
+		}
+		flatItems = append(flatItems, flatTaskTransferItem{Item: item, ParentID: getValue("parent_id")})
+	}
+	return nestTaskTransferItems(flatItems)
+}
+
+// todo.txt has no place for bodies or comments, sections are stored as +projects and subtasks with id: and parent: tags
+func encodeTaskTransferItemsTodoTxt(items []*TaskTransferItem) []byte {
+	var buffer bytes.Buffer
+	for _, flatItem := range flattenTaskTransferItems(items, "") {
+		item := flatItem.Item
+		parts := []string{}
+		priority := ""
+		if item.Priority >= 1 && int(item.Priority) <= len(todoTxtPriorities) {
+			priority = string(todoTxtPriorities[int(item.Priority)-1])
+		}
+		if item.IsCompleted {
+			parts = append(parts, "x")
+		} else if priority != "" {
+			parts = append(parts, "("+priority+")")
+		}
+		parts = append(parts, strings.Join(strings.Fields(item.Title), " "))
+		if item.Section != "" {
+			parts = append(parts, "+"+strings.Join(strings.Fields(item.Section), "_"))
+		}
+		if item.DueDate != "" {
+			parts = append(parts, "due:"+item.DueDate)
+		}
+		// completed tasks cannot have a (A) style priority
+		if item.IsCompleted && priority != "" {
+			parts = append(parts, "pri:"+priority)
+		}
+		if len(item.Subtasks) > 0 {
+			parts = append(parts, "id:"+item.ID)
+		}
+		if flatItem.ParentID != "" {
+			parts = append(parts, "parent:"+flatItem.ParentID)
+		}
+		buffer.WriteString(strings.Join(parts, " ") + "\n")
+	}
+	return buffer.Bytes()
+}
+
+func decodeTaskTransferItemsTodoTxt(data string) ([]*TaskTransferItem, error) {
+	flatItems := []flatTaskTransferItem{}
+	for _, line := range strings.Split(data, "\n") {
+		tokens := strings.Fields(line)
+		if len(tokens) == 0 {
+			continue
+		}
+		item := &TaskTransferItem{}
+		parentID := ""
+		if tokens[0] == "x" {
+			item.IsCompleted = true
+			tokens = tokens[1:]
+		}
+		titleWords := []string{}
+		for index, token := range tokens {
+			if index == 0 && len(token) == 3 && token[0] == '(' && token[2] == ')' {
+				item.Priority = getTodoTxtPriority(token[1:2])
+				continue
+			}
+			// completion and creation dates come before the description
+			if len(titleWords) == 0 && isTodoTxtDate(token) {
+				continue
+			}
+			if strings.HasPrefix(token, "+") && len(token) > 1 {
+				item.Section = strings.ReplaceAll(token[1:], "_", " ")
+				continue
+			}
+			if key, value, found := strings.Cut(token, ":"); found && value != "" && !strings.Contains(key, "/") {
+				switch key {
+				case "due":
+					item.DueDate = value
+					continue
+				case "pri":
+					item.Priority = getTodoTxtPriority(value)
+					continue
+				case "id":
+					item.ID = value
+					continue
+				case "parent":
+					parentID = value
+					continue
+				}
+			}
+			titleWords = append(titleWords, token)
+		}
+		item.Title = strings.Join(titleWords, " ")
+		flatItems = append(flatItems, flatTaskTransferItem{Item: item, ParentID: parentID})
+	}
+	return nestTaskTransferItems(flatItems)
+}
+
+// priorities below D are treated as the lowest priority
+func getTodoTxtPriority(letter string) float64 {
+	if len(letter) != 1 || letter[0] < 'A' || letter[0] > 'Z' {
+		return 0
+	}
+	index := strings.Index(todoTxtPriorities, letter)
+	if index < 0 {
+		return float64(len(todoTxtPriorities))
+	}
+	return float64(index + 1)
+}
+
+func isTodoTxtDate(token string) bool {
+	_, err := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, token)
+	return err == nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestTaskTransferItems() []*TaskTransferItem {
+	return []*TaskTransferItem{
+		{
+			ID:       "1",
+			Title:    "plan launch",
+			Body:     "with the whole team",
+			Section:  "Work Stuff",
+			DueDate:  "2023-03-10",
+			Priority: 1,
+			Comments: []TaskTransferComment{{Author: "John", Body: "sounds good"}},
+			Subtasks: []*TaskTransferItem{
+				{ID: "2", Title: "write announcement", IsCompleted: true, Priority: 2},
+			},
+		},
+		{ID: "3", Title: "buy milk"},
+	}
+}
+
+func TestTaskTransferJSON(t *testing.T) {
+	data, err := encodeTaskTransferItems(constants.TaskTransferFormatJSON, getTestTaskTransferItems())
+	assert.NoError(t, err)
+	items, err := decodeTaskTransferItems(constants.TaskTransferFormatJSON, string(data))
+	assert.NoError(t, err)
+	assert.Equal(t, getTestTaskTransferItems(), items)
+
+	_, err = decodeTaskTransferItems(constants.TaskTransferFormatJSON, "{")
+	assert.EqualError(t, err, "invalid JSON")
+	_, err = decodeTaskTransferItems(constants.TaskTransferFormatJSON, `[{"title": "bad date", "due_date": "tomorrow"}]`)
+	assert.EqualError(t, err, "invalid due date for 'bad date'")
+	_, err = decodeTaskTransferItems(constants.TaskTransferFormatJSON, `[{"title": "  "}]`)
+	assert.EqualError(t, err, "every task must have a title")
+	_, err = decodeTaskTransferItems(constants.TaskTransferFormatJSON, `[null]`)
+	assert.EqualError(t, err, "every task must have a title")
+	_, err = decodeTaskTransferItems(constants.TaskTransferFormatJSON, `[{"title": "parent", "subtasks": [null]}]`)
+	assert.EqualError(t, err, "every task must have a title")
+}
+
+func TestTaskTransferCSV(t *testing.T) {
+	data, err := encodeTaskTransferItems(constants.TaskTransferFormatCSV, getTestTaskTransferItems())
+	assert.NoError(t, err)
+	assert.Equal(t, "id,parent_id,title,body,section,due_date,priority,is_completed,comments\n"+
+		"1,,plan launch,with the whole team,Work Stuff,2023-03-10,1,false,John: sounds good\n"+
+		"2,1,write announcement,,,,2,true,\n"+
+		"3,,buy milk,,,,,false,\n", string(data))
+	items, err := decodeTaskTransferItems(constants.TaskTransferFormatCSV, string(data))
+	assert.NoError(t, err)
+	assert.Equal(t, getTestTaskTransferItems(), items)
+
+	t.Run("OnlyTitle", func(t *testing.T) {
+		items, err := decodeTaskTransferItems(constants.TaskTransferFormatCSV, "Title\nfirst\nsecond\n")
+		assert.NoError(t, err)
+		assert.Equal(t, []*TaskTransferItem{{Title: "first"}, {Title: "second"}}, items)
+	})
+	t.Run("MissingTitleColumn", func(t *testing.T) {
+		_, err := decodeTaskTransferItems(constants.TaskTransferFormatCSV, "name\nfirst\n")
+		assert.EqualError(t, err, "CSV must have a 'title' column")
+	})
+	t.Run("UnknownParent", func(t *testing.T) {
+		_, err := decodeTaskTransferItems(constants.TaskTransferFormatCSV, "id,parent_id,title\n1,5,first\n")
+		assert.EqualError(t, err, "parent task '5' not found")
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		_, err := decodeTaskTransferItems(constants.TaskTransferFormatCSV, "id,parent_id,title\n1,2,first\n2,1,second\n")
+		assert.EqualError(t, err, "subtasks cannot form a cycle")
+	})
+	t.Run("InvalidPriority", func(t *testing.T) {
+		_, err := decodeTaskTransferItems(constants.TaskTransferFormatCSV, "title,priority\nfirst,high\n")
+		assert.EqualError(t, err, "invalid priority on row 2")
+		_, err = decodeTaskTransferItems(constants.TaskTransferFormatCSV, "title,priority\nfirst,7\n")
+		assert.EqualError(t, err, "invalid priority for 'first'")
+	})
+}
+
+func TestTaskTransferTodoTxt(t *testing.T) {
+	data, err := encodeTaskTransferItems(constants.TaskTransferFormatTodoTxt, getTestTaskTransferItems())
+	assert.NoError(t, err)
+	assert.Equal(t, "(A) plan launch +Work_Stuff due:2023-03-10 id:1\n"+
+		"x write announcement pri:B parent:1\n"+
+		"buy milk\n", string(data))
+
+	items, err := decodeTaskTransferItems(constants.TaskTransferFormatTodoTxt, string(data))
+	assert.NoError(t, err)
+	// bodies and comments are not part of todo.txt, and only parent tasks keep their ids
+	expected := getTestTaskTransferItems()
+	expected[0].Body = ""
+	expected[0].Comments = nil
+	expected[0].Subtasks[0].ID = ""
+	expected[1].ID = ""
+	assert.Equal(t, expected, items)
+
+	t.Run("Dates", func(t *testing.T) {
+		items, err := decodeTaskTransferItems(constants.TaskTransferFormatTodoTxt, "x 2023-03-02 2023-03-01 call mom at 5:30 @phone\n\n(E) read https://example.com\n")
+		assert.NoError(t, err)
+		assert.Equal(t, []*TaskTransferItem{
+			{Title: "call mom at 5:30 @phone", IsCompleted: true},
+			{Title: "read https://example.com", Priority: 4},
+		}, items)
+	})
+}
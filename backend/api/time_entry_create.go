@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TimeEntryCreateParams struct {
+	TaskID    primitive.ObjectID `json:"task_id" binding:"required"`
+	StartTime string             `json:"start_time" binding:"required"`
+	EndTime   string             `json:"end_time" binding:"required"`
+}
+
+// TimeEntryCreate records time spent on a task after the fact
+func (api *API) TimeEntryCreate(c *gin.Context) {
+	var params TimeEntryCreateParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	startTime, err := time.Parse(time.RFC3339, params.StartTime)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "'start_time' is not a valid date"})
+		return
+	}
+	endTime, err := time.Parse(time.RFC3339, params.EndTime)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "'end_time' is not a valid date"})
+		return
+	}
+	now := api.GetCurrentTime()
+	if !startTime.Before(endTime) || endTime.After(now) {
+		c.JSON(400, gin.H{"detail": "time entry must end after it starts and cannot end in the future"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	_, err = database.GetTask(api.DB, params.TaskID, userID)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "task not found"})
+		return
+	}
+	overlaps, err := database.HasOverlappingTimeEntry(api.DB, userID, startTime, endTime, now)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	if overlaps {
+		c.JSON(400, gin.H{"detail": "time entry overlaps an existing time entry"})
+		return
+	}
+
+	insertResult, err := database.GetTimeEntryCollection(api.DB).InsertOne(context.Background(), database.TimeEntry{
+		UserID:    userID,
+		TaskID:    params.TaskID,
+		StartTime: primitive.NewDateTimeFromTime(startTime),
+		EndTime:   primitive.NewDateTimeFromTime(endTime),
+		CreatedAt: primitive.NewDateTimeFromTime(now),
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create time entry")
+		Handle500(c)
+		return
+	}
+	err = database.UpdateTaskTimeTracked(api.DB, userID, params.TaskID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{"time_entry_id": insertResult.InsertedID.(primitive.ObjectID)})
+}
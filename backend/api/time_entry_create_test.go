@@ -0,0 +1,61 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTimeEntryCreate(t *testing.T) {
+	authToken := login("test_time_entry_create@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	currentTime := time.Date(2023, time.January, 4, 20, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+
+	insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{UserID: userID})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	create := func(taskID primitive.ObjectID, startTime string, endTime string, expectedCode int) {
+		body := `{"task_id": "` + taskID.Hex() + `", "start_time": "` + startTime + `", "end_time": "` + endTime + `"}`
+		ServeRequest(t, authToken, "POST", "/time_entries/create/", bytes.NewBuffer([]byte(body)), expectedCode, api)
+	}
+
+	UnauthorizedTest(t, "POST", "/time_entries/create/", nil)
+	t.Run("InvalidParams", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/time_entries/create/", bytes.NewBuffer([]byte(`{"task_id": "`+taskID.Hex()+`"}`)), http.StatusBadRequest, api)
+		create(taskID, "yesterday", "2023-01-04T10:00:00Z", http.StatusBadRequest)
+		create(taskID, "2023-01-04T09:00:00Z", "today", http.StatusBadRequest)
+		// ends before it starts
+		create(taskID, "2023-01-04T10:00:00Z", "2023-01-04T09:00:00Z", http.StatusBadRequest)
+		// ends in the future
+		create(taskID, "2023-01-04T19:00:00Z", "2023-01-04T21:00:00Z", http.StatusBadRequest)
+	})
+	t.Run("TaskNotFound", func(t *testing.T) {
+		create(primitive.NewObjectID(), "2023-01-04T09:00:00Z", "2023-01-04T10:00:00Z", http.StatusNotFound)
+	})
+	t.Run("Success", func(t *testing.T) {
+		create(taskID, "2023-01-04T09:00:00Z", "2023-01-04T10:00:00Z", http.StatusOK)
+		create(taskID, "2023-01-04T10:00:00Z", "2023-01-04T10:20:00Z", http.StatusOK)
+		task, err := database.GetTask(api.DB, taskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(80*time.Minute), *task.TimeTracked)
+	})
+	t.Run("Overlap", func(t *testing.T) {
+		create(taskID, "2023-01-04T08:30:00Z", "2023-01-04T09:30:00Z", http.StatusBadRequest)
+		create(taskID, "2023-01-04T10:10:00Z", "2023-01-04T10:15:00Z", http.StatusBadRequest)
+		// overlaps a running timer
+		ServeRequest(t, authToken, "POST", "/time_entries/start/", bytes.NewBuffer([]byte(`{"task_id": "`+taskID.Hex()+`"}`)), http.StatusOK, api)
+		later := currentTime.Add(time.Hour)
+		api.OverrideTime = &later
+		create(taskID, "2023-01-04T20:10:00Z", "2023-01-04T20:20:00Z", http.StatusBadRequest)
+		create(taskID, "2023-01-04T19:00:00Z", "2023-01-04T19:30:00Z", http.StatusOK)
+	})
+}
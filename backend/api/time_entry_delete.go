@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (api *API) TimeEntryDelete(c *gin.Context) {
+	timeEntryID, err := primitive.ObjectIDFromHex(c.Param("time_entry_id"))
+	if err != nil {
+		// This means the time entry ID is improperly formatted
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	var timeEntry database.TimeEntry
+	err = database.GetTimeEntryCollection(api.DB).FindOneAndDelete(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": timeEntryID},
+			{"user_id": userID},
+		}},
+	).Decode(&timeEntry)
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	err = database.UpdateTaskTimeTracked(api.DB, userID, timeEntry.TaskID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
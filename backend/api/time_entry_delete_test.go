@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTimeEntryDelete(t *testing.T) {
+	authToken := login("test_time_entry_delete@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	startTime := time.Date(2023, time.January, 4, 9, 0, 0, 0, time.UTC)
+	timeTracked := int64(time.Hour)
+	insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{UserID: userID, TimeTracked: &timeTracked})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	insertResult, err = database.GetTimeEntryCollection(api.DB).InsertOne(context.Background(), database.TimeEntry{
+		UserID:    userID,
+		TaskID:    taskID,
+		StartTime: primitive.NewDateTimeFromTime(startTime),
+		EndTime:   primitive.NewDateTimeFromTime(startTime.Add(time.Hour)),
+	})
+	assert.NoError(t, err)
+	timeEntryID := insertResult.InsertedID.(primitive.ObjectID)
+
+	UnauthorizedTest(t, "DELETE", "/time_entries/delete/"+timeEntryID.Hex()+"/", nil)
+	t.Run("NotFound", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", "/time_entries/delete/invalid/", nil, http.StatusNotFound, api)
+		ServeRequest(t, authToken, "DELETE", "/time_entries/delete/"+primitive.NewObjectID().Hex()+"/", nil, http.StatusNotFound, api)
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, "DELETE", "/time_entries/delete/"+timeEntryID.Hex()+"/", nil, http.StatusOK, api)
+		task, err := database.GetTask(api.DB, taskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), *task.TimeTracked)
+		ServeRequest(t, authToken, "DELETE", "/time_entries/delete/"+timeEntryID.Hex()+"/", nil, http.StatusNotFound, api)
+	})
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TimeEntryResult struct {
+	ID        primitive.ObjectID `json:"id"`
+	TaskID    primitive.ObjectID `json:"task_id"`
+	TaskTitle string             `json:"task_title"`
+	StartTime string             `json:"start_time"`
+	EndTime   string             `json:"end_time,omitempty"`
+	// running timers report the time tracked so far
+	DurationSeconds int64 `json:"duration_seconds"`
+	IsRunning       bool  `json:"is_running"`
+}
+
+// TimeEntriesList lists time entries started in the date range (inclusive, in UTC), optionally as a CSV export
+func (api *API) TimeEntriesList(c *gin.Context) {
+	var start *time.Time
+	if c.Query("start_date") != "" {
+		startDate, err := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, c.Query("start_date"))
+		if err != nil {
+			c.JSON(400, gin.H{"detail": "'start_date' is not a valid date"})
+			return
+		}
+		start = &startDate
+	}
+	var end *time.Time
+	if c.Query("end_date") != "" {
+		endDate, err := time.Parse(constants.YEAR_MONTH_DAY_FORMAT, c.Query("end_date"))
+		if err != nil {
+			c.JSON(400, gin.H{"detail": "'end_date' is not a valid date"})
+			return
+		}
+		endDate = endDate.AddDate(0, 0, 1)
+		end = &endDate
+	}
+	format := c.DefaultQuery("format", constants.TimeEntryExportFormatJSON)
+	if format != constants.TimeEntryExportFormatJSON && format != constants.TimeEntryExportFormatCSV {
+		c.JSON(400, gin.H{"detail": "'format' must be json or csv"})
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+	timeEntries, err := database.GetTimeEntries(api.DB, userID, start, end)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	if len(*timeEntries) > constants.MAX_TIME_ENTRIES {
+		c.JSON(400, gin.H{"detail": fmt.Sprintf("date range has more than %d time entries, please use a smaller range", constants.MAX_TIME_ENTRIES)})
+		return
+	}
+	results, err := api.timeEntriesToTimeEntryResults(userID, timeEntries)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+
+	if format == constants.TimeEntryExportFormatCSV {
+		body, err := timeEntryResultsToCSV(results)
+		if err != nil {
+			api.Logger.Error().Err(err).Msg("failed to write time entries csv")
+			Handle500(c)
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=time_entries.csv")
+		c.Data(200, "text/csv; charset=utf-8", body)
+		return
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) timeEntriesToTimeEntryResults(userID primitive.ObjectID, timeEntries *[]database.TimeEntry) ([]TimeEntryResult, error) {
+	taskIDs := []primitive.ObjectID{}
+	for _, timeEntry := range *timeEntries {
+		taskIDs = append(taskIDs, timeEntry.TaskID)
+	}
+	tasks, err := database.GetTasks(api.DB, userID, &[]bson.M{{"_id": bson.M{"$in": taskIDs}}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	taskTitles := make(map[primitive.ObjectID]string)
+	for _, task := range *tasks {
+		if task.Title != nil {
+			taskTitles[task.ID] = *task.Title
+		}
+	}
+
+	now := api.GetCurrentTime()
+	results := []TimeEntryResult{}
+	for _, timeEntry := range *timeEntries {
+		result := TimeEntryResult{
+			ID:        timeEntry.ID,
+			TaskID:    timeEntry.TaskID,
+			TaskTitle: taskTitles[timeEntry.TaskID],
+			StartTime: timeEntry.StartTime.Time().UTC().Format(time.RFC3339),
+			IsRunning: timeEntry.EndTime == 0,
+		}
+		endTime := now
+		if !result.IsRunning {
+			endTime = timeEntry.EndTime.Time()
+			result.EndTime = endTime.UTC().Format(time.RFC3339)
+		}
+		result.DurationSeconds = int64(endTime.Sub(timeEntry.StartTime.Time()).Seconds())
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func timeEntryResultsToCSV(results []TimeEntryResult) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	rows := [][]string{{"id", "task_id", "task_title", "start_time", "end_time", "duration_seconds"}}
+	for _, result := range results {
+		rows = append(rows, []string{
+			result.ID.Hex(),
+			result.TaskID.Hex(),
+			result.TaskTitle,
+			result.StartTime,
+			result.EndTime,
+			strconv.FormatInt(result.DurationSeconds, 10),
+		})
+	}
+	err := writer.WriteAll(rows)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTimeEntriesList(t *testing.T) {
+	authToken := login("test_time_entries_list@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	currentTime := time.Date(2023, time.January, 4, 20, 0, 0, 0, time.UTC)
+	api.OverrideTime = &currentTime
+
+	title := "tracked, task"
+	insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{UserID: userID, Title: &title})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	insertTimeEntry := func(userID primitive.ObjectID, startTime time.Time, endTime time.Time) primitive.ObjectID {
+		timeEntry := database.TimeEntry{
+			UserID:    userID,
+			TaskID:    taskID,
+			StartTime: primitive.NewDateTimeFromTime(startTime),
+		}
+		if !endTime.IsZero() {
+			timeEntry.EndTime = primitive.NewDateTimeFromTime(endTime)
+		}
+		insertResult, err := database.GetTimeEntryCollection(api.DB).InsertOne(context.Background(), timeEntry)
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	earlyEntryID := insertTimeEntry(userID, time.Date(2023, time.January, 2, 9, 0, 0, 0, time.UTC), time.Date(2023, time.January, 2, 10, 0, 0, 0, time.UTC))
+	runningEntryID := insertTimeEntry(userID, time.Date(2023, time.January, 4, 19, 30, 0, 0, time.UTC), time.Time{})
+	// wrong user
+	insertTimeEntry(primitive.NewObjectID(), time.Date(2023, time.January, 4, 9, 0, 0, 0, time.UTC), time.Date(2023, time.January, 4, 10, 0, 0, 0, time.UTC))
+
+	UnauthorizedTest(t, "GET", "/time_entries/", nil)
+	t.Run("InvalidParams", func(t *testing.T) {
+		ServeRequest(t, authToken, "GET", "/time_entries/?start_date=yesterday", nil, http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "GET", "/time_entries/?end_date=2023-1-4", nil, http.StatusBadRequest, api)
+		ServeRequest(t, authToken, "GET", "/time_entries/?format=xml", nil, http.StatusBadRequest, api)
+	})
+	t.Run("SuccessJSON", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/time_entries/", nil, http.StatusOK, api)
+		var results []TimeEntryResult
+		assert.NoError(t, json.Unmarshal(body, &results))
+		assert.Equal(t, []TimeEntryResult{
+			{
+				ID:              earlyEntryID,
+				TaskID:          taskID,
+				TaskTitle:       title,
+				StartTime:       "2023-01-02T09:00:00Z",
+				EndTime:         "2023-01-02T10:00:00Z",
+				DurationSeconds: 3600,
+			},
+			{
+				ID:              runningEntryID,
+				TaskID:          taskID,
+				TaskTitle:       title,
+				StartTime:       "2023-01-04T19:30:00Z",
+				DurationSeconds: 1800,
+				IsRunning:       true,
+			},
+		}, results)
+	})
+	t.Run("SuccessDateRange", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/time_entries/?start_date=2023-01-03&end_date=2023-01-04", nil, http.StatusOK, api)
+		var results []TimeEntryResult
+		assert.NoError(t, json.Unmarshal(body, &results))
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, runningEntryID, results[0].ID)
+
+		body = ServeRequest(t, authToken, "GET", "/time_entries/?end_date=2023-01-03", nil, http.StatusOK, api)
+		assert.NoError(t, json.Unmarshal(body, &results))
+		assert.Equal(t, 1, len(results))
+		assert.Equal(t, earlyEntryID, results[0].ID)
+	})
+	t.Run("SuccessCSV", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/time_entries/?format=csv&end_date=2023-01-03", nil, http.StatusOK, api)
+		assert.Equal(t, "id,task_id,task_title,start_time,end_time,duration_seconds\n"+
+			earlyEntryID.Hex()+","+taskID.Hex()+",\"tracked, task\",2023-01-02T09:00:00Z,2023-01-02T10:00:00Z,3600\n", string(body))
+	})
+	t.Run("TooManyTimeEntries", func(t *testing.T) {
+		timeEntries := []interface{}{}
+		for index := 0; index <= constants.MAX_TIME_ENTRIES; index++ {
+			startTime := time.Date(2022, time.March, 1, 9, 0, 0, 0, time.UTC).Add(time.Duration(index) * time.Minute)
+			timeEntries = append(timeEntries, database.TimeEntry{
+				UserID:    userID,
+				TaskID:    taskID,
+				StartTime: primitive.NewDateTimeFromTime(startTime),
+				EndTime:   primitive.NewDateTimeFromTime(startTime.Add(time.Minute)),
+			})
+		}
+		_, err := database.GetTimeEntryCollection(api.DB).InsertMany(context.Background(), timeEntries)
+		assert.NoError(t, err)
+
+		body := ServeRequest(t, authToken, "GET", "/time_entries/?format=csv&start_date=2022-03-01&end_date=2022-03-31", nil, http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"date range has more than 1000 time entries, please use a smaller range"}`, string(body))
+		ServeRequest(t, authToken, "GET", "/time_entries/?start_date=2023-01-01", nil, http.StatusOK, api)
+	})
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TimeEntryStartParams struct {
+	TaskID primitive.ObjectID `json:"task_id" binding:"required"`
+}
+
+// TimeEntryStart starts a timer on a task, a user can only have one running timer so any running timer is stopped first
+func (api *API) TimeEntryStart(c *gin.Context) {
+	var params TimeEntryStartParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	_, err = database.GetTask(api.DB, params.TaskID, userID)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "task not found"})
+		return
+	}
+
+	_, err = api.stopRunningTimeEntry(userID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		Handle500(c)
+		return
+	}
+
+	now := primitive.NewDateTimeFromTime(api.GetCurrentTime())
+	insertResult, err := database.GetTimeEntryCollection(api.DB).InsertOne(context.Background(), database.TimeEntry{
+		UserID:    userID,
+		TaskID:    params.TaskID,
+		StartTime: now,
+		CreatedAt: now,
+		IsRunning: true,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		// another request started a timer after this one stopped the running timer
+		c.JSON(400, gin.H{"detail": "a timer is already running"})
+		return
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create time entry")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{"time_entry_id": insertResult.InsertedID.(primitive.ObjectID)})
+}
+
+func (api *API) TimeEntryStop(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	timeEntry, err := api.stopRunningTimeEntry(userID)
+	if err == mongo.ErrNoDocuments {
+		c.JSON(404, gin.H{"detail": "no running timer"})
+		return
+	}
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{"time_entry_id": timeEntry.ID})
+}
+
+func (api *API) stopRunningTimeEntry(userID primitive.ObjectID) (*database.TimeEntry, error) {
+	timeEntry, err := database.GetRunningTimeEntry(api.DB, userID)
+	if err != nil {
+		return nil, err
+	}
+	timeEntry.EndTime = primitive.NewDateTimeFromTime(api.GetCurrentTime())
+	_, err = database.GetTimeEntryCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": timeEntry.ID},
+			{"user_id": userID},
+			{"end_time": bson.M{"$exists": false}},
+		}},
+		bson.M{
+			"$set":   bson.M{"end_time": timeEntry.EndTime},
+			"$unset": bson.M{"is_running": ""},
+		},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to stop time entry")
+		return nil, err
+	}
+	err = database.UpdateTaskTimeTracked(api.DB, userID, timeEntry.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	return timeEntry, nil
+}
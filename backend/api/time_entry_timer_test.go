@@ -0,0 +1,83 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestTimeEntryTimer(t *testing.T) {
+	authToken := login("test_time_entry_timer@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+	startTime := time.Date(2023, time.January, 4, 20, 0, 0, 0, time.UTC)
+	api.OverrideTime = &startTime
+
+	title := "timed task"
+	insertResult, err := database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{UserID: userID, Title: &title})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	insertResult, err = database.GetTaskCollection(api.DB).InsertOne(context.Background(), database.Task{UserID: userID, Title: &title})
+	assert.NoError(t, err)
+	otherTaskID := insertResult.InsertedID.(primitive.ObjectID)
+	start := func(taskID primitive.ObjectID, expectedCode int) primitive.ObjectID {
+		body := ServeRequest(t, authToken, "POST", "/time_entries/start/", bytes.NewBuffer([]byte(`{"task_id": "`+taskID.Hex()+`"}`)), expectedCode, api)
+		var result map[string]primitive.ObjectID
+		if expectedCode == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(body, &result))
+		}
+		return result["time_entry_id"]
+	}
+	getTimeEntry := func(timeEntryID primitive.ObjectID) database.TimeEntry {
+		var timeEntry database.TimeEntry
+		assert.NoError(t, database.GetTimeEntryCollection(api.DB).FindOne(context.Background(), bson.M{"_id": timeEntryID}).Decode(&timeEntry))
+		return timeEntry
+	}
+
+	UnauthorizedTest(t, "POST", "/time_entries/start/", nil)
+	UnauthorizedTest(t, "POST", "/time_entries/stop/", nil)
+	t.Run("StopWithoutTimer", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/time_entries/stop/", nil, http.StatusNotFound, api)
+	})
+	t.Run("StartInvalidTask", func(t *testing.T) {
+		ServeRequest(t, authToken, "POST", "/time_entries/start/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+		start(primitive.NewObjectID(), http.StatusNotFound)
+	})
+	t.Run("StartStopSuccess", func(t *testing.T) {
+		timeEntryID := start(taskID, http.StatusOK)
+		assert.Equal(t, primitive.DateTime(0), getTimeEntry(timeEntryID).EndTime)
+		assert.True(t, getTimeEntry(timeEntryID).IsRunning)
+
+		stopTime := startTime.Add(30 * time.Minute)
+		api.OverrideTime = &stopTime
+		ServeRequest(t, authToken, "POST", "/time_entries/stop/", nil, http.StatusOK, api)
+		assert.Equal(t, primitive.NewDateTimeFromTime(stopTime), getTimeEntry(timeEntryID).EndTime)
+		assert.False(t, getTimeEntry(timeEntryID).IsRunning)
+		task, err := database.GetTask(api.DB, taskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(30*time.Minute), *task.TimeTracked)
+	})
+	t.Run("StartStopsRunningTimer", func(t *testing.T) {
+		firstStartTime := startTime.Add(time.Hour)
+		api.OverrideTime = &firstStartTime
+		firstTimeEntryID := start(taskID, http.StatusOK)
+
+		secondStartTime := firstStartTime.Add(15 * time.Minute)
+		api.OverrideTime = &secondStartTime
+		secondTimeEntryID := start(otherTaskID, http.StatusOK)
+		assert.Equal(t, primitive.NewDateTimeFromTime(secondStartTime), getTimeEntry(firstTimeEntryID).EndTime)
+		assert.Equal(t, primitive.DateTime(0), getTimeEntry(secondTimeEntryID).EndTime)
+		task, err := database.GetTask(api.DB, taskID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(45*time.Minute), *task.TimeTracked)
+	})
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type UserAvailabilityResult struct {
+	Timezone     string                       `json:"timezone"`
+	WorkingHours []database.WorkingHours      `json:"working_hours"`
+	Blocks       []database.AvailabilityBlock `json:"blocks"`
+	// true until the user sets their own working hours
+	IsDefault bool `json:"is_default"`
+}
+
+type UserAvailabilityParams struct {
+	Timezone     *string                       `json:"timezone"`
+	WorkingHours *[]database.WorkingHours      `json:"working_hours"`
+	Blocks       *[]database.AvailabilityBlock `json:"blocks"`
+}
+
+func (api *API) UserAvailabilityGet(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	availability := utils.GetUserAvailability(user)
+	c.JSON(200, UserAvailabilityResult{
+		Timezone:     user.Timezone,
+		WorkingHours: availability.WorkingHours,
+		Blocks:       availability.Blocks,
+		IsDefault:    user.Availability == nil,
+	})
+}
+
+// fields which are not provided keep their current value
+func (api *API) UserAvailabilityUpdate(c *gin.Context) {
+	var params UserAvailabilityParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameters."})
+		return
+	}
+	if params.Timezone != nil {
+		_, err = time.LoadLocation(*params.Timezone)
+		if err != nil || *params.Timezone == "" {
+			c.JSON(400, gin.H{"detail": "invalid timezone."})
+			return
+		}
+	}
+
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	updateFields := bson.M{}
+	if params.WorkingHours != nil || params.Blocks != nil {
+		availability := utils.GetUserAvailability(user)
+		if params.WorkingHours != nil {
+			availability.WorkingHours = append([]database.WorkingHours{}, *params.WorkingHours...)
+		}
+		if params.Blocks != nil {
+			availability.Blocks = append([]database.AvailabilityBlock{}, *params.Blocks...)
+		}
+		err = utils.ValidateAvailability(availability)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": err.Error()})
+			return
+		}
+		updateFields["availability"] = availability
+	}
+	if params.Timezone != nil {
+		updateFields["timezone"] = *params.Timezone
+	}
+	if len(updateFields) == 0 {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameters."})
+		return
+	}
+	_, err = database.GetUserCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"_id": userID},
+		bson.M{"$set": updateFields},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update user availability")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserAvailability(t *testing.T) {
+	authToken := login("test_user_availability@generaltask.com", "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	userID := getUserIDFromAuthToken(t, api.DB, authToken)
+
+	UnauthorizedTest(t, "GET", "/availability/", nil)
+	UnauthorizedTest(t, "PATCH", "/availability/", nil)
+	t.Run("GetDefault", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "GET", "/availability/", nil, http.StatusOK, api)
+		assert.Equal(t, `{"timezone":"","working_hours":[{"weekday":1,"start_time":"09:00","end_time":"17:00"},{"weekday":2,"start_time":"09:00","end_time":"17:00"},{"weekday":3,"start_time":"09:00","end_time":"17:00"},{"weekday":4,"start_time":"09:00","end_time":"17:00"},{"weekday":5,"start_time":"09:00","end_time":"17:00"}],"blocks":[],"is_default":true}`, string(body))
+	})
+	t.Run("EmptyPayload", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", "/availability/", bytes.NewBuffer([]byte(`{}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid or missing parameters."}`, string(body))
+	})
+	t.Run("InvalidTimezone", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", "/availability/", bytes.NewBuffer([]byte(`{"timezone":"Mars/Olympus_Mons"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid timezone."}`, string(body))
+	})
+	t.Run("InvalidBlockType", func(t *testing.T) {
+		body := ServeRequest(t, authToken, "PATCH", "/availability/", bytes.NewBuffer([]byte(`{"blocks":[{"type":"gym","weekdays":[1],"start_time":"12:00","end_time":"13:00"}]}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"invalid availability block type 'gym'"}`, string(body))
+	})
+	t.Run("Success", func(t *testing.T) {
+		ServeRequest(t, authToken, "PATCH", "/availability/", bytes.NewBuffer([]byte(`{"timezone":"America/Los_Angeles","working_hours":[{"weekday":1,"start_time":"08:00","end_time":"16:00"}],"blocks":[{"type":"lunch","weekdays":[1],"start_time":"12:00","end_time":"13:00"}]}`)), http.StatusOK, api)
+
+		user, err := database.GetUser(api.DB, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, "America/Los_Angeles", user.Timezone)
+		assert.Equal(t, &database.UserAvailability{
+			WorkingHours: []database.WorkingHours{{Weekday: 1, StartTime: "08:00", EndTime: "16:00"}},
+			Blocks:       []database.AvailabilityBlock{{Type: "lunch", Weekdays: []int{1}, StartTime: "12:00", EndTime: "13:00"}},
+		}, user.Availability)
+
+		body := ServeRequest(t, authToken, "GET", "/availability/", nil, http.StatusOK, api)
+		assert.Contains(t, string(body), `"is_default":false`)
+	})
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/GeneralTask/task-manager/backend/database"
 	"github.com/GeneralTask/task-manager/backend/utils"
@@ -20,11 +21,14 @@ type UserInfo struct {
 	IsCompanyEmail      bool   `json:"is_company_email"`
 	LinearName          string `json:"linear_name,omitempty"`
 	LinearDisplayName   string `json:"linear_display_name,omitempty"`
+	Timezone            string `json:"timezone,omitempty"`
 }
 
 type UserInfoParams struct {
 	AgreedToTerms      *bool `json:"agreed_to_terms" bson:"agreed_to_terms,omitempty"`
 	OptedIntoMarketing *bool `json:"opted_into_marketing" bson:"opted_into_marketing,omitempty"`
+	// used by background jobs such as recurring task generation
+	Timezone *string `json:"timezone" bson:"timezone,omitempty"`
 }
 
 func (api *API) UserInfoGet(c *gin.Context) {
@@ -48,6 +52,7 @@ func (api *API) UserInfoGet(c *gin.Context) {
 		IsCompanyEmail:      isCompanyEmail(userObject.Email),
 		LinearName:          userObject.LinearName,
 		LinearDisplayName:   userObject.LinearDisplayName,
+		Timezone:            userObject.Timezone,
 	})
 }
 
@@ -68,6 +73,13 @@ func (api *API) UserInfoUpdate(c *gin.Context) {
 		c.JSON(400, gin.H{"detail": "invalid or missing parameters."})
 		return
 	}
+	if params.Timezone != nil {
+		_, err = time.LoadLocation(*params.Timezone)
+		if err != nil || *params.Timezone == "" {
+			c.JSON(400, gin.H{"detail": "invalid timezone."})
+			return
+		}
+	}
 
 	userID, _ := c.Get("user")
 	userCollection := database.GetUserCollection(api.DB)
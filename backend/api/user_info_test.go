@@ -144,4 +144,18 @@ func TestUserInfo(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "{\"agreed_to_terms\":true,\"opted_into_marketing\":false,\"business_mode_enabled\":false,\"name\":\"\",\"is_employee\":true,\"email\":\"userinfo2@generaltask.com\",\"is_company_email\":true}", string(body))
 	})
+	t.Run("InvalidTimezone", func(t *testing.T) {
+		api, dbCleanup := GetAPIWithDBCleanup()
+		defer dbCleanup()
+		body := ServeRequest(t, authToken, "PATCH", "/user_info/", bytes.NewBuffer([]byte(`{"timezone":"Mars/Olympus_Mons"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, "{\"detail\":\"invalid timezone.\"}", string(body))
+	})
+	t.Run("SuccessUpdateTimezone", func(t *testing.T) {
+		api, dbCleanup := GetAPIWithDBCleanup()
+		defer dbCleanup()
+		ServeRequest(t, authToken, "PATCH", "/user_info/", bytes.NewBuffer([]byte(`{"timezone":"America/Los_Angeles"}`)), http.StatusOK, api)
+
+		body := ServeRequest(t, authToken, "GET", "/user_info/", nil, http.StatusOK, api)
+		assert.Equal(t, "{\"agreed_to_terms\":true,\"opted_into_marketing\":false,\"business_mode_enabled\":false,\"name\":\"\",\"is_employee\":true,\"email\":\"userinfo2@generaltask.com\",\"is_company_email\":true,\"timezone\":\"America/Los_Angeles\"}", string(body))
+	})
 }
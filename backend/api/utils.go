@@ -147,6 +147,19 @@ func LoggingMiddleware(db *mongo.Database) func(c *gin.Context) {
 	}
 }
 
+// limits the requests for each key (e.g. the client's IP address) to a number per minute, requests are allowed if counting fails
+func RateLimitMiddleware(db *mongo.Database, name string, limit int, getKey func(c *gin.Context) string) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		count, err := database.IncrementRateLimitCount(db, name+"_"+getKey(c), time.Now().Truncate(time.Minute))
+		if err != nil {
+			return
+		}
+		if count > limit {
+			c.AbortWithStatusJSON(429, gin.H{"detail": "too many requests, please try again later"})
+		}
+	}
+}
+
 func getToken(c *gin.Context) (string, error) {
 	token := c.Request.Header.Get("Authorization")
 	//Token is 36 characters + 6 for Bearer prefix + 1 for space = 43
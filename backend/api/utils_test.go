@@ -8,8 +8,10 @@ import (
 	"testing"
 
 	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func TestCORSHeaders(t *testing.T) {
@@ -132,6 +134,28 @@ func TestBusinessMiddleware(t *testing.T) {
 	})
 }
 
+func TestRateLimitMiddleware(t *testing.T) {
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	key := primitive.NewObjectID().Hex()
+	router.GET("/limited/", RateLimitMiddleware(api.DB, "test", 2, func(c *gin.Context) string { return key }), api.Ping)
+	serveLimitedRequest := func() int {
+		request, _ := http.NewRequest("GET", "/limited/", nil)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serveLimitedRequest())
+	assert.Equal(t, http.StatusOK, serveLimitedRequest())
+	assert.Equal(t, http.StatusTooManyRequests, serveLimitedRequest())
+	// each key has its own count
+	key = primitive.NewObjectID().Hex()
+	assert.Equal(t, http.StatusOK, serveLimitedRequest())
+}
+
 func TestLoggingMiddleware(t *testing.T) {
 	authToken := login("approved@generaltask.com", "")
 	t.Run("Success", func(t *testing.T) {
@@ -0,0 +1,415 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WorkspaceTaskCreateParams struct {
+	Title         string     `json:"title" binding:"required"`
+	Body          string     `json:"body"`
+	DueDate       *time.Time `json:"due_date"`
+	TimeDuration  *int       `json:"time_duration"`
+	IDTaskSection string     `json:"id_task_section" binding:"required"`
+	AssigneeID    string     `json:"assignee_id"`
+}
+
+type WorkspaceTaskAssignParams struct {
+	// an empty string unassigns the task
+	AssigneeID string `json:"assignee_id"`
+}
+
+func (api *API) WorkspaceSectionsList(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, false)
+	if !ok {
+		return
+	}
+	var sections []database.TaskSection
+	cursor, err := database.GetTaskSectionCollection(api.DB).Find(context.Background(), bson.M{"workspace_id": workspace.ID})
+	if err == nil {
+		err = cursor.All(context.Background(), &sections)
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch workspace sections")
+		Handle500(c)
+		return
+	}
+	sectionResults := []SectionResult{}
+	for _, section := range sections {
+		sectionResults = append(sectionResults, SectionResult{
+			ID:         section.ID,
+			IDOrdering: section.IDOrdering,
+			Name:       section.Name,
+		})
+	}
+	sort.SliceStable(sectionResults, func(i, j int) bool {
+		if sectionResults[i].IDOrdering == sectionResults[j].IDOrdering {
+			return sectionResults[i].ID.Hex() < sectionResults[j].ID.Hex()
+		}
+		return sectionResults[i].IDOrdering < sectionResults[j].IDOrdering
+	})
+	c.JSON(200, sectionResults)
+}
+
+func (api *API) WorkspaceSectionAdd(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, true)
+	if !ok {
+		return
+	}
+	var params SectionCreateParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing 'name' parameter"})
+		return
+	}
+	sectionCollection := database.GetTaskSectionCollection(api.DB)
+	sectionCount, err := sectionCollection.CountDocuments(context.Background(), bson.M{"workspace_id": workspace.ID})
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	if sectionCount >= constants.MAX_WORKSPACE_SECTIONS {
+		c.JSON(400, gin.H{"detail": "too many workspace sections"})
+		return
+	}
+	insertResult, err := sectionCollection.InsertOne(context.Background(), &database.TaskSection{
+		Name:        params.Name,
+		IDOrdering:  params.IDOrdering,
+		WorkspaceID: workspace.ID,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to insert workspace section")
+		Handle500(c)
+		return
+	}
+	c.JSON(201, gin.H{"id": insertResult.InsertedID.(primitive.ObjectID).Hex()})
+}
+
+func (api *API) WorkspaceSectionModify(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, true)
+	if !ok {
+		return
+	}
+	section, ok := api.getWorkspaceSectionFromParam(c, workspace)
+	if !ok {
+		return
+	}
+	var params SectionModifyParams
+	err := c.BindJSON(&params)
+	if err != nil || (params.Name == "" && params.IDOrdering == 0) {
+		c.JSON(400, gin.H{"detail": "invalid or missing task section modify parameter"})
+		return
+	}
+	updateFields := bson.M{}
+	if params.Name != "" {
+		updateFields["name"] = params.Name
+	}
+	if params.IDOrdering != 0 {
+		updateFields["id_ordering"] = params.IDOrdering
+	}
+	_, err = database.GetTaskSectionCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"_id": section.ID},
+		bson.M{"$set": updateFields},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update workspace section")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// tasks in the section are moved back to the default section of their owners
+func (api *API) WorkspaceSectionDelete(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, true)
+	if !ok {
+		return
+	}
+	section, ok := api.getWorkspaceSectionFromParam(c, workspace)
+	if !ok {
+		return
+	}
+	_, err := database.GetTaskSectionCollection(api.DB).DeleteOne(context.Background(), bson.M{"_id": section.ID})
+	if err == nil {
+		_, err = database.GetTaskCollection(api.DB).UpdateMany(
+			context.Background(),
+			bson.M{"$and": []bson.M{
+				{"workspace_id": workspace.ID},
+				{"id_task_section": section.ID},
+			}},
+			bson.M{
+				"$set":   bson.M{"id_task_section": constants.IDTaskSectionDefault},
+				"$unset": bson.M{"workspace_id": "", "assignee_id": "", "assigned_by": "", "assignment_status": "", "assigned_at": ""},
+			},
+		)
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete workspace section")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// the tasks in the workspace's shared sections, optionally only the ones assigned to a member
+func (api *API) WorkspaceTasksList(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, false)
+	if !ok {
+		return
+	}
+	filters := []bson.M{
+		{"workspace_id": workspace.ID},
+		{"is_deleted": bson.M{"$ne": true}},
+	}
+	if assigneeIDHex := c.Query("assignee_id"); assigneeIDHex != "" {
+		assigneeID, err := primitive.ObjectIDFromHex(assigneeIDHex)
+		if err != nil {
+			c.JSON(400, gin.H{"detail": "invalid assignee_id"})
+			return
+		}
+		filters = append(filters, bson.M{"assignee_id": assigneeID}, bson.M{"assignment_status": bson.M{"$ne": constants.TaskAssignmentStatusDeclined}})
+	}
+	var tasks []database.Task
+	cursor, err := database.GetTaskCollection(api.DB).Find(
+		context.Background(),
+		bson.M{"$and": filters},
+		options.Find().SetSort(bson.D{{Key: "id_ordering", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(constants.MAX_WORKSPACE_TASKS),
+	)
+	if err == nil {
+		err = cursor.All(context.Background(), &tasks)
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch workspace tasks")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, api.taskListToTaskResultListV4(&tasks, getUserIDFromContext(c)))
+}
+
+// creates a General Task task owned by the member in one of the workspace's shared sections
+func (api *API) WorkspaceTaskCreate(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, false)
+	if !ok {
+		return
+	}
+	var params WorkspaceTaskCreateParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	sectionID, err := primitive.ObjectIDFromHex(params.IDTaskSection)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "'id_task_section' is not a valid ID"})
+		return
+	}
+	section, err := database.GetWorkspaceSection(api.DB, sectionID)
+	if err != nil || section.WorkspaceID != workspace.ID {
+		c.JSON(400, gin.H{"detail": "'id_task_section' is not a valid ID"})
+		return
+	}
+	assigneeID, err := api.getWorkspaceAssigneeID(workspace.ID, params.AssigneeID)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	var timeAllocation *int64
+	if params.TimeDuration != nil {
+		timeAllocationTemp := (time.Duration(*params.TimeDuration) * time.Second).Nanoseconds()
+		timeAllocation = &timeAllocationTemp
+	}
+	taskSourceResult, err := api.ExternalConfig.GetSourceResult(external.TASK_SOURCE_ID_GT_TASK)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to load external task source")
+		Handle500(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	taskCreationObject := external.TaskCreationObject{
+		Title:          params.Title,
+		Body:           params.Body,
+		DueDate:        params.DueDate,
+		TimeAllocation: timeAllocation,
+		IDTaskSection:  section.ID,
+		WorkspaceID:    workspace.ID,
+		AssigneeID:     assigneeID,
+	}
+	if assigneeID != primitive.NilObjectID {
+		taskCreationObject.AssignedBy = userID
+		taskCreationObject.AssignmentStatus = newTaskAssignmentStatus(userID, assigneeID)
+	}
+	taskID, err := taskSourceResult.Source.CreateNewTask(api.DB, userID, external.GeneralTaskDefaultAccountID, taskCreationObject)
+	if err != nil {
+		c.JSON(503, gin.H{"detail": "failed to create task"})
+		return
+	}
+	api.notifyWorkspaceTaskAssignment(&database.Task{ID: taskID, Title: &params.Title}, userID, assigneeID)
+	IDOrdering := constants.DefaultTaskIDOrdering
+	err = api.ReOrderTask(c, taskID, userID, &IDOrdering, nil, &database.Task{IDTaskSection: section.ID})
+	if err != nil {
+		return
+	}
+	c.JSON(200, gin.H{"task_id": taskID})
+}
+
+func (api *API) WorkspaceTaskAssign(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, false)
+	if !ok {
+		return
+	}
+	taskID, err := primitive.ObjectIDFromHex(c.Param("task_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var params WorkspaceTaskAssignParams
+	err = c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	assigneeID, err := api.getWorkspaceAssigneeID(workspace.ID, params.AssigneeID)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	update := bson.M{"$set": bson.M{
+		"assignee_id":       assigneeID,
+		"assigned_by":       userID,
+		"assignment_status": newTaskAssignmentStatus(userID, assigneeID),
+		"assigned_at":       primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	}}
+	if assigneeID == primitive.NilObjectID {
+		update = bson.M{"$unset": bson.M{"assignee_id": "", "assigned_by": "", "assignment_status": "", "assigned_at": ""}}
+	}
+	result, err := database.GetTaskCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": taskID},
+			{"workspace_id": workspace.ID},
+		}},
+		update,
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to assign workspace task")
+		Handle500(c)
+		return
+	}
+	if result.MatchedCount != 1 {
+		c.JSON(404, gin.H{"detail": "task not found.", "taskId": taskID})
+		return
+	}
+	task, err := api.getModifiableTask(taskID, userID)
+	if err == nil {
+		api.notifyWorkspaceTaskAssignment(task, userID, assigneeID)
+	}
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) notifyWorkspaceTaskAssignment(task *database.Task, assignedBy primitive.ObjectID, assigneeID primitive.ObjectID) {
+	if assigneeID == primitive.NilObjectID || assigneeID == assignedBy {
+		return
+	}
+	assigner, err := database.GetUser(api.DB, assignedBy)
+	if err != nil {
+		return
+	}
+	api.notifyTaskAssigned(task, assigneeID, assigner)
+}
+
+// owners can modify any of their tasks, workspace members the tasks in the workspace's shared sections
+func (api *API) getModifiableTask(taskID primitive.ObjectID, userID primitive.ObjectID) (*database.Task, error) {
+	task, err := database.GetTask(api.DB, taskID, userID)
+	if err == nil {
+		return task, nil
+	}
+	return database.GetWorkspaceTask(api.DB, taskID, userID)
+}
+
+// workspace members can edit the content of the tasks shared with them and move them between sections, while sharing,
+// deleting, snoozing and reassigning are left to the task's owner
+func validateWorkspaceTaskModify(modifyParams *TaskModifyParams) error {
+	ownerOnlyFields := TaskItemChangeableFields{
+		IsDeleted:    modifyParams.IsDeleted,
+		DeletedAt:    modifyParams.DeletedAt,
+		SharedAccess: modifyParams.SharedAccess,
+		SharedUntil:  modifyParams.SharedUntil,
+		Task: TaskChangeable{
+			Comments:                modifyParams.Task.Comments,
+			RecurringTaskTemplateID: modifyParams.Task.RecurringTaskTemplateID,
+		},
+	}
+	if ownerOnlyFields != (TaskItemChangeableFields{}) || modifyParams.SnoozedUntil != nil {
+		return errors.New("only the owner can share, delete or snooze this task")
+	}
+	if modifyParams.Title != nil && strings.HasPrefix(*modifyParams.Title, "<to ") {
+		return errors.New("only the owner can reassign this task")
+	}
+	return nil
+}
+
+// tasks can only be moved into the shared sections of workspaces the user has joined, and only their owner can move
+// them out of their workspace
+func (api *API) validateTaskSectionMove(task *database.Task, userID primitive.ObjectID, sectionID primitive.ObjectID) error {
+	section, err := database.GetWorkspaceSection(api.DB, sectionID)
+	if err != nil {
+		if task.UserID != userID {
+			return errors.New("workspace tasks can only be moved to the sections of their workspace")
+		}
+		return nil
+	}
+	if task.SourceID != external.TASK_SOURCE_ID_GT_TASK {
+		return errors.New("only General Task tasks can be added to a workspace")
+	}
+	_, err = database.GetWorkspaceMember(api.DB, section.WorkspaceID, userID)
+	if err != nil {
+		return errors.New("'id_task_section' is not a valid ID")
+	}
+	if task.UserID != userID && section.WorkspaceID != task.WorkspaceID {
+		return errors.New("workspace tasks can only be moved to the sections of their workspace")
+	}
+	return nil
+}
+
+// tasks can only be assigned to members who have joined the workspace
+func (api *API) getWorkspaceAssigneeID(workspaceID primitive.ObjectID, assigneeIDHex string) (primitive.ObjectID, error) {
+	if assigneeIDHex == "" {
+		return primitive.NilObjectID, nil
+	}
+	assigneeID, err := primitive.ObjectIDFromHex(assigneeIDHex)
+	if err != nil {
+		return primitive.NilObjectID, errors.New("invalid assignee_id")
+	}
+	_, err = database.GetWorkspaceMember(api.DB, workspaceID, assigneeID)
+	if err != nil {
+		return primitive.NilObjectID, errors.New("assignee is not a member of the workspace")
+	}
+	return assigneeID, nil
+}
+
+func (api *API) getWorkspaceSectionFromParam(c *gin.Context, workspace *database.Workspace) (*database.TaskSection, bool) {
+	sectionID, err := primitive.ObjectIDFromHex(c.Param("section_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	section, err := database.GetWorkspaceSection(api.DB, sectionID)
+	if err != nil || section.WorkspaceID != workspace.ID {
+		Handle404(c)
+		return nil, false
+	}
+	return section, true
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWorkspaceTasks(t *testing.T) {
+	memberEmail := createRandomGTEmail()
+	adminToken := login(createRandomGTEmail(), "Admin")
+	memberToken := login(memberEmail, "Member")
+	outsiderToken := login(createRandomGTEmail(), "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	adminUserID := getUserIDFromAuthToken(t, api.DB, adminToken)
+	memberUserID := getUserIDFromAuthToken(t, api.DB, memberToken)
+
+	var createResult map[string]string
+	assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "POST", "/workspaces/", bytes.NewBuffer([]byte(`{"name":"Engineering"}`)), http.StatusCreated, api), &createResult))
+	workspaceURL := "/workspaces/" + createResult["id"] + "/"
+	ServeRequest(t, adminToken, "POST", workspaceURL+"members/", bytes.NewBuffer([]byte(`{"email":"`+memberEmail+`"}`)), http.StatusCreated, api)
+	ServeRequest(t, memberToken, "POST", workspaceURL+"join/", nil, http.StatusOK, api)
+
+	var sectionID string
+	var taskID string
+	t.Run("Sections", func(t *testing.T) {
+		ServeRequest(t, memberToken, "POST", workspaceURL+"sections/", bytes.NewBuffer([]byte(`{"name":"Sprint"}`)), http.StatusBadRequest, api)
+		var sectionResult map[string]string
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "POST", workspaceURL+"sections/", bytes.NewBuffer([]byte(`{"name":"Sprint"}`)), http.StatusCreated, api), &sectionResult))
+		sectionID = sectionResult["id"]
+
+		var sections []SectionResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, memberToken, "GET", workspaceURL+"sections/", nil, http.StatusOK, api), &sections))
+		assert.Equal(t, 1, len(sections))
+		assert.Equal(t, "Sprint", sections[0].Name)
+		ServeRequest(t, outsiderToken, "GET", workspaceURL+"sections/", nil, http.StatusNotFound, api)
+
+		// workspace sections don't show up in the members' own section lists
+		personalSections, err := database.GetTaskSections(api.DB, memberUserID)
+		assert.NoError(t, err)
+		for _, section := range *personalSections {
+			assert.NotEqual(t, sectionID, section.ID.Hex())
+		}
+	})
+	t.Run("Create", func(t *testing.T) {
+		ServeRequest(t, memberToken, "POST", workspaceURL+"tasks/", bytes.NewBuffer([]byte(`{"title":"review","id_task_section":"`+primitive.NewObjectID().Hex()+`"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, memberToken, "POST", workspaceURL+"tasks/", bytes.NewBuffer([]byte(`{"title":"review","id_task_section":"`+sectionID+`","assignee_id":"`+primitive.NewObjectID().Hex()+`"}`)), http.StatusBadRequest, api)
+		var taskResult map[string]string
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, memberToken, "POST", workspaceURL+"tasks/", bytes.NewBuffer([]byte(`{"title":"review","id_task_section":"`+sectionID+`","assignee_id":"`+adminUserID.Hex()+`"}`)), http.StatusOK, api), &taskResult))
+		taskID = taskResult["task_id"]
+
+		var tasks []TaskResultV4
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "GET", workspaceURL+"tasks/", nil, http.StatusOK, api), &tasks))
+		assert.Equal(t, 1, len(tasks))
+		assert.Equal(t, "review", tasks[0].Title)
+		assert.Equal(t, sectionID, tasks[0].IDFolder)
+		assert.Equal(t, adminUserID.Hex(), tasks[0].AssigneeID)
+
+		// only the owner sees who the task is privately shared with
+		ServeRequest(t, memberToken, "PATCH", "/tasks/"+taskID+"/shared_with/", bytes.NewBuffer([]byte(`{"email":"someone@generaltask.com","role":"reader"}`)), http.StatusOK, api)
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "GET", workspaceURL+"tasks/", nil, http.StatusOK, api), &tasks))
+		assert.Equal(t, 0, len(tasks[0].SharedWith))
+		var taskDetail TaskResultV4
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "GET", "/tasks/detail/"+taskID+"/", nil, http.StatusOK, api), &taskDetail))
+		assert.Equal(t, 0, len(taskDetail.SharedWith))
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, memberToken, "GET", workspaceURL+"tasks/", nil, http.StatusOK, api), &tasks))
+		assert.Equal(t, 1, len(tasks[0].SharedWith))
+	})
+	t.Run("Modify", func(t *testing.T) {
+		// any member can edit the tasks in the shared sections
+		ServeRequest(t, adminToken, "PATCH", "/tasks/modify/"+taskID+"/", bytes.NewBuffer([]byte(`{"title":"review PR"}`)), http.StatusOK, api)
+		ServeRequest(t, outsiderToken, "PATCH", "/tasks/modify/"+taskID+"/", bytes.NewBuffer([]byte(`{"title":"review PR"}`)), http.StatusNotFound, api)
+		taskObjectID, _ := primitive.ObjectIDFromHex(taskID)
+		task, err := database.GetTask(api.DB, taskObjectID, memberUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, "review PR", *task.Title)
+	})
+	t.Run("ModifyOwnerOnlyFields", func(t *testing.T) {
+		for _, params := range []string{`{"is_deleted":true}`, `{"shared_access":"public"}`, `{"snoozed_until":""}`} {
+			body := ServeRequest(t, adminToken, "PATCH", "/tasks/modify/"+taskID+"/", bytes.NewBuffer([]byte(params)), http.StatusBadRequest, api)
+			assert.Equal(t, `{"detail":"only the owner can share, delete or snooze this task"}`, string(body))
+		}
+		body := ServeRequest(t, adminToken, "PATCH", "/tasks/modify/"+taskID+"/", bytes.NewBuffer([]byte(`{"title":"<to someone>review PR"}`)), http.StatusBadRequest, api)
+		assert.Equal(t, `{"detail":"only the owner can reassign this task"}`, string(body))
+
+		// the owner keeps full control of the task
+		ServeRequest(t, memberToken, "PATCH", "/tasks/modify/"+taskID+"/", bytes.NewBuffer([]byte(`{"snoozed_until":""}`)), http.StatusOK, api)
+		taskObjectID, _ := primitive.ObjectIDFromHex(taskID)
+		task, err := database.GetTask(api.DB, taskObjectID, memberUserID)
+		assert.NoError(t, err)
+		assert.False(t, task.IsDeleted != nil && *task.IsDeleted)
+		assert.Equal(t, "review PR", *task.Title)
+	})
+	t.Run("Assign", func(t *testing.T) {
+		assignURL := workspaceURL + "tasks/" + taskID + "/assignee/"
+		ServeRequest(t, adminToken, "PATCH", assignURL, bytes.NewBuffer([]byte(`{"assignee_id":"`+primitive.NewObjectID().Hex()+`"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, adminToken, "PATCH", assignURL, bytes.NewBuffer([]byte(`{"assignee_id":"`+memberUserID.Hex()+`"}`)), http.StatusOK, api)
+
+		var tasks []TaskResultV4
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "GET", workspaceURL+"tasks/?assignee_id="+adminUserID.Hex(), nil, http.StatusOK, api), &tasks))
+		assert.Equal(t, 0, len(tasks))
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "GET", workspaceURL+"tasks/?assignee_id="+memberUserID.Hex(), nil, http.StatusOK, api), &tasks))
+		assert.Equal(t, 1, len(tasks))
+
+		ServeRequest(t, adminToken, "PATCH", assignURL, bytes.NewBuffer([]byte(`{"assignee_id":""}`)), http.StatusOK, api)
+		var unassignedTasks []TaskResultV4
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "GET", workspaceURL+"tasks/", nil, http.StatusOK, api), &unassignedTasks))
+		assert.Equal(t, "", unassignedTasks[0].AssigneeID)
+	})
+	t.Run("DeleteSection", func(t *testing.T) {
+		ServeRequest(t, adminToken, "DELETE", workspaceURL+"sections/"+sectionID+"/", nil, http.StatusOK, api)
+		var tasks []TaskResultV4
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "GET", workspaceURL+"tasks/", nil, http.StatusOK, api), &tasks))
+		assert.Equal(t, 0, len(tasks))
+
+		taskObjectID, _ := primitive.ObjectIDFromHex(taskID)
+		task, err := database.GetTask(api.DB, taskObjectID, memberUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.IDTaskSectionDefault, task.IDTaskSection)
+		assert.Equal(t, primitive.NilObjectID, task.WorkspaceID)
+	})
+	t.Run("RemoveMember", func(t *testing.T) {
+		var sectionResult map[string]string
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "POST", workspaceURL+"sections/", bytes.NewBuffer([]byte(`{"name":"Backlog"}`)), http.StatusCreated, api), &sectionResult))
+		var taskResult map[string]string
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, memberToken, "POST", workspaceURL+"tasks/", bytes.NewBuffer([]byte(`{"title":"triage","id_task_section":"`+sectionResult["id"]+`"}`)), http.StatusOK, api), &taskResult))
+		ServeRequest(t, adminToken, "GET", "/tasks/detail/"+taskResult["task_id"]+"/", nil, http.StatusOK, api)
+
+		var members []WorkspaceMemberResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "GET", workspaceURL+"members/", nil, http.StatusOK, api), &members))
+		ServeRequest(t, adminToken, "DELETE", workspaceURL+"members/"+members[1].ID.Hex()+"/", nil, http.StatusOK, api)
+
+		// the remaining members lose access to the removed member's tasks
+		ServeRequest(t, adminToken, "GET", "/tasks/detail/"+taskResult["task_id"]+"/", nil, http.StatusNotFound, api)
+		ServeRequest(t, adminToken, "PATCH", "/tasks/modify/"+taskResult["task_id"]+"/", bytes.NewBuffer([]byte(`{"title":"triage bugs"}`)), http.StatusNotFound, api)
+		var tasks []TaskResultV4
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "GET", workspaceURL+"tasks/", nil, http.StatusOK, api), &tasks))
+		assert.Equal(t, 0, len(tasks))
+
+		taskObjectID, _ := primitive.ObjectIDFromHex(taskResult["task_id"])
+		task, err := database.GetTask(api.DB, taskObjectID, memberUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.IDTaskSectionDefault, task.IDTaskSection)
+		assert.Equal(t, primitive.NilObjectID, task.WorkspaceID)
+	})
+}
@@ -0,0 +1,616 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/jobs"
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WorkspaceParams struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type WorkspaceResult struct {
+	ID        primitive.ObjectID `json:"id"`
+	Name      string             `json:"name"`
+	Role      string             `json:"role"`
+	CreatedAt string             `json:"created_at"`
+}
+
+type WorkspaceMemberParams struct {
+	Email string `json:"email" binding:"required"`
+	Role  string `json:"role"`
+}
+
+type WorkspaceMemberModifyParams struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type WorkspaceMemberResult struct {
+	ID        primitive.ObjectID `json:"id"`
+	UserID    string             `json:"user_id,omitempty"`
+	Name      string             `json:"name,omitempty"`
+	Email     string             `json:"email"`
+	Role      string             `json:"role"`
+	IsPending bool               `json:"is_pending"`
+	InvitedAt string             `json:"invited_at"`
+	JoinedAt  string             `json:"joined_at,omitempty"`
+}
+
+type WorkspaceInviteResult struct {
+	WorkspaceID   primitive.ObjectID `json:"workspace_id"`
+	WorkspaceName string             `json:"workspace_name"`
+	MemberID      primitive.ObjectID `json:"member_id"`
+	Role          string             `json:"role"`
+	InvitedBy     string             `json:"invited_by,omitempty"`
+	InvitedAt     string             `json:"invited_at"`
+}
+
+// the creator of a workspace is its first admin
+func (api *API) WorkspaceCreate(c *gin.Context) {
+	var params WorkspaceParams
+	err := c.BindJSON(&params)
+	if err != nil || strings.TrimSpace(params.Name) == "" {
+		c.JSON(400, gin.H{"detail": "invalid or missing 'name' parameter"})
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to find user")
+		Handle500(c)
+		return
+	}
+	currentTime := primitive.NewDateTimeFromTime(api.GetCurrentTime())
+	insertResult, err := database.GetWorkspaceCollection(api.DB).InsertOne(context.Background(), database.Workspace{
+		Name:      params.Name,
+		CreatedBy: userID,
+		CreatedAt: currentTime,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to create workspace")
+		Handle500(c)
+		return
+	}
+	workspaceID := insertResult.InsertedID.(primitive.ObjectID)
+	_, err = database.GetWorkspaceMemberCollection(api.DB).InsertOne(context.Background(), database.WorkspaceMember{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Email:       strings.ToLower(user.Email),
+		Role:        constants.WorkspaceRoleAdmin,
+		InvitedAt:   currentTime,
+		JoinedAt:    currentTime,
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to add workspace admin")
+		Handle500(c)
+		return
+	}
+	c.JSON(201, gin.H{"id": workspaceID})
+}
+
+func (api *API) WorkspacesList(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	var members []database.WorkspaceMember
+	cursor, err := database.GetWorkspaceMemberCollection(api.DB).Find(context.Background(), bson.M{"user_id": userID})
+	if err == nil {
+		err = cursor.All(context.Background(), &members)
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch workspace members")
+		Handle500(c)
+		return
+	}
+	results := []WorkspaceResult{}
+	for _, member := range members {
+		var workspace database.Workspace
+		err = database.GetWorkspaceCollection(api.DB).FindOne(context.Background(), bson.M{"_id": member.WorkspaceID}).Decode(&workspace)
+		if err != nil {
+			continue
+		}
+		results = append(results, WorkspaceResult{
+			ID:        workspace.ID,
+			Name:      workspace.Name,
+			Role:      member.Role,
+			CreatedAt: workspace.CreatedAt.Time().UTC().Format(time.RFC3339),
+		})
+	}
+	c.JSON(200, results)
+}
+
+func (api *API) WorkspaceModify(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, true)
+	if !ok {
+		return
+	}
+	var params WorkspaceParams
+	err := c.BindJSON(&params)
+	if err != nil || strings.TrimSpace(params.Name) == "" {
+		c.JSON(400, gin.H{"detail": "invalid or missing 'name' parameter"})
+		return
+	}
+	_, err = database.GetWorkspaceCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"_id": workspace.ID},
+		bson.M{"$set": bson.M{"name": params.Name}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update workspace")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// tasks in the shared sections are moved back to the default section of their owners
+func (api *API) WorkspaceDelete(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, true)
+	if !ok {
+		return
+	}
+	_, err := database.GetTaskCollection(api.DB).UpdateMany(
+		context.Background(),
+		bson.M{"workspace_id": workspace.ID},
+		bson.M{
+			"$set":   bson.M{"id_task_section": constants.IDTaskSectionDefault},
+			"$unset": bson.M{"workspace_id": "", "assignee_id": "", "assigned_by": "", "assignment_status": "", "assigned_at": ""},
+		},
+	)
+	if err == nil {
+		_, err = database.GetTaskSectionCollection(api.DB).DeleteMany(context.Background(), bson.M{"workspace_id": workspace.ID})
+	}
+	var memberUserIDs []primitive.ObjectID
+	if err == nil {
+		memberUserIDs, err = api.getWorkspaceMemberUserIDs(workspace.ID)
+	}
+	if err == nil {
+		_, err = database.GetWorkspaceMemberCollection(api.DB).DeleteMany(context.Background(), bson.M{"workspace_id": workspace.ID})
+	}
+	if err == nil {
+		_, err = database.GetWorkspaceCollection(api.DB).DeleteOne(context.Background(), bson.M{"_id": workspace.ID})
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to delete workspace")
+		Handle500(c)
+		return
+	}
+	go api.syncWorkspaceDashboardTeams(memberUserIDs)
+	c.JSON(200, gin.H{})
+}
+
+func (api *API) WorkspaceMembersList(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, false)
+	if !ok {
+		return
+	}
+	members, err := database.GetWorkspaceMembers(api.DB, workspace.ID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	results := []WorkspaceMemberResult{}
+	for _, member := range *members {
+		result := WorkspaceMemberResult{
+			ID:        member.ID,
+			Email:     member.Email,
+			Role:      member.Role,
+			IsPending: member.UserID == primitive.NilObjectID,
+			InvitedAt: member.InvitedAt.Time().UTC().Format(time.RFC3339),
+		}
+		if !result.IsPending {
+			result.UserID = member.UserID.Hex()
+			result.JoinedAt = member.JoinedAt.Time().UTC().Format(time.RFC3339)
+			user, err := database.GetUser(api.DB, member.UserID)
+			if err == nil {
+				result.Name = user.Name
+			}
+		}
+		results = append(results, result)
+	}
+	c.JSON(200, results)
+}
+
+// invites a person to the workspace by email, they can join once they have a General Task account
+func (api *API) WorkspaceMemberInvite(c *gin.Context) {
+	workspace, inviter, ok := api.getWorkspaceForMember(c, true)
+	if !ok {
+		return
+	}
+	var params WorkspaceMemberParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	if params.Role == "" {
+		params.Role = constants.WorkspaceRoleMember
+	}
+	email, err := validateWorkspaceMemberParams(params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": err.Error()})
+		return
+	}
+	memberCollection := database.GetWorkspaceMemberCollection(api.DB)
+	memberCount, err := memberCollection.CountDocuments(context.Background(), bson.M{"workspace_id": workspace.ID})
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	if memberCount >= constants.MAX_WORKSPACE_MEMBERS {
+		c.JSON(400, gin.H{"detail": "too many workspace members"})
+		return
+	}
+	existingCount, err := memberCollection.CountDocuments(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"workspace_id": workspace.ID},
+			{"email": email},
+		}},
+	)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	if existingCount > 0 {
+		c.JSON(400, gin.H{"detail": "this person is already a member of the workspace or has been invited"})
+		return
+	}
+	insertResult, err := memberCollection.InsertOne(context.Background(), database.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		Email:       email,
+		Role:        params.Role,
+		InvitedBy:   inviter.UserID,
+		InvitedAt:   primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to invite workspace member")
+		Handle500(c)
+		return
+	}
+	api.notifyWorkspaceInvite(workspace, inviter.UserID, email)
+	c.JSON(201, gin.H{"id": insertResult.InsertedID.(primitive.ObjectID)})
+}
+
+func (api *API) WorkspaceMemberModify(c *gin.Context) {
+	workspace, _, ok := api.getWorkspaceForMember(c, true)
+	if !ok {
+		return
+	}
+	member, ok := api.getWorkspaceMemberFromParam(c, workspace)
+	if !ok {
+		return
+	}
+	var params WorkspaceMemberModifyParams
+	err := c.BindJSON(&params)
+	if err != nil {
+		c.JSON(400, gin.H{"detail": "invalid or missing parameter"})
+		return
+	}
+	if params.Role != constants.WorkspaceRoleAdmin && params.Role != constants.WorkspaceRoleMember {
+		c.JSON(400, gin.H{"detail": "invalid role, must be one of 'admin' or 'member'"})
+		return
+	}
+	if params.Role != constants.WorkspaceRoleAdmin && !api.hasOtherWorkspaceAdmin(workspace.ID, member) {
+		c.JSON(400, gin.H{"detail": "a workspace must have at least one admin"})
+		return
+	}
+	_, err = database.GetWorkspaceMemberCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"_id": member.ID},
+		bson.M{"$set": bson.M{"role": params.Role}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to update workspace member")
+		Handle500(c)
+		return
+	}
+	c.JSON(200, gin.H{})
+}
+
+// admins can remove any member, members can leave the workspace and invitees can decline their invite
+func (api *API) WorkspaceMemberDelete(c *gin.Context) {
+	workspaceID, err := primitive.ObjectIDFromHex(c.Param("workspace_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	var workspace database.Workspace
+	err = database.GetWorkspaceCollection(api.DB).FindOne(context.Background(), bson.M{"_id": workspaceID}).Decode(&workspace)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "workspace not found"})
+		return
+	}
+	member, ok := api.getWorkspaceMemberFromParam(c, &workspace)
+	if !ok {
+		return
+	}
+	userID := getUserIDFromContext(c)
+	if !api.isWorkspaceMemberSelf(member, userID) {
+		requester, err := database.GetWorkspaceMember(api.DB, workspace.ID, userID)
+		if err != nil {
+			c.JSON(404, gin.H{"detail": "workspace not found"})
+			return
+		}
+		if requester.Role != constants.WorkspaceRoleAdmin {
+			c.JSON(400, gin.H{"detail": "only workspace admins can remove other members"})
+			return
+		}
+	}
+	if !api.hasOtherWorkspaceAdmin(workspace.ID, member) {
+		c.JSON(400, gin.H{"detail": "a workspace must have at least one admin"})
+		return
+	}
+	_, err = database.GetWorkspaceMemberCollection(api.DB).DeleteOne(context.Background(), bson.M{"_id": member.ID})
+	if err == nil && member.UserID != primitive.NilObjectID {
+		_, err = database.GetTaskCollection(api.DB).UpdateMany(
+			context.Background(),
+			bson.M{"$and": []bson.M{
+				{"workspace_id": workspace.ID},
+				{"assignee_id": member.UserID},
+			}},
+			bson.M{"$unset": bson.M{"assignee_id": "", "assigned_by": "", "assignment_status": "", "assigned_at": ""}},
+		)
+		if err == nil {
+			// the member's own tasks leave the workspace with them
+			_, err = database.GetTaskCollection(api.DB).UpdateMany(
+				context.Background(),
+				bson.M{"$and": []bson.M{
+					{"workspace_id": workspace.ID},
+					{"user_id": member.UserID},
+				}},
+				bson.M{
+					"$set":   bson.M{"id_task_section": constants.IDTaskSectionDefault},
+					"$unset": bson.M{"workspace_id": "", "assignee_id": "", "assigned_by": "", "assignment_status": "", "assigned_at": ""},
+				},
+			)
+		}
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to remove workspace member")
+		Handle500(c)
+		return
+	}
+	if member.UserID != primitive.NilObjectID {
+		api.syncWorkspaceMembersDashboardTeams(workspace.ID, member.UserID)
+	}
+	c.JSON(200, gin.H{})
+}
+
+// pending invites for the user's email
+func (api *API) WorkspaceInvitesList(c *gin.Context) {
+	user, err := database.GetUser(api.DB, getUserIDFromContext(c))
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	var invites []database.WorkspaceMember
+	cursor, err := database.GetWorkspaceMemberCollection(api.DB).Find(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"email": strings.ToLower(user.Email)},
+			{"user_id": bson.M{"$exists": false}},
+		}},
+	)
+	if err == nil {
+		err = cursor.All(context.Background(), &invites)
+	}
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch workspace invites")
+		Handle500(c)
+		return
+	}
+	results := []WorkspaceInviteResult{}
+	for _, invite := range invites {
+		var workspace database.Workspace
+		err = database.GetWorkspaceCollection(api.DB).FindOne(context.Background(), bson.M{"_id": invite.WorkspaceID}).Decode(&workspace)
+		if err != nil {
+			continue
+		}
+		result := WorkspaceInviteResult{
+			WorkspaceID:   workspace.ID,
+			WorkspaceName: workspace.Name,
+			MemberID:      invite.ID,
+			Role:          invite.Role,
+			InvitedAt:     invite.InvitedAt.Time().UTC().Format(time.RFC3339),
+		}
+		inviter, err := database.GetUser(api.DB, invite.InvitedBy)
+		if err == nil {
+			result.InvitedBy = inviter.Name
+		}
+		results = append(results, result)
+	}
+	c.JSON(200, results)
+}
+
+// accepts the user's invite to the workspace
+func (api *API) WorkspaceJoin(c *gin.Context) {
+	workspaceID, err := primitive.ObjectIDFromHex(c.Param("workspace_id"))
+	if err != nil {
+		Handle404(c)
+		return
+	}
+	userID := getUserIDFromContext(c)
+	user, err := database.GetUser(api.DB, userID)
+	if err != nil {
+		Handle500(c)
+		return
+	}
+	result, err := database.GetWorkspaceMemberCollection(api.DB).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"workspace_id": workspaceID},
+			{"email": strings.ToLower(user.Email)},
+			{"user_id": bson.M{"$exists": false}},
+		}},
+		bson.M{"$set": bson.M{
+			"user_id":   userID,
+			"joined_at": primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+		}},
+	)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to join workspace")
+		Handle500(c)
+		return
+	}
+	if result.MatchedCount != 1 {
+		c.JSON(404, gin.H{"detail": "invite not found"})
+		return
+	}
+	api.syncWorkspaceMembersDashboardTeams(workspaceID)
+	c.JSON(200, gin.H{})
+}
+
+// returns the workspace if the user has joined it, and optionally is an admin
+func (api *API) getWorkspaceForMember(c *gin.Context, requireAdmin bool) (*database.Workspace, *database.WorkspaceMember, bool) {
+	workspaceID, err := primitive.ObjectIDFromHex(c.Param("workspace_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, nil, false
+	}
+	member, err := database.GetWorkspaceMember(api.DB, workspaceID, getUserIDFromContext(c))
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "workspace not found"})
+		return nil, nil, false
+	}
+	var workspace database.Workspace
+	err = database.GetWorkspaceCollection(api.DB).FindOne(context.Background(), bson.M{"_id": workspaceID}).Decode(&workspace)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "workspace not found"})
+		return nil, nil, false
+	}
+	if requireAdmin && member.Role != constants.WorkspaceRoleAdmin {
+		c.JSON(400, gin.H{"detail": "only workspace admins can make this change"})
+		return nil, nil, false
+	}
+	return &workspace, member, true
+}
+
+func (api *API) getWorkspaceMemberFromParam(c *gin.Context, workspace *database.Workspace) (*database.WorkspaceMember, bool) {
+	memberID, err := primitive.ObjectIDFromHex(c.Param("member_id"))
+	if err != nil {
+		Handle404(c)
+		return nil, false
+	}
+	var member database.WorkspaceMember
+	err = database.GetWorkspaceMemberCollection(api.DB).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": memberID},
+			{"workspace_id": workspace.ID},
+		}},
+	).Decode(&member)
+	if err != nil {
+		c.JSON(404, gin.H{"detail": "member not found"})
+		return nil, false
+	}
+	return &member, true
+}
+
+// whether the member is the user, or is a pending invite for the user's email
+func (api *API) isWorkspaceMemberSelf(member *database.WorkspaceMember, userID primitive.ObjectID) bool {
+	if member.UserID != primitive.NilObjectID {
+		return member.UserID == userID
+	}
+	user, err := database.GetUser(api.DB, userID)
+	return err == nil && strings.EqualFold(user.Email, member.Email)
+}
+
+// whether the workspace still has an admin if the member is removed or stops being an admin
+func (api *API) hasOtherWorkspaceAdmin(workspaceID primitive.ObjectID, member *database.WorkspaceMember) bool {
+	if member.Role != constants.WorkspaceRoleAdmin || member.UserID == primitive.NilObjectID {
+		return true
+	}
+	count, err := database.GetWorkspaceMemberCollection(api.DB).CountDocuments(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"workspace_id": workspaceID},
+			{"_id": bson.M{"$ne": member.ID}},
+			{"role": constants.WorkspaceRoleAdmin},
+			{"user_id": bson.M{"$exists": true}},
+		}},
+	)
+	return err == nil && count > 0
+}
+
+func validateWorkspaceMemberParams(params WorkspaceMemberParams) (string, error) {
+	address, err := mail.ParseAddress(params.Email)
+	if err != nil {
+		return "", errors.New("invalid email address")
+	}
+	if params.Role != constants.WorkspaceRoleAdmin && params.Role != constants.WorkspaceRoleMember {
+		return "", errors.New("invalid role, must be one of 'admin' or 'member'")
+	}
+	return strings.ToLower(address.Address), nil
+}
+
+// people without a General Task account see the invite once they sign up
+func (api *API) notifyWorkspaceInvite(workspace *database.Workspace, inviterID primitive.ObjectID, email string) {
+	invitedUser, err := database.GetUserByEmail(api.DB, email)
+	if err != nil {
+		return
+	}
+	inviterName := "Someone"
+	inviter, err := database.GetUser(api.DB, inviterID)
+	if err == nil && inviter.Name != "" {
+		inviterName = inviter.Name
+	}
+	err = jobs.SendNotification(api.DB, &database.Notification{
+		UserID:    invitedUser.ID,
+		Type:      constants.NotificationTypeWorkspaceInvite,
+		Title:     fmt.Sprintf("%s invited you to join the \"%s\" workspace", inviterName, workspace.Name),
+		CreatedAt: primitive.NewDateTimeFromTime(api.GetCurrentTime()),
+	})
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to send workspace invite notification")
+	}
+}
+
+func (api *API) getWorkspaceMemberUserIDs(workspaceID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var members []database.WorkspaceMember
+	cursor, err := database.GetWorkspaceMemberCollection(api.DB).Find(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"workspace_id": workspaceID},
+			{"user_id": bson.M{"$exists": true}},
+		}},
+	)
+	if err == nil {
+		err = cursor.All(context.Background(), &members)
+	}
+	if err != nil {
+		return nil, err
+	}
+	userIDs := []primitive.ObjectID{}
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	return userIDs, nil
+}
+
+// the dashboard teams of the members, and of the members who just left, are synced in the background once the
+// membership of the workspace changed
+func (api *API) syncWorkspaceMembersDashboardTeams(workspaceID primitive.ObjectID, formerMemberIDs ...primitive.ObjectID) {
+	memberUserIDs, err := api.getWorkspaceMemberUserIDs(workspaceID)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to fetch workspace members")
+		return
+	}
+	go api.syncWorkspaceDashboardTeams(append(memberUserIDs, formerMemberIDs...))
+}
+
+func (api *API) syncWorkspaceDashboardTeams(userIDs []primitive.ObjectID) {
+	err := jobs.SyncWorkspaceDashboardTeams(api.DB, userIDs)
+	if err != nil {
+		api.Logger.Error().Err(err).Msg("failed to sync workspace dashboard teams")
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateWorkspaceMemberParams(t *testing.T) {
+	email, err := validateWorkspaceMemberParams(WorkspaceMemberParams{Email: "Jane <Jane@GeneralTask.com>", Role: constants.WorkspaceRoleMember})
+	assert.NoError(t, err)
+	assert.Equal(t, "jane@generaltask.com", email)
+	_, err = validateWorkspaceMemberParams(WorkspaceMemberParams{Email: "jane", Role: constants.WorkspaceRoleMember})
+	assert.EqualError(t, err, "invalid email address")
+	_, err = validateWorkspaceMemberParams(WorkspaceMemberParams{Email: "jane@generaltask.com", Role: "owner"})
+	assert.EqualError(t, err, "invalid role, must be one of 'admin' or 'member'")
+}
+
+func TestWorkspaces(t *testing.T) {
+	adminEmail := createRandomGTEmail()
+	memberEmail := createRandomGTEmail()
+	adminToken := login(adminEmail, "Admin")
+	memberToken := login(memberEmail, "Member")
+	outsiderToken := login(createRandomGTEmail(), "")
+	api, dbCleanup := GetAPIWithDBCleanup()
+	defer dbCleanup()
+	adminUserID := getUserIDFromAuthToken(t, api.DB, adminToken)
+	memberUserID := getUserIDFromAuthToken(t, api.DB, memberToken)
+
+	UnauthorizedTest(t, "GET", "/workspaces/", nil)
+	ServeRequest(t, adminToken, "POST", "/workspaces/", bytes.NewBuffer([]byte(`{"name":""}`)), http.StatusBadRequest, api)
+	var createResult map[string]string
+	assert.NoError(t, json.Unmarshal(ServeRequest(t, adminToken, "POST", "/workspaces/", bytes.NewBuffer([]byte(`{"name":"Engineering"}`)), http.StatusCreated, api), &createResult))
+	workspaceURL := "/workspaces/" + createResult["id"] + "/"
+	getMembers := func(authToken string) []WorkspaceMemberResult {
+		var members []WorkspaceMemberResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, authToken, "GET", workspaceURL+"members/", nil, http.StatusOK, api), &members))
+		return members
+	}
+	// dashboard teams are synced in the background when the members change
+	isDashboardTeamMember := func(userID primitive.ObjectID, teamMemberUserID primitive.ObjectID) bool {
+		team, err := database.GetOrCreateDashboardTeam(api.DB, userID)
+		assert.NoError(t, err)
+		teamMembers, err := database.GetDashboardTeamMembers(api.DB, team.ID)
+		assert.NoError(t, err)
+		for _, teamMember := range *teamMembers {
+			if teamMember.UserID == teamMemberUserID {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("Invite", func(t *testing.T) {
+		ServeRequest(t, adminToken, "POST", workspaceURL+"members/", bytes.NewBuffer([]byte(`{"email":"not an email"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, adminToken, "POST", workspaceURL+"members/", bytes.NewBuffer([]byte(`{"email":"`+memberEmail+`"}`)), http.StatusCreated, api)
+		ServeRequest(t, adminToken, "POST", workspaceURL+"members/", bytes.NewBuffer([]byte(`{"email":"`+memberEmail+`"}`)), http.StatusBadRequest, api)
+		// only admins can invite, and only members can see the workspace
+		ServeRequest(t, memberToken, "POST", workspaceURL+"members/", bytes.NewBuffer([]byte(`{"email":"someone@generaltask.com"}`)), http.StatusNotFound, api)
+		ServeRequest(t, outsiderToken, "GET", workspaceURL+"members/", nil, http.StatusNotFound, api)
+
+		members := getMembers(adminToken)
+		assert.Equal(t, 2, len(members))
+		assert.Equal(t, constants.WorkspaceRoleAdmin, members[0].Role)
+		assert.False(t, members[0].IsPending)
+		assert.True(t, members[1].IsPending)
+
+		count, err := database.GetNotificationCollection(api.DB).CountDocuments(context.Background(), bson.M{"$and": []bson.M{
+			{"user_id": memberUserID},
+			{"type": constants.NotificationTypeWorkspaceInvite},
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+	t.Run("Join", func(t *testing.T) {
+		var invites []WorkspaceInviteResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, memberToken, "GET", "/workspaces/invites/", nil, http.StatusOK, api), &invites))
+		assert.Equal(t, 1, len(invites))
+		assert.Equal(t, "Engineering", invites[0].WorkspaceName)
+		assert.Equal(t, "Admin", invites[0].InvitedBy)
+
+		ServeRequest(t, outsiderToken, "POST", workspaceURL+"join/", nil, http.StatusNotFound, api)
+		ServeRequest(t, memberToken, "POST", workspaceURL+"join/", nil, http.StatusOK, api)
+		ServeRequest(t, memberToken, "POST", workspaceURL+"join/", nil, http.StatusNotFound, api)
+
+		var workspaces []WorkspaceResult
+		assert.NoError(t, json.Unmarshal(ServeRequest(t, memberToken, "GET", "/workspaces/", nil, http.StatusOK, api), &workspaces))
+		assert.Equal(t, 1, len(workspaces))
+		assert.Equal(t, constants.WorkspaceRoleMember, workspaces[0].Role)
+		members := getMembers(memberToken)
+		assert.False(t, members[1].IsPending)
+		assert.Equal(t, "Member", members[1].Name)
+
+		assert.Eventually(t, func() bool {
+			return isDashboardTeamMember(adminUserID, memberUserID) && isDashboardTeamMember(memberUserID, adminUserID)
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("Roles", func(t *testing.T) {
+		members := getMembers(adminToken)
+		adminURL := workspaceURL + "members/" + members[0].ID.Hex() + "/"
+		memberURL := workspaceURL + "members/" + members[1].ID.Hex() + "/"
+		ServeRequest(t, memberToken, "PATCH", workspaceURL, bytes.NewBuffer([]byte(`{"name":"Platform"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, adminToken, "PATCH", workspaceURL, bytes.NewBuffer([]byte(`{"name":"Platform"}`)), http.StatusOK, api)
+		// the last admin can't step down or leave
+		ServeRequest(t, adminToken, "PATCH", adminURL, bytes.NewBuffer([]byte(`{"role":"member"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, adminToken, "DELETE", adminURL, nil, http.StatusBadRequest, api)
+		ServeRequest(t, adminToken, "PATCH", memberURL, bytes.NewBuffer([]byte(`{"role":"owner"}`)), http.StatusBadRequest, api)
+		ServeRequest(t, adminToken, "PATCH", memberURL, bytes.NewBuffer([]byte(`{"role":"admin"}`)), http.StatusOK, api)
+		ServeRequest(t, adminToken, "PATCH", adminURL, bytes.NewBuffer([]byte(`{"role":"member"}`)), http.StatusOK, api)
+		// members can only remove themselves
+		ServeRequest(t, adminToken, "DELETE", memberURL, nil, http.StatusBadRequest, api)
+		ServeRequest(t, adminToken, "DELETE", adminURL, nil, http.StatusOK, api)
+		assert.Equal(t, 1, len(getMembers(memberToken)))
+		ServeRequest(t, adminToken, "GET", workspaceURL+"members/", nil, http.StatusNotFound, api)
+
+		assert.Eventually(t, func() bool {
+			return !isDashboardTeamMember(adminUserID, memberUserID) && !isDashboardTeamMember(memberUserID, adminUserID)
+		}, time.Second, 10*time.Millisecond)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		ServeRequest(t, adminToken, "DELETE", workspaceURL, nil, http.StatusNotFound, api)
+		ServeRequest(t, memberToken, "DELETE", workspaceURL, nil, http.StatusOK, api)
+		ServeRequest(t, memberToken, "GET", workspaceURL+"members/", nil, http.StatusNotFound, api)
+	})
+}
@@ -0,0 +1,19 @@
+package constants
+
+// Valid values for the type of availability blocks
+const (
+	AvailabilityBlockTypeLunch      = "lunch"
+	AvailabilityBlockTypeNoMeetings = "no_meetings"
+)
+
+// working hours used for users who have not set their own, weekdays are time.Weekday values
+const (
+	DefaultWorkingHoursStart = "09:00"
+	DefaultWorkingHoursEnd   = "17:00"
+)
+
+var DefaultWorkingWeekdays = []int{1, 2, 3, 4, 5}
+
+const WORKING_HOURS_TIME_FORMAT = "15:04"
+
+const MAX_AVAILABILITY_BLOCKS = 20
@@ -0,0 +1,9 @@
+package constants
+
+// limits for comments written in General Task
+const (
+	MAX_COMMENT_LENGTH    = 10000
+	MAX_COMMENTS_PER_ITEM = 1000
+	// at most this many people are notified when mentioned in a single comment
+	MAX_COMMENT_MENTIONS = 20
+)
@@ -2,4 +2,29 @@ package constants
 
 const DashboardGraphTypePRResponseTime = "pr_response_time_mins"
 const DashboardGraphTypeFocusTime = "focus_time_mins"
-const UTC_OFFSET = 8
+
+// computed from the user's tasks when the dashboard is loaded, rather than stored
+const DashboardGraphTypeEstimatedTime = "estimated_time_mins"
+const DashboardGraphTypeTrackedTime = "tracked_time_mins"
+
+// day boundaries for users who have not set a timezone, and for the industry data
+const DashboardDefaultTimezone = "America/Los_Angeles"
+
+const (
+	DashboardIntervalWeekday = "weekday"
+	DashboardIntervalWeek    = "week"
+	DashboardIntervalMonth   = "month"
+)
+
+const DASHBOARD_MAX_RANGE_DAYS = 2 * 366
+
+// data points stored before they were dated at midnight are dated at this hour in UTC, on their UTC day
+const DASHBOARD_LEGACY_DATA_POINT_UTC_HOUR = 8
+
+const (
+	DashboardExportFormatJSON = "json"
+	DashboardExportFormatCSV  = "csv"
+)
+
+// limits the number of GitHub profiles fetched at once when syncing the team members from a GitHub roster
+const MAX_GITHUB_ROSTER_PROFILE_CONCURRENCY = 10
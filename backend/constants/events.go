@@ -0,0 +1,8 @@
+package constants
+
+// Valid values for which instances of a recurring event are modified or deleted
+const (
+	RecurrenceScopeThis      = "this"
+	RecurrenceScopeFollowing = "following"
+	RecurrenceScopeAll       = "all"
+)
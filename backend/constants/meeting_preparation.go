@@ -0,0 +1,22 @@
+package constants
+
+// Valid values for the action of meeting preparation rules
+const (
+	MeetingPrepRuleActionInclude = "include"
+	MeetingPrepRuleActionExclude = "exclude"
+)
+
+// placeholders which are replaced in the body template of meeting prep tasks
+const (
+	MeetingPrepTemplateTitle      = "{{title}}"
+	MeetingPrepTemplateAttendees  = "{{attendees}}"
+	MeetingPrepTemplatePriorNotes = "{{prior_notes}}"
+)
+
+const MAX_MEETING_PREP_RULES = 50
+const MAX_MEETING_PREP_TEMPLATE_LENGTH = 10000
+
+// number of notes from earlier instances of a recurring meeting linked in a prep task
+const MAX_MEETING_PREP_PRIOR_NOTES = 5
+
+const MEETING_PREP_FOLLOW_UP_TITLE_PREFIX = "Follow up: "
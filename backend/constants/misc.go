@@ -4,6 +4,15 @@ const DefaultTaskIDOrdering = 1
 
 const AccessControlOwner = "owner"
 const AccessControlReader = "reader"
+const AccessControlCommenter = "commenter"
+
+// Valid values for the type of shared items
+const (
+	SharedItemTypeTask = "task"
+	SharedItemTypeNote = "note"
+)
+
+const MAX_SHARED_WITH = 100
 
 // Valid strings for shared_access field in task modify request
 const (
@@ -11,3 +20,18 @@ const (
 	StringSharedAccessDomain           = "domain"
 	StringSharedAccessMeetingAttendees = "meeting_attendees"
 )
+
+// Valid strings for operation field in task bulk modify request
+const (
+	BulkOperationComplete      = "complete"
+	BulkOperationDelete        = "delete"
+	BulkOperationMoveToSection = "move_to_section"
+	BulkOperationSetDueDate    = "set_due_date"
+	BulkOperationSetPriority   = "set_priority"
+	BulkOperationAddLabel      = "add_label"
+)
+
+const MAX_BULK_MODIFY_TASKS = 100
+
+// limits the number of concurrent external writes to a single task source during a bulk modify
+const MAX_BULK_MODIFY_CONCURRENCY_PER_SOURCE = 5
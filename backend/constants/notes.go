@@ -0,0 +1,35 @@
+package constants
+
+import "time"
+
+// notes are created for synced instances of a recurring event series once they are this close to starting
+const SERIES_NOTE_CREATE_AHEAD = 24 * time.Hour
+
+// saves within this long of the latest revision of a note are merged into it, so autosaves while typing
+// do not each create a revision
+const NOTE_REVISION_COALESCE_WINDOW = 5 * time.Minute
+
+// older revisions of a note are deleted once it has this many
+const MAX_NOTE_REVISIONS = 100
+
+// Valid types of a line in the diff between two note revisions
+const (
+	NoteDiffLineEqual  = "equal"
+	NoteDiffLineInsert = "insert"
+	NoteDiffLineDelete = "delete"
+)
+
+// Valid values for the format of note exports
+const (
+	NoteExportFormatMarkdown = "md"
+	NoteExportFormatHTML     = "html"
+)
+
+// Valid values for the target type of note links
+const (
+	NoteLinkTargetTask        = "task"
+	NoteLinkTargetPullRequest = "pull_request"
+	NoteLinkTargetNote        = "note"
+)
+
+const MAX_NOTE_LINKS = 100
@@ -0,0 +1,32 @@
+package constants
+
+// Valid channels a notification can be delivered through
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelSlack = "slack"
+	NotificationChannelInApp = "in_app"
+)
+
+// Valid notification types
+const (
+	NotificationTypeTaskReminder           = "task_reminder"
+	NotificationTypeCommentMention         = "comment_mention"
+	NotificationTypeWorkspaceInvite        = "workspace_invite"
+	NotificationTypeTaskAssigned           = "task_assigned"
+	NotificationTypeTaskAssignmentDeclined = "task_assignment_declined"
+	NotificationTypeAssignedTaskCompleted  = "assigned_task_completed"
+)
+
+const MAX_NOTIFICATIONS = 100
+
+const MAX_REMINDERS_PER_TASK = 5
+
+// reminders which were missed (e.g. the job did not run) are still sent if they are at most this old
+const REMINDER_LOOKBACK_SECONDS int = DAY
+
+// the furthest ahead of the due date a reminder rule can be set
+const MAX_REMINDER_LEAD_SECONDS int = WEEK
+
+// used for the time of day reminder rules, and for the "morning of due date" default reminder
+const REMINDER_TIME_OF_DAY_FORMAT = "15:04"
+const DEFAULT_REMINDER_TIME_OF_DAY = "09:00"
@@ -15,6 +15,8 @@ const (
 	ViewGithubName             = "Github"
 	ViewMeetingPreparationName = "Meeting Preparation"
 	ViewDueTodayName           = "Due Today"
+	ViewSnoozedName            = "Snoozed"
+	ViewDelegatedName          = "Delegated by me"
 )
 
 const (
@@ -25,6 +27,8 @@ const (
 	ViewGithub             ViewType = "github"
 	ViewMeetingPreparation ViewType = "meeting_preparation"
 	ViewDueToday           ViewType = "due_today"
+	ViewSnoozed            ViewType = "snoozed"
+	ViewDelegated          ViewType = "delegated"
 )
 
 const (
@@ -0,0 +1,16 @@
+package constants
+
+// Valid values for the recurrence rate of recurring task templates
+const (
+	RecurrenceRateDaily     int = 0
+	RecurrenceRateWeekDaily int = 1
+	RecurrenceRateWeekly    int = 2
+	RecurrenceRateMonthly   int = 3
+	RecurrenceRateAnnually  int = 4
+)
+
+// locks on templates expire in case a job dies while generating tasks
+const RECURRING_TASK_TEMPLATE_LOCK_TTL_SECONDS = 5 * 60
+
+// limits the size of the subtask tree created for each recurring task
+const MAX_RECURRING_TASK_TEMPLATE_SUBTASKS = 100
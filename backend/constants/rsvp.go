@@ -0,0 +1,11 @@
+package constants
+
+// Valid values for the response status of calendar event attendees, matching Google Calendar
+const (
+	RSVPStatusAccepted    = "accepted"
+	RSVPStatusDeclined    = "declined"
+	RSVPStatusTentative   = "tentative"
+	RSVPStatusNeedsAction = "needsAction"
+)
+
+const MAX_RSVP_COMMENT_LENGTH = 1000
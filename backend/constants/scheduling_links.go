@@ -0,0 +1,26 @@
+package constants
+
+// limits, in minutes, on the meetings which can be booked through a scheduling link
+const (
+	SCHEDULING_LINK_MIN_DURATION = 5
+	SCHEDULING_LINK_MAX_DURATION = 8 * 60
+	SCHEDULING_LINK_MAX_BUFFER   = 4 * 60
+)
+
+// number of days ahead that guests can book when a link does not set its own
+const SCHEDULING_LINK_DEFAULT_DAYS_AHEAD = 14
+const SCHEDULING_LINK_MAX_DAYS_AHEAD = 60
+
+// offered meeting times start on multiples of this many minutes
+const SCHEDULING_LINK_SLOT_INTERVAL = 15
+
+const MAX_SCHEDULING_LINKS = 50
+
+const FREE_BUSY_MAX_DAYS = 31
+
+// the public scheduling routes allow this many requests per minute from each IP address and for each link
+const SCHEDULING_LINK_IP_RATE_LIMIT = 30
+const SCHEDULING_LINK_TOKEN_RATE_LIMIT = 100
+
+// a booking which has not finished in this time no longer blocks other bookings
+const SCHEDULING_BOOKING_LOCK_SECONDS = 30
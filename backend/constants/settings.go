@@ -46,6 +46,18 @@ const (
 	LabSmartPrioritizeEnabled = "lab_smart_prioritize_enabled"
 	// Misc settings
 	HasDismissedMulticalPrompt = "has_dismissed_multical_prompt"
+	// Reminder settings
+	SettingFieldDefaultTaskReminder   = "default_task_reminder"
+	ChoiceKeyReminderNone             = "none"
+	ChoiceKeyReminderOneHourBefore    = "one_hour_before"
+	ChoiceKeyReminderOneDayBefore     = "one_day_before"
+	ChoiceKeyReminderMorningOfDueDate = "morning_of_due_date"
+	// Notification channel settings
+	SettingFieldNotificationChannelEmail = "notification_channel_email"
+	SettingFieldNotificationChannelSlack = "notification_channel_slack"
+	SettingFieldNotificationChannelInApp = "notification_channel_in_app"
+	// Meeting preparation settings
+	SettingFieldMeetingPrepCarryover = "meeting_preparation_carryover"
 )
 
 const (
@@ -0,0 +1,11 @@
+package constants
+
+// Valid statuses of an assigned task
+const (
+	TaskAssignmentStatusPending  = "pending"
+	TaskAssignmentStatusAccepted = "accepted"
+	TaskAssignmentStatusDeclined = "declined"
+)
+
+// how long completed tasks stay in the assigner's "Delegated by me" view
+const DELEGATED_TASK_COMPLETED_LOOKBACK_SECONDS int = WEEK
@@ -0,0 +1,7 @@
+package constants
+
+// number of days the scheduler looks ahead for free time when no end is given
+const TASK_SCHEDULE_DEFAULT_DAYS = 7
+const TASK_SCHEDULE_MAX_DAYS = 31
+
+const MAX_SCHEDULE_TASKS = 100
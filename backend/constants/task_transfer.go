@@ -0,0 +1,10 @@
+package constants
+
+// Valid values for the format of task imports and exports
+const (
+	TaskTransferFormatJSON    = "json"
+	TaskTransferFormatCSV     = "csv"
+	TaskTransferFormatTodoTxt = "todotxt"
+)
+
+const MAX_IMPORT_TASKS = 1000
@@ -0,0 +1,9 @@
+package constants
+
+// Valid values for the format parameter of the time entries list
+const (
+	TimeEntryExportFormatJSON = "json"
+	TimeEntryExportFormatCSV  = "csv"
+)
+
+const MAX_TIME_ENTRIES = 1000
@@ -0,0 +1,13 @@
+package constants
+
+// Valid roles of workspace members
+const (
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+)
+
+const MAX_WORKSPACE_MEMBERS = 200
+
+const MAX_WORKSPACE_SECTIONS = 50
+
+const MAX_WORKSPACE_TASKS = 1000
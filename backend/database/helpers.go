@@ -195,6 +195,52 @@ func GetNote(db *mongo.Database, itemID primitive.ObjectID, userID primitive.Obj
 	return &note, nil
 }
 
+// hard deletes the note along with its revisions and links
+func DeleteNote(db *mongo.Database, noteID primitive.ObjectID, userID primitive.ObjectID) error {
+	result, err := GetNoteCollection(db).DeleteOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": noteID},
+			{"user_id": userID},
+		}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount != 1 {
+		return mongo.ErrNoDocuments
+	}
+	_, err = GetNoteRevisionCollection(db).DeleteMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"note_id": noteID},
+			{"user_id": userID},
+		}})
+	if err != nil {
+		return err
+	}
+	_, err = GetNoteLinkCollection(db).DeleteMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"$or": []bson.M{
+				{"note_id": noteID},
+				{"target_id": noteID},
+			}},
+		}})
+	return err
+}
+
+// removes the note links to a task, pull request or note which no longer exists
+func DeleteNoteLinksToTarget(db *mongo.Database, targetID primitive.ObjectID, userID primitive.ObjectID) error {
+	_, err := GetNoteLinkCollection(db).DeleteMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"target_id": targetID},
+			{"user_id": userID},
+		}})
+	return err
+}
+
 /**
  * Get the domain of an email address
  * This only works for emails with a single @
@@ -221,16 +267,17 @@ func CheckNoteSharingAccessValid(sharedAccess *SharedAccess) bool {
 }
 
 func CheckTaskSharingAccessValid(sharedAccess SharedAccess) bool {
-	return sharedAccess == SharedAccessDomain || sharedAccess == SharedAccessPublic
+	return sharedAccess == SharedAccessDomain || sharedAccess == SharedAccessPublic || sharedAccess == SharedAccessMeetingAttendees
 }
 
 func GetSharedTask(db *mongo.Database, taskID primitive.ObjectID, userID *primitive.ObjectID) (*Task, error) {
 	logger := logging.GetSentryLogger()
+	userEmail := getSharedWithEmail(db, userID)
 	mongoResult := GetTaskCollection(db).FindOne(
 		context.Background(),
 		bson.M{"$and": []bson.M{
 			{"_id": taskID},
-			{"shared_until": bson.M{"$gte": time.Now()}},
+			getSharedFilter(userEmail),
 			{"is_deleted": bson.M{"$ne": true}},
 		}})
 	var task Task
@@ -239,13 +286,16 @@ func GetSharedTask(db *mongo.Database, taskID primitive.ObjectID, userID *primit
 		logger.Error().Err(err).Msgf("failed to get task: %+v", taskID)
 		return nil, err
 	}
+	if GetSharedWithRole(task.SharedWith, userEmail) != "" {
+		return &task, nil
+	}
 
 	// Check if the task is shared
 	if task.SharedAccess == nil {
 		return nil, errors.New("task is not shared")
 	}
 	// Check if shared access value is valid
-	if *task.SharedAccess != SharedAccessDomain && *task.SharedAccess != SharedAccessPublic {
+	if !CheckTaskSharingAccessValid(*task.SharedAccess) {
 		return nil, errors.New("invalid shared access value")
 	}
 
@@ -279,11 +329,86 @@ func GetSharedTask(db *mongo.Database, taskID primitive.ObjectID, userID *primit
 		if userDomain != taskOwnerDomain {
 			return nil, errors.New("user domain does not match task owner domain")
 		}
+	} else if *task.SharedAccess == SharedAccessMeetingAttendees {
+		if userID == nil {
+			return nil, errors.New("user is not allowed to access this task")
+		}
+		if *userID == task.UserID {
+			return &task, nil
+		}
+		user, err := GetUser(db, *userID)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to get user: %+v", userID)
+			return nil, err
+		}
+		// a task is linked to the events it was scheduled in, and to its meeting for meeting prep tasks
+		eventFilters := []bson.M{{"linked_task_id": task.ID}}
+		if task.MeetingPreparationParams != nil && task.MeetingPreparationParams.CalendarEventID != primitive.NilObjectID {
+			eventFilters = append(eventFilters, bson.M{"_id": task.MeetingPreparationParams.CalendarEventID})
+		}
+		linkedNoteEventIDs, err := getLinkedNoteEventIDs(db, task.UserID, task.ID)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to get linked notes: %+v", task.ID)
+			return nil, err
+		}
+		if len(linkedNoteEventIDs) > 0 {
+			eventFilters = append(eventFilters, bson.M{"_id": bson.M{"$in": linkedNoteEventIDs}})
+		}
+		isAttendee, err := isLinkedEventAttendee(db, task.UserID, eventFilters, user.Email)
+		if err != nil {
+			logger.Error().Err(err).Msgf("failed to get linked events: %+v", task.ID)
+			return nil, err
+		}
+		if !isAttendee {
+			return nil, errors.New("user not found in list of attendees")
+		}
 	}
 
 	return &task, nil
 }
 
+// returns the events of the owner's meeting notes which link to the task
+func getLinkedNoteEventIDs(db *mongo.Database, ownerID primitive.ObjectID, taskID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var links []NoteLink
+	err := FindWithCollection(GetNoteLinkCollection(db), ownerID, &[]bson.M{{"target_id": taskID}}, &links, nil)
+	if err != nil || len(links) == 0 {
+		return nil, err
+	}
+	noteIDs := []primitive.ObjectID{}
+	for _, link := range links {
+		noteIDs = append(noteIDs, link.NoteID)
+	}
+	var notes []Note
+	err = FindWithCollection(GetNoteCollection(db), ownerID, &[]bson.M{{"_id": bson.M{"$in": noteIDs}}}, &notes, nil)
+	if err != nil {
+		return nil, err
+	}
+	eventIDs := []primitive.ObjectID{}
+	for _, note := range notes {
+		if note.LinkedEventID != primitive.NilObjectID {
+			eventIDs = append(eventIDs, note.LinkedEventID)
+		}
+	}
+	return eventIDs, nil
+}
+
+// returns whether the email is an attendee of any of the owner's events which match the filters
+func isLinkedEventAttendee(db *mongo.Database, ownerID primitive.ObjectID, eventFilters []bson.M, email string) (bool, error) {
+	var events []CalendarEvent
+	err := FindWithCollection(GetCalendarEventCollection(db), ownerID, &[]bson.M{{"$or": eventFilters}}, &events, nil)
+	if err != nil {
+		return false, err
+	}
+	for _, event := range events {
+		for _, attendeeEmail := range event.AttendeeEmails {
+			if strings.EqualFold(attendeeEmail, email) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 func GetSharedNote(db *mongo.Database, itemID primitive.ObjectID) (*Note, error) {
 	logger := logging.GetSentryLogger()
 	mongoResult := GetNoteCollection(db).FindOne(
@@ -309,11 +434,12 @@ func GetSharedNote(db *mongo.Database, itemID primitive.ObjectID) (*Note, error)
 
 func GetSharedNoteWithAuth(db *mongo.Database, itemID primitive.ObjectID, userID primitive.ObjectID) (*Note, error) {
 	logger := logging.GetSentryLogger()
+	userEmail := getSharedWithEmail(db, &userID)
 	mongoResult := GetNoteCollection(db).FindOne(
 		context.Background(),
 		bson.M{"$and": []bson.M{
 			{"_id": itemID},
-			{"shared_until": bson.M{"$gte": time.Now()}},
+			getSharedFilter(userEmail),
 			{"is_deleted": bson.M{"$ne": true}},
 		}})
 	var note Note
@@ -322,6 +448,9 @@ func GetSharedNoteWithAuth(db *mongo.Database, itemID primitive.ObjectID, userID
 		logger.Error().Err(err).Msgf("failed to get note: %+v", itemID)
 		return nil, err
 	}
+	if GetSharedWithRole(note.SharedWith, userEmail) != "" {
+		return &note, nil
+	}
 
 	// Check if the note is shared
 	if note.SharedAccess != nil && *note.SharedAccess != SharedAccessPublic && note.UserID != userID {
@@ -358,35 +487,67 @@ func GetSharedNoteWithAuth(db *mongo.Database, itemID primitive.ObjectID, userID
 				return nil, errors.New("user domain does not match note owner domain")
 			}
 		} else if *note.SharedAccess == SharedAccessMeetingAttendees {
-			if note.LinkedEventID == primitive.NilObjectID {
+			// notes of a recurring event series are shared with the attendees of any of its instances
+			eventFilters := []bson.M{}
+			if note.LinkedEventID != primitive.NilObjectID {
+				eventFilters = append(eventFilters, bson.M{"_id": note.LinkedEventID})
+			}
+			if note.LinkedRecurringEventID != "" {
+				eventFilters = append(eventFilters, bson.M{"recurring_event_id": note.LinkedRecurringEventID})
+			}
+			if len(eventFilters) == 0 {
 				return nil, errors.New("linked event required for note's shared access type")
 			}
 
-			var event CalendarEvent
-			err := GetCalendarEventCollection(db).FindOne(
-				context.Background(),
-				bson.M{
-					"$and": []bson.M{
-						{"_id": note.LinkedEventID},
-					},
-				},
-			).Decode(&event)
+			isAttendee, err := isLinkedEventAttendee(db, note.UserID, eventFilters, user.Email)
 			if err != nil {
 				logger.Error().Err(err).Msgf("failed to get linked event: %+v", note.LinkedEventID)
 				return nil, err
 			}
-
-			for _, attendeeEmail := range event.AttendeeEmails {
-				if user.Email == attendeeEmail {
-					return &note, nil
-				}
+			if !isAttendee {
+				return nil, errors.New("user not found in list of attendees")
 			}
-			return nil, errors.New("user not found in list of attendees")
 		}
 	}
 	return &note, nil
 }
 
+// returns the role of the email in the people an item is shared with, or an empty string if it is not shared with them
+func GetSharedWithRole(sharedWith []SharedWithEntry, email string) string {
+	if email == "" {
+		return ""
+	}
+	for _, entry := range sharedWith {
+		if strings.EqualFold(entry.Email, email) {
+			return entry.Role
+		}
+	}
+	return ""
+}
+
+func getSharedWithEmail(db *mongo.Database, userID *primitive.ObjectID) string {
+	if userID == nil {
+		return ""
+	}
+	user, err := GetUser(db, *userID)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(user.Email)
+}
+
+// items shared with the user stay accessible after shared_until has passed
+func getSharedFilter(userEmail string) bson.M {
+	sharedUntilFilter := bson.M{"shared_until": bson.M{"$gte": time.Now()}}
+	if userEmail == "" {
+		return sharedUntilFilter
+	}
+	return bson.M{"$or": []bson.M{
+		sharedUntilFilter,
+		{"shared_with.email": userEmail},
+	}}
+}
+
 func GetTaskByExternalIDWithoutUser(db *mongo.Database, externalID string, logError bool) (*Task, error) {
 	taskCollection := GetTaskCollection(db)
 	mongoResult := taskCollection.FindOne(
@@ -630,6 +791,104 @@ func GetActiveTasks(db *mongo.Database, userID primitive.ObjectID) (*[]Task, err
 	return &tasks, nil
 }
 
+// GetActiveUnsnoozedTasks leaves out snoozed tasks, unlike GetActiveTasks which still returns them so that syncing can
+// detect when they are completed externally
+func GetActiveUnsnoozedTasks(db *mongo.Database, userID primitive.ObjectID, currentTime time.Time) (*[]Task, error) {
+	return GetTasks(db, userID,
+		&[]bson.M{
+			{"is_completed": false},
+			{"is_deleted": bson.M{"$ne": true}},
+			GetNotSnoozedFilter(currentTime),
+		},
+		nil,
+	)
+}
+
+func GetSnoozedTasks(db *mongo.Database, userID primitive.ObjectID, currentTime time.Time) (*[]Task, error) {
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "snoozed_until", Value: 1}, {Key: "_id", Value: 1}})
+	return GetTasks(db, userID,
+		&[]bson.M{
+			{"is_completed": false},
+			{"is_deleted": bson.M{"$ne": true}},
+			{"snoozed_until": bson.M{"$gt": primitive.NewDateTimeFromTime(currentTime)}},
+		},
+		findOptions,
+	)
+}
+
+// returns the tasks the user assigned to someone else, including the ones completed since completedAfter
+func GetDelegatedTasks(db *mongo.Database, userID primitive.ObjectID, completedAfter time.Time) (*[]Task, error) {
+	cursor, err := GetTaskCollection(db).Find(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"assigned_by": userID},
+			{"assignee_id": bson.M{"$ne": userID}},
+			{"is_deleted": bson.M{"$ne": true}},
+			{"$or": []bson.M{
+				{"is_completed": false},
+				{"completed_at": bson.M{"$gt": primitive.NewDateTimeFromTime(completedAfter)}},
+			}},
+		}},
+		options.Find().SetSort(bson.D{{Key: "assigned_at", Value: -1}, {Key: "_id", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []Task
+	err = cursor.All(context.Background(), &tasks)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks, nil
+}
+
+// matches tasks which were never snoozed, as well as tasks whose snooze has already passed
+func GetNotSnoozedFilter(currentTime time.Time) bson.M {
+	return bson.M{"snoozed_until": bson.M{"$not": bson.M{"$gt": primitive.NewDateTimeFromTime(currentTime)}}}
+}
+
+// moves tasks whose snooze has passed to the top of their section and clears the snooze
+func ResurfaceSnoozedTasks(db *mongo.Database, currentTime time.Time) (int64, error) {
+	result, err := GetTaskCollection(db).UpdateMany(
+		context.Background(),
+		bson.M{"snoozed_until": bson.M{"$lte": primitive.NewDateTimeFromTime(currentTime)}},
+		bson.M{
+			"$set":   bson.M{"id_ordering": 0},
+			"$unset": bson.M{"snoozed_until": ""},
+		},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to resurface snoozed tasks")
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// returns incomplete tasks for all users with a due date in the given range, used to evaluate reminders
+func GetIncompleteTasksDueBetween(db *mongo.Database, start time.Time, end time.Time) (*[]Task, error) {
+	cursor, err := GetTaskCollection(db).Find(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"is_completed": false},
+			{"is_deleted": bson.M{"$ne": true}},
+			{"due_date": bson.M{"$gte": primitive.NewDateTimeFromTime(start)}},
+			{"due_date": bson.M{"$lte": primitive.NewDateTimeFromTime(end)}},
+		}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch tasks with upcoming due dates")
+		return nil, err
+	}
+	var tasks []Task
+	err = cursor.All(context.Background(), &tasks)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch tasks with upcoming due dates")
+		return nil, err
+	}
+	return &tasks, nil
+}
+
 func GetNotes(db *mongo.Database, userID primitive.ObjectID) (*[]Note, error) {
 	noteCollection := GetNoteCollection(db)
 	cursor, err := noteCollection.Find(
@@ -924,6 +1183,7 @@ func GetEventsUntilEndOfDay(db *mongo.Database, userID primitive.ObjectID, curre
 		{"linked_task_id": bson.M{"$exists": false}},
 		{"linked_view_id": bson.M{"$exists": false}},
 		{"linked_pull_request_id": bson.M{"$exists": false}},
+		{"self_response_status": bson.M{"$ne": constants.RSVPStatusDeclined}},
 	})
 }
 
@@ -1003,12 +1263,25 @@ func GetUser(db *mongo.Database, userID primitive.ObjectID) (*User, error) {
 	return &userObject, nil
 }
 
-func GetGeneralTaskUserByName(db *mongo.Database, name string) (*User, error) {
-	var user User
+// returns the location of the user's stored timezone, or the fallback when the user has not set a valid timezone
+func GetUserLocation(user *User, fallback *time.Location) *time.Location {
+	if user.Timezone != "" {
+		location, err := time.LoadLocation(user.Timezone)
+		if err == nil {
+			return location
+		}
+	}
+	return fallback
+}
 
-	if err := GetUserCollection(db).FindOne(
+// emails are matched case insensitively
+func GetUserByEmail(db *mongo.Database, email string) (*User, error) {
+	var user User
+	err := GetUserCollection(db).FindOne(
 		context.Background(),
-		bson.M{"email": name + "@generaltask.com"}).Decode(&user); err != nil {
+		bson.M{"email_lowercase": strings.ToLower(email)},
+	).Decode(&user)
+	if err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -1264,6 +1537,15 @@ func UpdateUserSetting(db *mongo.Database, userID primitive.ObjectID, fieldKey s
 	return nil
 }
 
+func GetDashboardTeam(db *mongo.Database, userID primitive.ObjectID) (*DashboardTeam, error) {
+	var dashboardTeam DashboardTeam
+	err := GetDashboardTeamCollection(db).FindOne(context.Background(), bson.M{"user_id": userID}).Decode(&dashboardTeam)
+	if err != nil {
+		return nil, err
+	}
+	return &dashboardTeam, nil
+}
+
 func GetOrCreateDashboardTeam(db *mongo.Database, userID primitive.ObjectID) (*DashboardTeam, error) {
 	teamCollection := GetDashboardTeamCollection(db)
 
@@ -1306,13 +1588,124 @@ func GetDashboardTeamMembers(db *mongo.Database, teamID primitive.ObjectID) (*[]
 	return &teamMembers, nil
 }
 
-func GetDashboardDataPoints(db *mongo.Database, teamID primitive.ObjectID, now time.Time, lookbackDays int) (*[]DashboardDataPoint, error) {
+// returns the member if the user has joined the workspace
+func GetWorkspaceMember(db *mongo.Database, workspaceID primitive.ObjectID, userID primitive.ObjectID) (*WorkspaceMember, error) {
+	var member WorkspaceMember
+	err := GetWorkspaceMemberCollection(db).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"workspace_id": workspaceID},
+			{"user_id": userID},
+		}},
+	).Decode(&member)
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// returns the members of the workspace, including pending invites
+func GetWorkspaceMembers(db *mongo.Database, workspaceID primitive.ObjectID) (*[]WorkspaceMember, error) {
+	cursor, err := GetWorkspaceMemberCollection(db).Find(
+		context.Background(),
+		bson.M{"workspace_id": workspaceID},
+		options.Find().SetSort(bson.M{"invited_at": 1}),
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch workspace members")
+		return nil, err
+	}
+	var members []WorkspaceMember
+	err = cursor.All(context.Background(), &members)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to load workspace members")
+		return nil, err
+	}
+	return &members, nil
+}
+
+// returns the workspaces the user has joined
+func GetUserWorkspaceIDs(db *mongo.Database, userID primitive.ObjectID) ([]primitive.ObjectID, error) {
+	cursor, err := GetWorkspaceMemberCollection(db).Find(context.Background(), bson.M{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	var members []WorkspaceMember
+	err = cursor.All(context.Background(), &members)
+	if err != nil {
+		return nil, err
+	}
+	workspaceIDs := []primitive.ObjectID{}
+	for _, member := range members {
+		workspaceIDs = append(workspaceIDs, member.WorkspaceID)
+	}
+	return workspaceIDs, nil
+}
+
+// returns a task in the shared sections of one of the workspaces the user has joined
+func GetWorkspaceTask(db *mongo.Database, taskID primitive.ObjectID, userID primitive.ObjectID) (*Task, error) {
+	workspaceIDs, err := GetUserWorkspaceIDs(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(workspaceIDs) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	var task Task
+	err = GetTaskCollection(db).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": taskID},
+			{"workspace_id": bson.M{"$in": workspaceIDs}},
+		}},
+	).Decode(&task)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func HasSharedWorkspace(db *mongo.Database, userID primitive.ObjectID, otherUserID primitive.ObjectID) (bool, error) {
+	workspaceIDs, err := GetUserWorkspaceIDs(db, userID)
+	if err != nil || len(workspaceIDs) == 0 {
+		return false, err
+	}
+	count, err := GetWorkspaceMemberCollection(db).CountDocuments(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"workspace_id": bson.M{"$in": workspaceIDs}},
+			{"user_id": otherUserID},
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func GetWorkspaceSection(db *mongo.Database, sectionID primitive.ObjectID) (*TaskSection, error) {
+	var section TaskSection
+	err := GetTaskSectionCollection(db).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": sectionID},
+			{"workspace_id": bson.M{"$exists": true}},
+		}},
+	).Decode(&section)
+	if err != nil {
+		return nil, err
+	}
+	return &section, nil
+}
+
+// returns the team's and the industry's data points dated from start up to end
+func GetDashboardDataPoints(db *mongo.Database, teamID primitive.ObjectID, start time.Time, end time.Time) (*[]DashboardDataPoint, error) {
 	dataPointCollection := GetDashboardDataPointCollection(db)
 	cursor, err := dataPointCollection.Find(
 		context.Background(),
 		bson.M{"$and": []bson.M{
-			// this timestamp is approximate for now, will refine as needed
-			{"date": bson.M{"$gte": now.Add(-time.Hour * 24 * time.Duration(lookbackDays))}},
+			{"date": bson.M{"$gte": start}},
+			{"date": bson.M{"$lt": end}},
 			{"$or": []bson.M{
 				{"team_id": teamID},
 				{"team_id": bson.M{"$exists": false}},
@@ -1334,6 +1727,182 @@ func GetDashboardDataPoints(db *mongo.Database, teamID primitive.ObjectID, now t
 	return &dataPoints, nil
 }
 
+func GetInboxNotifications(db *mongo.Database, userID primitive.ObjectID) (*[]Notification, error) {
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+	findOptions.SetLimit(int64(constants.MAX_NOTIFICATIONS))
+	var notifications []Notification
+	err := FindWithCollection(GetNotificationCollection(db), userID, &[]bson.M{{"channels": constants.NotificationChannelInApp}}, &notifications, findOptions)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch notifications for user")
+		return nil, err
+	}
+	return &notifications, nil
+}
+
+func HasReminderBeenSent(db *mongo.Database, taskID primitive.ObjectID, reminderKey string) (bool, error) {
+	count, err := GetNotificationCollection(db).CountDocuments(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"task_id": taskID},
+			{"reminder_key": reminderKey},
+		}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to count reminder notifications")
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// returns a duplicate key error while another booking holds the user's lock, expired locks are taken over
+func AcquireSchedulingBookingLock(db *mongo.Database, userID primitive.ObjectID, currentTime time.Time, expiresAt time.Time) (primitive.ObjectID, error) {
+	lockID := primitive.NewObjectID()
+	_, err := GetSchedulingBookingLockCollection(db).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": userID},
+			{"expires_at": bson.M{"$lte": primitive.NewDateTimeFromTime(currentTime)}},
+		}},
+		bson.M{"$set": bson.M{"lock_id": lockID, "expires_at": primitive.NewDateTimeFromTime(expiresAt)}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to acquire scheduling booking lock")
+		}
+		return primitive.NilObjectID, err
+	}
+	return lockID, nil
+}
+
+func ReleaseSchedulingBookingLock(db *mongo.Database, userID primitive.ObjectID, lockID primitive.ObjectID) error {
+	_, err := GetSchedulingBookingLockCollection(db).DeleteOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": userID},
+			{"lock_id": lockID},
+		}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to release scheduling booking lock")
+	}
+	return err
+}
+
+// counts a request for the key and returns the number of requests made in the window so far
+func IncrementRateLimitCount(db *mongo.Database, key string, windowStart time.Time) (int, error) {
+	var rateLimit RateLimit
+	err := GetRateLimitCollection(db).FindOneAndUpdate(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"key": key},
+			{"window_start": primitive.NewDateTimeFromTime(windowStart)},
+		}},
+		bson.M{"$inc": bson.M{"count": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&rateLimit)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update rate limit")
+		return 0, err
+	}
+	return rateLimit.Count, nil
+}
+
+func GetRunningTimeEntry(db *mongo.Database, userID primitive.ObjectID) (*TimeEntry, error) {
+	var timeEntry TimeEntry
+	err := GetTimeEntryCollection(db).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"end_time": bson.M{"$exists": false}},
+		}},
+	).Decode(&timeEntry)
+	if err != nil {
+		if err != mongo.ErrNoDocuments {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to get running time entry")
+		}
+		return nil, err
+	}
+	return &timeEntry, nil
+}
+
+// a running time entry is treated as ending at currentTime
+func HasOverlappingTimeEntry(db *mongo.Database, userID primitive.ObjectID, start time.Time, end time.Time, currentTime time.Time) (bool, error) {
+	endTimeFilter := []bson.M{{"end_time": bson.M{"$gt": primitive.NewDateTimeFromTime(start)}}}
+	if currentTime.After(start) {
+		endTimeFilter = append(endTimeFilter, bson.M{"end_time": bson.M{"$exists": false}})
+	}
+	count, err := GetTimeEntryCollection(db).CountDocuments(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"start_time": bson.M{"$lt": primitive.NewDateTimeFromTime(end)}},
+			{"$or": endTimeFilter},
+		}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to count overlapping time entries")
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// returns time entries which started in the range, a nil bound leaves that side of the range open
+func GetTimeEntries(db *mongo.Database, userID primitive.ObjectID, start *time.Time, end *time.Time) (*[]TimeEntry, error) {
+	filters := []bson.M{}
+	if start != nil {
+		filters = append(filters, bson.M{"start_time": bson.M{"$gte": primitive.NewDateTimeFromTime(*start)}})
+	}
+	if end != nil {
+		filters = append(filters, bson.M{"start_time": bson.M{"$lt": primitive.NewDateTimeFromTime(*end)}})
+	}
+	findOptions := options.Find()
+	findOptions.SetSort(bson.D{{Key: "start_time", Value: 1}})
+	// one more than the maximum so callers can tell when the range has too many entries
+	findOptions.SetLimit(int64(constants.MAX_TIME_ENTRIES + 1))
+	var timeEntries []TimeEntry
+	err := FindWithCollection(GetTimeEntryCollection(db), userID, &filters, &timeEntries, findOptions)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch time entries for user")
+		return nil, err
+	}
+	return &timeEntries, nil
+}
+
+// recomputes the task's total tracked time from its stopped time entries
+func UpdateTaskTimeTracked(db *mongo.Database, userID primitive.ObjectID, taskID primitive.ObjectID) error {
+	var timeEntries []TimeEntry
+	err := FindWithCollection(
+		GetTimeEntryCollection(db),
+		userID,
+		&[]bson.M{{"task_id": taskID}, {"end_time": bson.M{"$exists": true}}},
+		&timeEntries,
+		nil,
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to fetch time entries for task")
+		return err
+	}
+	timeTracked := int64(0)
+	for _, timeEntry := range timeEntries {
+		timeTracked += timeEntry.EndTime.Time().Sub(timeEntry.StartTime.Time()).Nanoseconds()
+	}
+	_, err = GetTaskCollection(db).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": taskID},
+			{"user_id": userID},
+		}},
+		bson.M{"$set": bson.M{"time_tracked": timeTracked}},
+	)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to update task time tracked")
+		return err
+	}
+	return nil
+}
+
 func GetServerRequestCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("server_requests")
 }
@@ -1434,10 +2003,50 @@ func GetDashboardTeamCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("dashboard_teams")
 }
 
+func GetNotificationCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("notifications")
+}
+
+func GetTimeEntryCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("time_entries")
+}
+
 func GetDashboardTeamMemberCollection(db *mongo.Database) *mongo.Collection {
 	return db.Collection("dashboard_team_members")
 }
 
+func GetSchedulingLinkCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("scheduling_links")
+}
+
+func GetSchedulingBookingLockCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("scheduling_booking_locks")
+}
+
+func GetRateLimitCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("rate_limits")
+}
+
+func GetNoteRevisionCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("note_revisions")
+}
+
+func GetNoteLinkCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("note_links")
+}
+
+func GetWorkspaceCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("workspaces")
+}
+
+func GetWorkspaceMemberCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("workspace_members")
+}
+
+func GetSharedItemViewCollection(db *mongo.Database) *mongo.Collection {
+	return db.Collection("shared_item_views")
+}
+
 func HasUserGrantedMultiCalendarScope(scopes []string) bool {
 	return slices.Contains(scopes, "https://www.googleapis.com/auth/calendar")
 }
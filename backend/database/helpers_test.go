@@ -143,6 +143,81 @@ func TestGetDeletedTasks(t *testing.T) {
 	})
 }
 
+func TestSnoozedTasks(t *testing.T) {
+	db, dbCleanup, err := GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	currentTime := time.Date(2023, time.January, 4, 20, 0, 0, 0, time.UTC)
+	notCompleted := false
+	createTask := func(IDExternal string, snoozedUntil primitive.DateTime) *Task {
+		task, err := GetOrCreateTask(
+			db,
+			userID,
+			IDExternal,
+			"foobar_source",
+			&Task{
+				IDExternal:   IDExternal,
+				IDOrdering:   3,
+				SourceID:     "foobar_source",
+				UserID:       userID,
+				IsCompleted:  &notCompleted,
+				SnoozedUntil: snoozedUntil,
+			},
+		)
+		assert.NoError(t, err)
+		return task
+	}
+	notSnoozedTask := createTask("snooze1", 0)
+	expiredSnoozeTask := createTask("snooze2", primitive.NewDateTimeFromTime(currentTime.Add(-time.Hour)))
+	laterSnoozeTask := createTask("snooze3", primitive.NewDateTimeFromTime(currentTime.Add(2*time.Hour)))
+	soonerSnoozeTask := createTask("snooze4", primitive.NewDateTimeFromTime(currentTime.Add(time.Hour)))
+
+	t.Run("GetActiveUnsnoozedTasks", func(t *testing.T) {
+		tasks, err := GetActiveUnsnoozedTasks(db, userID, currentTime)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(*tasks))
+		assert.Equal(t, notSnoozedTask.ID, (*tasks)[0].ID)
+		assert.Equal(t, expiredSnoozeTask.ID, (*tasks)[1].ID)
+	})
+	t.Run("GetActiveTasksIncludesSnoozed", func(t *testing.T) {
+		tasks, err := GetActiveTasks(db, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(*tasks))
+	})
+	t.Run("GetSnoozedTasks", func(t *testing.T) {
+		tasks, err := GetSnoozedTasks(db, userID, currentTime)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(*tasks))
+		assert.Equal(t, soonerSnoozeTask.ID, (*tasks)[0].ID)
+		assert.Equal(t, laterSnoozeTask.ID, (*tasks)[1].ID)
+	})
+	t.Run("SnoozeSurvivesSync", func(t *testing.T) {
+		title := "updated from source"
+		task, err := UpdateOrCreateTask(db, userID, laterSnoozeTask.IDExternal, laterSnoozeTask.SourceID, nil, Task{Title: &title}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, laterSnoozeTask.SnoozedUntil, task.SnoozedUntil)
+	})
+	t.Run("ResurfaceSnoozedTasks", func(t *testing.T) {
+		_, err := ResurfaceSnoozedTasks(db, currentTime)
+		assert.NoError(t, err)
+
+		task, err := GetTask(db, expiredSnoozeTask.ID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, primitive.DateTime(0), task.SnoozedUntil)
+		assert.Equal(t, 0, task.IDOrdering)
+
+		task, err = GetTask(db, soonerSnoozeTask.ID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, soonerSnoozeTask.SnoozedUntil, task.SnoozedUntil)
+		assert.Equal(t, 3, task.IDOrdering)
+
+		task, err = GetTask(db, notSnoozedTask.ID, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, task.IDOrdering)
+	})
+}
+
 func TestGetMeetingPreparationTasks(t *testing.T) {
 	db, dbCleanup, err := GetDBConnection()
 	assert.NoError(t, err)
@@ -218,6 +293,15 @@ func TestGetMeetingPreparationTasks(t *testing.T) {
 	})
 }
 
+func TestGetUserLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	assert.Equal(t, newYork, GetUserLocation(&User{Timezone: "America/New_York"}, time.UTC))
+	assert.Equal(t, time.UTC, GetUserLocation(&User{}, time.UTC))
+	assert.Equal(t, time.UTC, GetUserLocation(&User{Timezone: "Mars/Olympus_Mons"}, time.UTC))
+	assert.Nil(t, GetUserLocation(&User{}, nil))
+}
+
 func TestGetEmailDomain(t *testing.T) {
 	t.Run("EmptyString", func(t *testing.T) {
 		domain, err := GetEmailDomain("")
@@ -278,6 +362,10 @@ func TestCheckTaskSharingAccessValid(t *testing.T) {
 		result := CheckTaskSharingAccessValid(1)
 		assert.True(t, result)
 	})
+	t.Run("SuccessMeetingAttendees", func(t *testing.T) {
+		result := CheckTaskSharingAccessValid(2)
+		assert.True(t, result)
+	})
 }
 
 func TestGetSharedTask(t *testing.T) {
@@ -463,6 +551,145 @@ func TestGetSharedTask(t *testing.T) {
 		assert.Equal(t, "invalid email address", err.Error())
 		assert.Nil(t, task)
 	})
+	t.Run("MeetingAttendees", func(t *testing.T) {
+		attendee := SharedAccessMeetingAttendees
+		eventCollection := GetCalendarEventCollection(db)
+		eventResult, err := eventCollection.InsertOne(context.Background(), &CalendarEvent{
+			UserID:         taskOwnerID,
+			AttendeeEmails: []string{"taskOwner@generaltask.com", "DifferentUserDifferentDomain@lamecompany.com"},
+		})
+		assert.NoError(t, err)
+		eventID := eventResult.InsertedID.(primitive.ObjectID)
+		result, err := taskCollection.InsertOne(context.Background(), &Task{
+			UserID:                   taskOwnerID,
+			SharedUntil:              primitive.NewDateTimeFromTime(timeTomorrow),
+			SharedAccess:             &attendee,
+			IsMeetingPreparationTask: true,
+			MeetingPreparationParams: &MeetingPreparationParams{CalendarEventID: eventID},
+		})
+		assert.NoError(t, err)
+		taskID := result.InsertedID.(primitive.ObjectID)
+
+		// attendees are matched case-insensitively, regardless of domain
+		task, err := GetSharedTask(db, taskID, &userDifferentDomainID)
+		assert.NoError(t, err)
+		assert.Equal(t, taskID, task.ID)
+
+		task, err = GetSharedTask(db, taskID, &userSameDomainID)
+		assert.EqualError(t, err, "user not found in list of attendees")
+		assert.Nil(t, task)
+
+		task, err = GetSharedTask(db, taskID, nil)
+		assert.EqualError(t, err, "user is not allowed to access this task")
+		assert.Nil(t, task)
+
+		task, err = GetSharedTask(db, taskID, &taskOwnerID)
+		assert.NoError(t, err)
+		assert.Equal(t, taskID, task.ID)
+
+		// access follows the attendees of the event as it changes
+		_, err = eventCollection.UpdateOne(context.Background(), bson.M{"_id": eventID}, bson.M{"$set": bson.M{"attendee_emails": []string{"differentUserSameDomain@generaltask.com"}}})
+		assert.NoError(t, err)
+		task, err = GetSharedTask(db, taskID, &userDifferentDomainID)
+		assert.EqualError(t, err, "user not found in list of attendees")
+		assert.Nil(t, task)
+		task, err = GetSharedTask(db, taskID, &userSameDomainID)
+		assert.NoError(t, err)
+		assert.Equal(t, taskID, task.ID)
+	})
+	t.Run("MeetingAttendeesScheduledTask", func(t *testing.T) {
+		attendee := SharedAccessMeetingAttendees
+		result, err := taskCollection.InsertOne(context.Background(), &Task{
+			UserID:       taskOwnerID,
+			SharedUntil:  primitive.NewDateTimeFromTime(timeTomorrow),
+			SharedAccess: &attendee,
+		})
+		assert.NoError(t, err)
+		taskID := result.InsertedID.(primitive.ObjectID)
+		_, err = GetCalendarEventCollection(db).InsertOne(context.Background(), &CalendarEvent{
+			UserID:         taskOwnerID,
+			LinkedTaskID:   taskID,
+			AttendeeEmails: []string{"differentUserSameDomain@generaltask.com"},
+		})
+		assert.NoError(t, err)
+
+		task, err := GetSharedTask(db, taskID, &userSameDomainID)
+		assert.NoError(t, err)
+		assert.Equal(t, taskID, task.ID)
+	})
+	t.Run("MeetingAttendeesLinkedNote", func(t *testing.T) {
+		attendee := SharedAccessMeetingAttendees
+		result, err := taskCollection.InsertOne(context.Background(), &Task{
+			UserID:       taskOwnerID,
+			SharedUntil:  primitive.NewDateTimeFromTime(timeTomorrow),
+			SharedAccess: &attendee,
+		})
+		assert.NoError(t, err)
+		taskID := result.InsertedID.(primitive.ObjectID)
+		eventResult, err := GetCalendarEventCollection(db).InsertOne(context.Background(), &CalendarEvent{
+			UserID:         taskOwnerID,
+			AttendeeEmails: []string{"differentUserSameDomain@generaltask.com"},
+		})
+		assert.NoError(t, err)
+		noteResult, err := GetNoteCollection(db).InsertOne(context.Background(), &Note{
+			UserID:        taskOwnerID,
+			LinkedEventID: eventResult.InsertedID.(primitive.ObjectID),
+		})
+		assert.NoError(t, err)
+
+		_, err = GetSharedTask(db, taskID, &userSameDomainID)
+		assert.EqualError(t, err, "user not found in list of attendees")
+
+		// attendees of a meeting whose notes link to the task can access it
+		_, err = GetNoteLinkCollection(db).InsertOne(context.Background(), &NoteLink{
+			UserID:     taskOwnerID,
+			NoteID:     noteResult.InsertedID.(primitive.ObjectID),
+			TargetID:   taskID,
+			TargetType: "task",
+		})
+		assert.NoError(t, err)
+		task, err := GetSharedTask(db, taskID, &userSameDomainID)
+		assert.NoError(t, err)
+		assert.Equal(t, taskID, task.ID)
+	})
+	t.Run("MeetingAttendeesShareTimeExpired", func(t *testing.T) {
+		attendee := SharedAccessMeetingAttendees
+		eventResult, err := GetCalendarEventCollection(db).InsertOne(context.Background(), &CalendarEvent{
+			UserID:         taskOwnerID,
+			AttendeeEmails: []string{"differentUserSameDomain@generaltask.com"},
+		})
+		assert.NoError(t, err)
+		result, err := taskCollection.InsertOne(context.Background(), &Task{
+			UserID:                   taskOwnerID,
+			SharedUntil:              primitive.NewDateTimeFromTime(time.Now().AddDate(0, 0, -1)),
+			SharedAccess:             &attendee,
+			MeetingPreparationParams: &MeetingPreparationParams{CalendarEventID: eventResult.InsertedID.(primitive.ObjectID)},
+		})
+		assert.NoError(t, err)
+
+		task, err := GetSharedTask(db, result.InsertedID.(primitive.ObjectID), &userSameDomainID)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+		assert.Nil(t, task)
+	})
+	t.Run("SharedWithUser", func(t *testing.T) {
+		result, err := taskCollection.InsertOne(context.Background(), &Task{
+			UserID:       taskOwnerID,
+			SharedUntil:  primitive.NewDateTimeFromTime(timeTomorrow),
+			SharedAccess: &domain,
+			SharedWith:   []SharedWithEntry{{Email: "differentuserdifferentdomain@lamecompany.com", Role: constants.AccessControlReader}},
+		})
+		assert.NoError(t, err)
+		taskID := result.InsertedID.(primitive.ObjectID)
+
+		task, err := GetSharedTask(db, taskID, &userDifferentDomainID)
+		assert.NoError(t, err)
+		assert.Equal(t, taskID, task.ID)
+
+		// signed out users are not on the list
+		task, err = GetSharedTask(db, taskID, nil)
+		assert.EqualError(t, err, "user is not allowed to access this task")
+		assert.Nil(t, task)
+	})
 }
 
 func TestGetNotes(t *testing.T) {
@@ -813,6 +1040,107 @@ func TestGetSharedNoteWithAuth(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, noteID, note.ID)
 	})
+	t.Run("AttendeeRemoved", func(t *testing.T) {
+		eventCollection := GetCalendarEventCollection(db)
+		event, err := eventCollection.InsertOne(context.Background(), &CalendarEvent{
+			UserID:         noteOwnerID,
+			AttendeeEmails: []string{"differentUserSameDomain@generaltask.com"},
+		})
+		assert.NoError(t, err)
+		result, err := noteCollection.InsertOne(context.Background(), &Note{
+			UserID:        noteOwnerID,
+			SharedUntil:   primitive.NewDateTimeFromTime(timeTomorrow),
+			SharedAccess:  &attendee,
+			LinkedEventID: event.InsertedID.(primitive.ObjectID),
+		})
+		assert.NoError(t, err)
+		noteID := result.InsertedID.(primitive.ObjectID)
+
+		_, err = eventCollection.UpdateOne(context.Background(), bson.M{"_id": event.InsertedID}, bson.M{"$set": bson.M{"attendee_emails": []string{"otherDifferentUserSameDomain@generaltask.com"}}})
+		assert.NoError(t, err)
+		note, err := GetSharedNoteWithAuth(db, noteID, userSameDomainID)
+		assert.EqualError(t, err, "user not found in list of attendees")
+		assert.Nil(t, note)
+		note, err = GetSharedNoteWithAuth(db, noteID, otherUserSameDomainID)
+		assert.NoError(t, err)
+		assert.Equal(t, noteID, note.ID)
+	})
+	t.Run("SuccessAttendeesRecurringEvent", func(t *testing.T) {
+		_, err := GetCalendarEventCollection(db).InsertOne(context.Background(), &CalendarEvent{
+			UserID:           noteOwnerID,
+			RecurringEventID: "shared_series",
+			AttendeeEmails:   []string{"differentUserDifferentDomain@lamecompany.com"},
+		})
+		assert.NoError(t, err)
+		result, err := noteCollection.InsertOne(context.Background(), &Note{
+			UserID:                 noteOwnerID,
+			SharedUntil:            primitive.NewDateTimeFromTime(timeTomorrow),
+			SharedAccess:           &attendee,
+			LinkedRecurringEventID: "shared_series",
+		})
+		assert.NoError(t, err)
+		noteID := result.InsertedID.(primitive.ObjectID)
+
+		note, err := GetSharedNoteWithAuth(db, noteID, userDifferentDomainID)
+		assert.NoError(t, err)
+		assert.Equal(t, noteID, note.ID)
+	})
+	t.Run("AttendeesShareTimeExpired", func(t *testing.T) {
+		event, err := GetCalendarEventCollection(db).InsertOne(context.Background(), &CalendarEvent{
+			UserID:         noteOwnerID,
+			AttendeeEmails: []string{"differentUserSameDomain@generaltask.com"},
+		})
+		assert.NoError(t, err)
+		result, err := noteCollection.InsertOne(context.Background(), &Note{
+			UserID:        noteOwnerID,
+			SharedUntil:   primitive.NewDateTimeFromTime(time.Now().AddDate(0, 0, -1)),
+			SharedAccess:  &attendee,
+			LinkedEventID: event.InsertedID.(primitive.ObjectID),
+		})
+		assert.NoError(t, err)
+
+		note, err := GetSharedNoteWithAuth(db, result.InsertedID.(primitive.ObjectID), userSameDomainID)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+		assert.Nil(t, note)
+	})
+	t.Run("AttendeesWithoutLinkedEvent", func(t *testing.T) {
+		result, err := noteCollection.InsertOne(context.Background(), &Note{
+			UserID:       noteOwnerID,
+			SharedUntil:  primitive.NewDateTimeFromTime(timeTomorrow),
+			SharedAccess: &attendee,
+		})
+		assert.NoError(t, err)
+
+		note, err := GetSharedNoteWithAuth(db, result.InsertedID.(primitive.ObjectID), userSameDomainID)
+		assert.EqualError(t, err, "linked event required for note's shared access type")
+		assert.Nil(t, note)
+	})
+	t.Run("SharedWithUser", func(t *testing.T) {
+		result, err := noteCollection.InsertOne(context.Background(), &Note{
+			UserID:       noteOwnerID,
+			SharedUntil:  primitive.NewDateTimeFromTime(time.Now().AddDate(0, 0, -1)),
+			SharedAccess: &domain,
+			SharedWith:   []SharedWithEntry{{Email: "differentuserdifferentdomain@lamecompany.com", Role: constants.AccessControlCommenter}},
+		})
+		assert.NoError(t, err)
+		noteID := result.InsertedID.(primitive.ObjectID)
+
+		// accessible after shared_until has passed and outside of the owner's domain
+		note, err := GetSharedNoteWithAuth(db, noteID, userDifferentDomainID)
+		assert.NoError(t, err)
+		assert.Equal(t, noteID, note.ID)
+
+		// other users still need shared_until to be in the future
+		note, err = GetSharedNoteWithAuth(db, noteID, userSameDomainID)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+		assert.Nil(t, note)
+
+		_, err = noteCollection.UpdateOne(context.Background(), bson.M{"_id": noteID}, bson.M{"$set": bson.M{"shared_with": []SharedWithEntry{}}})
+		assert.NoError(t, err)
+		note, err = GetSharedNoteWithAuth(db, noteID, userDifferentDomainID)
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+		assert.Nil(t, note)
+	})
 }
 
 func TestGetPullRequests(t *testing.T) {
@@ -1424,37 +1752,6 @@ func TestGetUser(t *testing.T) {
 	})
 }
 
-func TestGetGeneralTaskUserByName(t *testing.T) {
-	db, dbCleanup, err := GetDBConnection()
-	assert.NoError(t, err)
-	defer dbCleanup()
-	userID := primitive.NewObjectID()
-	googleID := "example@generaltask.com"
-	name := "Tony the Tiger"
-
-	_, err = GetUserCollection(db).InsertOne(
-		context.Background(),
-		&User{
-			ID:       userID,
-			GoogleID: googleID,
-			Name:     name,
-			Email:    googleID,
-		},
-	)
-	assert.NoError(t, err)
-
-	t.Run("WrongUserID", func(t *testing.T) {
-		_, err := GetGeneralTaskUserByName(db, "julian")
-		assert.Equal(t, mongo.ErrNoDocuments, err)
-	})
-	t.Run("Success", func(t *testing.T) {
-		user, err := GetGeneralTaskUserByName(db, "example")
-		assert.NoError(t, err)
-		assert.Equal(t, googleID, user.Email)
-		assert.Equal(t, name, user.Name)
-	})
-}
-
 func TestGetStateToken(t *testing.T) {
 	db, dbCleanup, err := GetDBConnection()
 	assert.NoError(t, err)
@@ -1788,3 +2085,115 @@ func TestGetCalendarEventByExternalId(t *testing.T) {
 		assert.Equal(t, event, respEvent.ID)
 	})
 }
+
+func TestNotifications(t *testing.T) {
+	db, dbCleanup, err := GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	taskID := primitive.NewObjectID()
+	notificationCollection := GetNotificationCollection(db)
+	insertNotification := func(userID primitive.ObjectID, reminderKey string, channels []string, createdAt time.Time) primitive.ObjectID {
+		insertResult, err := notificationCollection.InsertOne(context.Background(), Notification{
+			UserID:      userID,
+			TaskID:      taskID,
+			Type:        constants.NotificationTypeTaskReminder,
+			ReminderKey: reminderKey,
+			Channels:    channels,
+			CreatedAt:   primitive.NewDateTimeFromTime(createdAt),
+		})
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	currentTime := time.Now()
+	olderID := insertNotification(userID, "key1", []string{constants.NotificationChannelInApp}, currentTime.Add(-time.Hour))
+	newerID := insertNotification(userID, "key2", []string{constants.NotificationChannelEmail, constants.NotificationChannelInApp}, currentTime)
+	insertNotification(userID, "key3", []string{constants.NotificationChannelEmail}, currentTime)
+	insertNotification(primitive.NewObjectID(), "key4", []string{constants.NotificationChannelInApp}, currentTime)
+
+	t.Run("GetInboxNotifications", func(t *testing.T) {
+		notifications, err := GetInboxNotifications(db, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(*notifications))
+		assert.Equal(t, newerID, (*notifications)[0].ID)
+		assert.Equal(t, olderID, (*notifications)[1].ID)
+	})
+	t.Run("HasReminderBeenSent", func(t *testing.T) {
+		sent, err := HasReminderBeenSent(db, taskID, "key3")
+		assert.NoError(t, err)
+		assert.True(t, sent)
+		sent, err = HasReminderBeenSent(db, taskID, "other_key")
+		assert.NoError(t, err)
+		assert.False(t, sent)
+		sent, err = HasReminderBeenSent(db, primitive.NewObjectID(), "key3")
+		assert.NoError(t, err)
+		assert.False(t, sent)
+	})
+}
+
+func TestTimeEntries(t *testing.T) {
+	db, dbCleanup, err := GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	currentTime := time.Date(2023, time.January, 4, 20, 0, 0, 0, time.UTC)
+	insertResult, err := GetTaskCollection(db).InsertOne(context.Background(), Task{UserID: userID})
+	assert.NoError(t, err)
+	taskID := insertResult.InsertedID.(primitive.ObjectID)
+	timeEntryCollection := GetTimeEntryCollection(db)
+	_, err = timeEntryCollection.InsertOne(context.Background(), TimeEntry{
+		UserID:    userID,
+		TaskID:    taskID,
+		StartTime: primitive.NewDateTimeFromTime(currentTime.Add(-3 * time.Hour)),
+		EndTime:   primitive.NewDateTimeFromTime(currentTime.Add(-2 * time.Hour)),
+	})
+	assert.NoError(t, err)
+	_, err = timeEntryCollection.InsertOne(context.Background(), TimeEntry{
+		UserID:    userID,
+		TaskID:    taskID,
+		StartTime: primitive.NewDateTimeFromTime(currentTime.Add(-30 * time.Minute)),
+	})
+	assert.NoError(t, err)
+
+	t.Run("HasOverlappingTimeEntry", func(t *testing.T) {
+		overlaps, err := HasOverlappingTimeEntry(db, userID, currentTime.Add(-150*time.Minute), currentTime.Add(-100*time.Minute), currentTime)
+		assert.NoError(t, err)
+		assert.True(t, overlaps)
+		// touching an entry's boundary is not an overlap
+		overlaps, err = HasOverlappingTimeEntry(db, userID, currentTime.Add(-2*time.Hour), currentTime.Add(-time.Hour), currentTime)
+		assert.NoError(t, err)
+		assert.False(t, overlaps)
+		// the running entry counts until the current time
+		overlaps, err = HasOverlappingTimeEntry(db, userID, currentTime.Add(-10*time.Minute), currentTime.Add(-5*time.Minute), currentTime)
+		assert.NoError(t, err)
+		assert.True(t, overlaps)
+		overlaps, err = HasOverlappingTimeEntry(db, primitive.NewObjectID(), currentTime.Add(-150*time.Minute), currentTime.Add(-100*time.Minute), currentTime)
+		assert.NoError(t, err)
+		assert.False(t, overlaps)
+	})
+	t.Run("GetRunningTimeEntry", func(t *testing.T) {
+		timeEntry, err := GetRunningTimeEntry(db, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, primitive.NewDateTimeFromTime(currentTime.Add(-30*time.Minute)), timeEntry.StartTime)
+		_, err = GetRunningTimeEntry(db, primitive.NewObjectID())
+		assert.Equal(t, mongo.ErrNoDocuments, err)
+	})
+	t.Run("UpdateTaskTimeTracked", func(t *testing.T) {
+		assert.NoError(t, UpdateTaskTimeTracked(db, userID, taskID))
+		task, err := GetTask(db, taskID, userID)
+		assert.NoError(t, err)
+		// the running entry is not included
+		assert.Equal(t, int64(time.Hour), *task.TimeTracked)
+	})
+}
+
+func TestGetSharedWithRole(t *testing.T) {
+	sharedWith := []SharedWithEntry{
+		{Email: "reader@generaltask.com", Role: constants.AccessControlReader},
+		{Email: "commenter@generaltask.com", Role: constants.AccessControlCommenter},
+	}
+	assert.Equal(t, constants.AccessControlReader, GetSharedWithRole(sharedWith, "Reader@GeneralTask.com"))
+	assert.Equal(t, constants.AccessControlCommenter, GetSharedWithRole(sharedWith, "commenter@generaltask.com"))
+	assert.Equal(t, "", GetSharedWithRole(sharedWith, "other@generaltask.com"))
+	assert.Equal(t, "", GetSharedWithRole(sharedWith, ""))
+}
@@ -21,6 +21,34 @@ type User struct {
 	LinearDisplayName     string             `bson:"linear_display_name"`
 	GPTSuggestionsLeft    int                `bson:"gpt_suggestions_left"`
 	GPTLastSuggestionTime primitive.DateTime `bson:"gpt_last_suggestion_time"`
+	// IANA timezone name used by background jobs, e.g. "America/Los_Angeles"
+	Timezone     string            `bson:"timezone,omitempty"`
+	Availability *UserAvailability `bson:"availability,omitempty"`
+	// users without one get a meeting prep task for every meeting on a calendar they own
+	MeetingPreparation *MeetingPreparationConfig `bson:"meeting_preparation,omitempty"`
+	// lookups by email are case insensitive, so they match on this instead of the email as entered
+	EmailLowercase string `bson:"email_lowercase,omitempty"`
+}
+
+// when the user works, users without one are treated as working the default working hours
+type UserAvailability struct {
+	WorkingHours []WorkingHours      `bson:"working_hours" json:"working_hours"`
+	Blocks       []AvailabilityBlock `bson:"blocks" json:"blocks"`
+}
+
+type WorkingHours struct {
+	Weekday   int    `bson:"weekday" json:"weekday"`       // i.e. 0 = Sunday, 1 = Monday, etc.
+	StartTime string `bson:"start_time" json:"start_time"` // i.e. "09:00" in the user's timezone
+	EndTime   string `bson:"end_time" json:"end_time"`
+}
+
+// recurring blocks such as lunch, which are never scheduled over, or no meeting blocks, which only tasks are scheduled over
+type AvailabilityBlock struct {
+	Type      string `bson:"type" json:"type"`
+	Name      string `bson:"name,omitempty" json:"name,omitempty"`
+	Weekdays  []int  `bson:"weekdays" json:"weekdays"`
+	StartTime string `bson:"start_time" json:"start_time"`
+	EndTime   string `bson:"end_time" json:"end_time"`
 }
 
 type UserChangeable struct {
@@ -29,6 +57,7 @@ type UserChangeable struct {
 	LastRefreshed     primitive.DateTime `bson:"last_refreshed,omitempty"`
 	LinearName        string             `bson:"linear_name,omitempty"`
 	LinearDisplayName string             `bson:"linear_display_name,omitempty"`
+	EmailLowercase    string             `bson:"email_lowercase,omitempty"`
 }
 
 // InternalAPIToken model
@@ -96,6 +125,8 @@ type Task struct {
 	ParentTaskID primitive.ObjectID `bson:"parent_task_id,omitempty"`
 	// required for recurring tasks
 	RecurringTaskTemplateID primitive.ObjectID `bson:"recurring_task_template_id,omitempty"`
+	// the date (YYYY-MM-DD, in the user's timezone) of the template occurrence this task was created for
+	RecurringTaskOccurrenceDate string `bson:"recurring_task_occurrence_date,omitempty"`
 	// generic task values (for all sources)
 	IDExternal         string              `bson:"id_external,omitempty"`
 	IDOrdering         int                 `bson:"id_ordering,omitempty"`
@@ -111,15 +142,19 @@ type Task struct {
 	HasBeenReordered   bool                `bson:"has_been_reordered,omitempty"`
 	DueDate            *primitive.DateTime `bson:"due_date,omitempty"`
 	TimeAllocation     *int64              `bson:"time_allocated,omitempty"` // time in nanoseconds
+	TimeTracked        *int64              `bson:"time_tracked,omitempty"`   // total of the task's stopped time entries, in nanoseconds
 	CreatedAtExternal  primitive.DateTime  `bson:"created_at_external,omitempty"`
 	UpdatedAt          primitive.DateTime  `bson:"updated_at,omitempty"`
 	CompletedAt        primitive.DateTime  `bson:"completed_at,omitempty"`
 	SharedUntil        primitive.DateTime  `bson:"shared_until,omitempty"`
 	SharedAccess       *SharedAccess       `bson:"shared_access,omitempty"`
+	SnoozedUntil       primitive.DateTime  `bson:"snoozed_until,omitempty"`
 	DeletedAt          primitive.DateTime  `bson:"deleted_at,omitempty"`
 	PriorityNormalized *float64            `bson:"priority_normalized,omitempty"`
 	TaskNumber         *int                `bson:"task_number,omitempty"`
 	Comments           *[]Comment          `bson:"comments,omitempty"`
+	Labels             *[]string           `bson:"labels,omitempty"`
+	Reminders          *[]ReminderRule     `bson:"reminders,omitempty"`
 	// used for external priority handling
 	ExternalPriority      *ExternalTaskPriority   `bson:"priority,omitempty"`
 	AllExternalPriorities []*ExternalTaskPriority `bson:"all_priorities,omitempty"`
@@ -138,6 +173,18 @@ type Task struct {
 	MeetingPreparationParams *MeetingPreparationParams `bson:"meeting_preparation_params,omitempty"`
 	IsMeetingPreparationTask bool                      `bson:"is_meeting_preparation_task,omitempty"`
 	LinearCycle              LinearCycle               `bson:"linear_cycle,omitempty"`
+	// people the task is shared with regardless of shared_access and shared_until
+	SharedWith []SharedWithEntry `bson:"shared_with,omitempty"`
+	// set for tasks in the shared sections of a workspace
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty"`
+	// set for tasks which were assigned by another user, delegated tasks are owned by the assignee
+	AssigneeID       primitive.ObjectID `bson:"assignee_id,omitempty"`
+	AssignedBy       primitive.ObjectID `bson:"assigned_by,omitempty"`
+	AssignmentStatus string             `bson:"assignment_status,omitempty"`
+	AssignedAt       primitive.DateTime `bson:"assigned_at,omitempty"`
+	// where the task was in the assigner's list, so a declined task goes back to the same place
+	AssignerTaskSection primitive.ObjectID `bson:"assigner_task_section,omitempty"`
+	AssignerIDOrdering  int                `bson:"assigner_id_ordering,omitempty"`
 }
 
 type RecurringTaskTemplate struct {
@@ -148,10 +195,17 @@ type RecurringTaskTemplate struct {
 	Body               *string            `bson:"body,omitempty" json:"body,omitempty"`
 	IDTaskSection      primitive.ObjectID `bson:"id_task_section,omitempty" json:"id_task_section,omitempty"`
 	PriorityNormalized *float64           `bson:"priority_normalized,omitempty" json:"priority_normalized,omitempty"`
+	// tasks are created in General Task unless an external source is set, subtasks are always General Task tasks
+	TimeAllocation    *int64                          `bson:"time_allocated,omitempty" json:"time_allocated,omitempty"`
+	DueDateOffsetDays *int                            `bson:"due_date_offset_days,omitempty" json:"due_date_offset_days,omitempty"` // i.e. 2 = due 2 days after the task is created
+	SourceID          string                          `bson:"source_id,omitempty" json:"source_id,omitempty"`
+	SourceAccountID   string                          `bson:"source_account_id,omitempty" json:"source_account_id,omitempty"`
+	Subtasks          *[]RecurringTaskTemplateSubtask `bson:"subtasks,omitempty" json:"subtasks,omitempty"`
 	// recurrence fields
 	IsEnabled                    *bool              `bson:"is_enabled,omitempty" json:"is_enabled,omitempty"`
 	IsDeleted                    *bool              `bson:"is_deleted,omitempty" json:"is_deleted,omitempty"`
 	RecurrenceRate               *int               `bson:"recurrence_rate,omitempty" json:"recurrence_rate,omitempty"` // i.e. 0 = Daily, 1 = WeekDaily, 2 = Weekly, etc.
+	RecurrenceRule               *string            `bson:"recurrence_rule,omitempty" json:"recurrence_rule,omitempty"` // RFC 5545 RRULE with optional DTSTART and EXDATE lines, takes precedence over recurrence rate
 	TimeOfDaySecondsToCreateTask *int               `bson:"time_of_day_seconds_to_create_task,omitempty" json:"time_of_day_seconds_to_create_task,omitempty"`
 	DayToCreateTask              *int               `bson:"day_to_create_task,omitempty" json:"day_to_create_task,omitempty"`
 	MonthToCreateTask            *int               `bson:"month_to_create_task,omitempty" json:"month_to_create_task,omitempty"`
@@ -163,6 +217,13 @@ type RecurringTaskTemplate struct {
 	UpdatedAt primitive.DateTime `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
 }
 
+type RecurringTaskTemplateSubtask struct {
+	Title          string                         `bson:"title" json:"title"`
+	Body           string                         `bson:"body,omitempty" json:"body,omitempty"`
+	TimeAllocation *int64                         `bson:"time_allocated,omitempty" json:"time_allocated,omitempty"`
+	Subtasks       []RecurringTaskTemplateSubtask `bson:"subtasks,omitempty" json:"subtasks,omitempty"`
+}
+
 type PullRequest struct {
 	ID                primitive.ObjectID   `bson:"_id,omitempty"`
 	UserID            primitive.ObjectID   `bson:"user_id,omitempty"`
@@ -248,6 +309,20 @@ type CalendarEvent struct {
 	ColorBackground     string             `bson:"color_background,omitempty"`
 	ColorForeground     string             `bson:"color_foreground,omitempty"`
 	AttendeeEmails      []string           `bson:"attendee_emails,omitempty"`
+	// set on every instance of a recurring event
+	RecurringEventID string `bson:"recurring_event_id,omitempty"`
+	// the user's own RSVP, see constants.RSVPStatus*
+	SelfResponseStatus string                  `bson:"self_response_status,omitempty"`
+	Attendees          []CalendarEventAttendee `bson:"attendees,omitempty"`
+}
+
+type CalendarEventAttendee struct {
+	Email          string `bson:"email,omitempty"`
+	DisplayName    string `bson:"display_name,omitempty"`
+	ResponseStatus string `bson:"response_status,omitempty"`
+	Comment        string `bson:"comment,omitempty"`
+	IsOrganizer    bool   `bson:"is_organizer,omitempty"`
+	IsSelf         bool   `bson:"is_self,omitempty"`
 }
 
 type MeetingPreparationParams struct {
@@ -257,6 +332,28 @@ type MeetingPreparationParams struct {
 	DatetimeEnd                   primitive.DateTime `bson:"datetime_end,omitempty"`
 	HasBeenAutomaticallyCompleted bool               `bson:"has_been_automatically_completed,omitempty"`
 	EventMovedOrDeleted           bool               `bson:"event_moved_or_deleted,omitempty"`
+	// task created for unfinished preparation after the meeting ended
+	FollowUpTaskID primitive.ObjectID `bson:"follow_up_task_id,omitempty"`
+}
+
+type MeetingPreparationConfig struct {
+	Rules []MeetingPreparationRule `bson:"rules" json:"rules"`
+	// filled in when a prep task is created, see constants.MeetingPrepTemplate* for the placeholders
+	BodyTemplate string `bson:"body_template" json:"body_template"`
+}
+
+// a meeting matches a rule when it matches every condition which is set
+type MeetingPreparationRule struct {
+	Action string `bson:"action" json:"action"`
+	// attendee counts include the user
+	MinAttendees *int `bson:"min_attendees,omitempty" json:"min_attendees,omitempty"`
+	MaxAttendees *int `bson:"max_attendees,omitempty" json:"max_attendees,omitempty"`
+	// attendees are external when their email domain is different from the user's
+	HasExternalAttendees *bool    `bson:"has_external_attendees,omitempty" json:"has_external_attendees,omitempty"`
+	AttendeeDomains      []string `bson:"attendee_domains,omitempty" json:"attendee_domains,omitempty"`
+	TitleRegex           string   `bson:"title_regex,omitempty" json:"title_regex,omitempty"`
+	CalendarIDs          []string `bson:"calendar_ids,omitempty" json:"calendar_ids,omitempty"`
+	IsRecurring          *bool    `bson:"is_recurring,omitempty" json:"is_recurring,omitempty"`
 }
 
 type LinearCycle struct {
@@ -319,6 +416,17 @@ type Comment struct {
 	Body       string             `bson:"body" json:"body"`
 	User       ExternalUser       `bson:"user" json:"user"`
 	CreatedAt  primitive.DateTime `bson:"created_at" json:"created_at"`
+	// set on comments written in General Task, only the author can edit or delete them
+	AuthorID  primitive.ObjectID `bson:"author_id,omitempty" json:"-"`
+	UpdatedAt primitive.DateTime `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// a reminder fires either a number of minutes before the due date, or at a time of day
+// (in the user's timezone) a number of days before the due date
+type ReminderRule struct {
+	MinutesBeforeDue int    `bson:"minutes_before_due,omitempty" json:"minutes_before_due,omitempty"`
+	DaysBeforeDue    int    `bson:"days_before_due,omitempty" json:"days_before_due,omitempty"`
+	TimeOfDay        string `bson:"time_of_day,omitempty" json:"time_of_day,omitempty"`
 }
 
 type ExternalTaskStatus struct {
@@ -385,6 +493,8 @@ type TaskSection struct {
 	IDOrdering int                `bson:"id_ordering"`
 	UserID     primitive.ObjectID `bson:"user_id"`
 	Name       string             `bson:"name"`
+	// shared sections of a workspace have no user ID
+	WorkspaceID primitive.ObjectID `bson:"workspace_id,omitempty"`
 }
 
 type Pagination struct {
@@ -441,6 +551,55 @@ type Note struct {
 	SharedUntil   primitive.DateTime `bson:"shared_until,omitempty"`
 	SharedAccess  *SharedAccess      `bson:"shared_access,omitempty"`
 	IsDeleted     *bool              `bson:"is_deleted,omitempty"`
+	// notes linked to a recurring event series without an event are the template for the notes of each instance
+	LinkedRecurringEventID string `bson:"linked_recurring_event_id,omitempty"`
+	// the note of the previous instance in the series
+	PreviousNoteID primitive.ObjectID `bson:"previous_note_id,omitempty"`
+	// people the note is shared with regardless of shared_access and shared_until
+	SharedWith []SharedWithEntry `bson:"shared_with,omitempty"`
+	Comments   *[]Comment        `bson:"comments,omitempty"`
+}
+
+// the content of a note after a save
+type NoteRevision struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	NoteID    primitive.ObjectID `bson:"note_id"`
+	UserID    primitive.ObjectID `bson:"user_id"`
+	Title     string             `bson:"title"`
+	Body      string             `bson:"body"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+	UpdatedAt primitive.DateTime `bson:"updated_at"`
+}
+
+// a link from a note to a task, pull request or another note
+type NoteLink struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `bson:"user_id"`
+	NoteID     primitive.ObjectID `bson:"note_id"`
+	TargetID   primitive.ObjectID `bson:"target_id"`
+	TargetType string             `bson:"target_type"`
+	// reference links come from [[type:id]] references in the note body and are replaced whenever the body is saved
+	IsReference bool               `bson:"is_reference"`
+	CreatedAt   primitive.DateTime `bson:"created_at"`
+}
+
+type SharedWithEntry struct {
+	// stored in lowercase
+	Email    string             `bson:"email" json:"email"`
+	Role     string             `bson:"role" json:"role"`
+	SharedAt primitive.DateTime `bson:"shared_at" json:"shared_at"`
+}
+
+// views of a shared task or note by signed in users other than its owner
+type SharedItemView struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	ItemID        primitive.ObjectID `bson:"item_id"`
+	ItemType      string             `bson:"item_type"`
+	ViewerID      primitive.ObjectID `bson:"viewer_id"`
+	ViewerEmail   string             `bson:"viewer_email"`
+	FirstViewedAt primitive.DateTime `bson:"first_viewed_at"`
+	LastViewedAt  primitive.DateTime `bson:"last_viewed_at"`
+	ViewCount     int                `bson:"view_count"`
 }
 
 type DashboardDataPoint struct {
@@ -457,6 +616,11 @@ type DashboardTeam struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty"`
 	UserID    primitive.ObjectID `bson:"user_id,omitempty"`
 	CreatedAt primitive.DateTime `bson:"created_at,omitempty"`
+	// set when the team members are synced from a GitHub organization, or from a team within it
+	GithubRosterAccountID    string             `bson:"github_roster_account_id,omitempty"`
+	GithubRosterOrganization string             `bson:"github_roster_organization,omitempty"`
+	GithubRosterTeamSlug     string             `bson:"github_roster_team_slug,omitempty"`
+	GithubRosterSyncedAt     primitive.DateTime `bson:"github_roster_synced_at,omitempty"`
 }
 
 type DashboardTeamMember struct {
@@ -466,4 +630,93 @@ type DashboardTeamMember struct {
 	GithubID  string             `bson:"github_id,omitempty"`
 	Name      string             `bson:"name,omitempty"`
 	CreatedAt primitive.DateTime `bson:"created_at,omitempty"`
+	// set for members which come from the team owner's workspaces rather than being added by hand
+	UserID primitive.ObjectID `bson:"user_id,omitempty"`
+	// set for members which are imported from the team's GitHub roster
+	IsGithubRosterMember bool `bson:"is_github_roster_member,omitempty"`
+	// the General Task user with the same verified email, or who linked the same GitHub account
+	MatchedUserID primitive.ObjectID `bson:"matched_user_id,omitempty"`
+	// members who leave the GitHub roster are flagged rather than removed from the team
+	RemovedFromRosterAt primitive.DateTime `bson:"removed_from_roster_at,omitempty"`
+}
+
+type Workspace struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Name      string             `bson:"name"`
+	CreatedBy primitive.ObjectID `bson:"created_by"`
+	CreatedAt primitive.DateTime `bson:"created_at"`
+}
+
+// members are invited by email, the user ID is set once they accept the invite
+type WorkspaceMember struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	WorkspaceID primitive.ObjectID `bson:"workspace_id"`
+	UserID      primitive.ObjectID `bson:"user_id,omitempty"`
+	// stored in lowercase
+	Email     string             `bson:"email"`
+	Role      string             `bson:"role"`
+	InvitedBy primitive.ObjectID `bson:"invited_by,omitempty"`
+	InvitedAt primitive.DateTime `bson:"invited_at"`
+	JoinedAt  primitive.DateTime `bson:"joined_at,omitempty"`
+}
+
+type Notification struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	UserID primitive.ObjectID `bson:"user_id,omitempty"`
+	TaskID primitive.ObjectID `bson:"task_id,omitempty"`
+	NoteID primitive.ObjectID `bson:"note_id,omitempty"`
+	Type   string             `bson:"type,omitempty"`
+	// identifies the reminder rule and due date which caused this notification, so it is only sent once
+	ReminderKey string             `bson:"reminder_key,omitempty"`
+	Title       string             `bson:"title,omitempty"`
+	Body        string             `bson:"body,omitempty"`
+	Channels    []string           `bson:"channels,omitempty"`
+	IsRead      bool               `bson:"is_read,omitempty"`
+	CreatedAt   primitive.DateTime `bson:"created_at,omitempty"`
+}
+
+type TimeEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id,omitempty"`
+	TaskID    primitive.ObjectID `bson:"task_id,omitempty"`
+	StartTime primitive.DateTime `bson:"start_time,omitempty"`
+	// unset while the timer is running
+	EndTime   primitive.DateTime `bson:"end_time,omitempty"`
+	CreatedAt primitive.DateTime `bson:"created_at,omitempty"`
+	// set while the timer is running, a unique partial index on user_id allows one running timer per user
+	IsRunning bool `bson:"is_running,omitempty"`
+}
+
+// SchedulingLink is a public link which guests can use to book a meeting with the user
+type SchedulingLink struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty"`
+	UserID primitive.ObjectID `bson:"user_id,omitempty"`
+	// random token used in the public URL instead of the link ID
+	Token           string `bson:"token,omitempty"`
+	Title           string `bson:"title,omitempty"`
+	SourceAccountID string `bson:"source_account_id,omitempty"`
+	CalendarID      string `bson:"calendar_id,omitempty"`
+	Timezone        string `bson:"timezone,omitempty"`
+	// durations are in minutes
+	DurationMinutes      int                `bson:"duration_minutes,omitempty"`
+	BufferMinutes        int                `bson:"buffer_minutes,omitempty"`
+	MinimumNoticeMinutes int                `bson:"minimum_notice_minutes,omitempty"`
+	DaysAhead            int                `bson:"days_ahead,omitempty"`
+	AddConferenceCall    bool               `bson:"add_conference_call,omitempty"`
+	CreatedAt            primitive.DateTime `bson:"created_at,omitempty"`
+}
+
+// SchedulingBookingLock is held while a guest books time on the user's calendar, so two guests cannot book the same time
+type SchedulingBookingLock struct {
+	UserID    primitive.ObjectID `bson:"_id"`
+	LockID    primitive.ObjectID `bson:"lock_id"`
+	ExpiresAt primitive.DateTime `bson:"expires_at"`
+}
+
+// RateLimit counts the requests made with a key, e.g. an IP address, in the window starting at window_start
+type RateLimit struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Key         string             `bson:"key"`
+	WindowStart primitive.DateTime `bson:"window_start"`
+	Count       int                `bson:"count"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,13 +35,23 @@ func processAndStoreEvent(event *calendar.Event, db *mongo.Database, userID prim
 		return &database.CalendarEvent{}
 	}
 
-	//exclude events we declined.
+	// declined events are stored so that the user can change their response
 	attendeeEmails := []string{}
+	attendees := []database.CalendarEventAttendee{}
+	selfResponseStatus := ""
 	for _, attendee := range event.Attendees {
-		if attendee.Self && attendee.ResponseStatus == "declined" {
-			return &database.CalendarEvent{}
+		if attendee.Self {
+			selfResponseStatus = attendee.ResponseStatus
 		}
 		attendeeEmails = append(attendeeEmails, attendee.Email)
+		attendees = append(attendees, database.CalendarEventAttendee{
+			Email:          attendee.Email,
+			DisplayName:    attendee.DisplayName,
+			ResponseStatus: attendee.ResponseStatus,
+			Comment:        attendee.Comment,
+			IsOrganizer:    attendee.Organizer,
+			IsSelf:         attendee.Self,
+		})
 	}
 
 	dbStartTime, _ := time.Parse(time.RFC3339, event.Start.DateTime)
@@ -74,6 +85,11 @@ func processAndStoreEvent(event *calendar.Event, db *mongo.Database, userID prim
 		CallPlatform:    conferenceCall.Platform,
 		AttendeeEmails:  attendeeEmails,
 	}
+	dbEvent.RecurringEventID = event.RecurringEventId
+	dbEvent.SelfResponseStatus = selfResponseStatus
+	if len(attendees) > 0 {
+		dbEvent.Attendees = attendees
+	}
 	if colors != nil {
 		dbEvent.ColorBackground = colors.Event[event.ColorId].Background
 		dbEvent.ColorForeground = colors.Event[event.ColorId].Foreground
@@ -281,7 +297,7 @@ func (googleCalendar GoogleCalendarSource) CreateNewEvent(db *mongo.Database, us
 	return nil
 }
 
-func (googleCalendar GoogleCalendarSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (googleCalendar GoogleCalendarSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string, recurrenceScope string) error {
 	// TODO: create a EventDeleteURL
 	calendarService, err := createGcalService(googleCalendar.Google.OverrideURLs.CalendarDeleteURL, userID, accountID, context.Background(), db)
 	if err != nil {
@@ -292,6 +308,19 @@ func (googleCalendar GoogleCalendarSource) DeleteEvent(db *mongo.Database, userI
 	if calendarID != "" {
 		calendarIDToDelete = calendarID
 	}
+	if recurrenceScope == constants.RecurrenceScopeAll || recurrenceScope == constants.RecurrenceScopeFollowing {
+		instance, recurringEvent, err := getRecurringEventInstance(calendarService, calendarIDToDelete, externalID)
+		if err != nil {
+			return err
+		}
+		if recurringEvent != nil {
+			if recurrenceScope == constants.RecurrenceScopeFollowing && !isFirstRecurringEventInstance(instance, recurringEvent) {
+				// ending the series before this instance removes it and every instance after it
+				return truncateRecurringEvent(calendarService, calendarIDToDelete, instance, recurringEvent)
+			}
+			externalID = recurringEvent.Id
+		}
+	}
 	err = calendarService.Events.Delete(calendarIDToDelete, externalID).Do()
 	logger := logging.GetSentryLogger()
 	if err != nil {
@@ -411,6 +440,39 @@ func (googleCalendar GoogleCalendarSource) ModifyEvent(db *mongo.Database, userI
 	if updateFields.CalendarID != "" {
 		calendarID = updateFields.CalendarID
 	}
+	if updateFields.ResponseStatus != nil {
+		// the whole attendee list is sent back with only the user's own response changed
+		existingEvent, err := calendarService.Events.Get(calendarID, eventID).Do()
+		if err != nil {
+			return err
+		}
+		isAttendee := false
+		for _, attendee := range existingEvent.Attendees {
+			if attendee.Self {
+				attendee.ResponseStatus = *updateFields.ResponseStatus
+				if updateFields.ResponseComment != nil {
+					attendee.Comment = *updateFields.ResponseComment
+				}
+				isAttendee = true
+			}
+		}
+		if !isAttendee {
+			return errors.New("user is not an attendee of the event")
+		}
+		gcalEvent.Attendees = existingEvent.Attendees
+	}
+	if updateFields.RecurrenceScope == constants.RecurrenceScopeAll || updateFields.RecurrenceScope == constants.RecurrenceScopeFollowing {
+		instance, recurringEvent, err := getRecurringEventInstance(calendarService, calendarID, eventID)
+		if err != nil {
+			return err
+		}
+		if recurringEvent != nil {
+			if updateFields.RecurrenceScope == constants.RecurrenceScopeFollowing && !isFirstRecurringEventInstance(instance, recurringEvent) {
+				return splitRecurringEvent(calendarService, calendarID, instance, recurringEvent, &gcalEvent)
+			}
+			return modifyRecurringEvent(calendarService, calendarID, instance, recurringEvent, &gcalEvent)
+		}
+	}
 	_, err = calendarService.Events.Patch(calendarID, eventID, &gcalEvent).Do()
 	if err != nil {
 		return err
@@ -418,6 +480,240 @@ func (googleCalendar GoogleCalendarSource) ModifyEvent(db *mongo.Database, userI
 	return nil
 }
 
+// returns the instance and the recurring event it belongs to, which is nil for single events
+func getRecurringEventInstance(calendarService *calendar.Service, calendarID string, eventID string) (*calendar.Event, *calendar.Event, error) {
+	instance, err := calendarService.Events.Get(calendarID, eventID).Do()
+	if err != nil {
+		return nil, nil, err
+	}
+	if instance.RecurringEventId == "" {
+		return instance, nil, nil
+	}
+	recurringEvent, err := calendarService.Events.Get(calendarID, instance.RecurringEventId).Do()
+	if err != nil {
+		return nil, nil, err
+	}
+	return instance, recurringEvent, nil
+}
+
+func isFirstRecurringEventInstance(instance *calendar.Event, recurringEvent *calendar.Event) bool {
+	if instance.OriginalStartTime == nil || recurringEvent.Start == nil {
+		return false
+	}
+	originalStart, err := parseEventDateTime(instance.OriginalStartTime)
+	if err != nil {
+		return false
+	}
+	seriesStart, err := parseEventDateTime(recurringEvent.Start)
+	if err != nil {
+		return false
+	}
+	return originalStart.Equal(seriesStart)
+}
+
+// all day events only have a date set
+func parseEventDateTime(eventTime *calendar.EventDateTime) (time.Time, error) {
+	if eventTime.DateTime == "" && eventTime.Date != "" {
+		return time.Parse(constants.YEAR_MONTH_DAY_FORMAT, eventTime.Date)
+	}
+	return time.Parse(time.RFC3339, eventTime.DateTime)
+}
+
+// changes to the instance times are applied to the recurring event as offsets, so every instance moves by the same amount
+func modifyRecurringEvent(calendarService *calendar.Service, calendarID string, instance *calendar.Event, recurringEvent *calendar.Event, gcalEvent *calendar.Event) error {
+	if instance.OriginalStartTime == nil {
+		return errors.New("instance is missing its original start time")
+	}
+	// offsets are taken from where the instance would be in the series, as the instance itself may have been moved
+	var err error
+	if gcalEvent.Start != nil {
+		gcalEvent.Start, err = shiftEventDateTime(recurringEvent.Start, instance.OriginalStartTime, gcalEvent.Start)
+		if err != nil {
+			return err
+		}
+	}
+	if gcalEvent.End != nil {
+		gcalEvent.End, err = shiftEventDateTime(recurringEvent.Start, instance.OriginalStartTime, gcalEvent.End)
+		if err != nil {
+			return err
+		}
+		gcalEvent.End.TimeZone = recurringEvent.End.TimeZone
+	}
+	_, err = calendarService.Events.Patch(calendarID, recurringEvent.Id, gcalEvent).Do()
+	return err
+}
+
+func shiftEventDateTime(seriesTime *calendar.EventDateTime, originalTime *calendar.EventDateTime, updatedTime *calendar.EventDateTime) (*calendar.EventDateTime, error) {
+	seriesStart, err := parseEventDateTime(seriesTime)
+	if err != nil {
+		return nil, err
+	}
+	originalStart, err := parseEventDateTime(originalTime)
+	if err != nil {
+		return nil, err
+	}
+	updatedStart, err := parseEventDateTime(updatedTime)
+	if err != nil {
+		return nil, err
+	}
+	shiftedStart := seriesStart.Add(updatedStart.Sub(originalStart))
+	if seriesTime.DateTime == "" && seriesTime.Date != "" {
+		return &calendar.EventDateTime{Date: shiftedStart.Format(constants.YEAR_MONTH_DAY_FORMAT)}, nil
+	}
+	return &calendar.EventDateTime{
+		DateTime: shiftedStart.Format(time.RFC3339),
+		TimeZone: seriesTime.TimeZone,
+	}, nil
+}
+
+// ends the recurring event before the instance and starts a new series from the instance with the changes applied
+func splitRecurringEvent(calendarService *calendar.Service, calendarID string, instance *calendar.Event, recurringEvent *calendar.Event, gcalEvent *calendar.Event) error {
+	if instance.OriginalStartTime == nil {
+		return errors.New("instance is missing its original start time")
+	}
+	originalStart, err := parseEventDateTime(instance.OriginalStartTime)
+	if err != nil {
+		return err
+	}
+	// the new series keeps the recurrence rules of the original one, less the instances which stay in the original one
+	recurrence := recurringEvent.Recurrence
+	if recurrenceHasCount(recurrence) {
+		previousInstances, err := countRecurringEventInstancesBefore(calendarService, calendarID, recurringEvent.Id, originalStart)
+		if err != nil {
+			return err
+		}
+		recurrence = reduceRecurrenceCount(recurrence, previousInstances)
+	}
+	err = truncateRecurringEvent(calendarService, calendarID, instance, recurringEvent)
+	if err != nil {
+		return err
+	}
+	newSeries := &calendar.Event{
+		Summary:     recurringEvent.Summary,
+		Description: recurringEvent.Description,
+		Location:    recurringEvent.Location,
+		ColorId:     recurringEvent.ColorId,
+		Visibility:  recurringEvent.Visibility,
+		Reminders:   recurringEvent.Reminders,
+		Attendees:   recurringEvent.Attendees,
+		Recurrence:  recurrence,
+		Start:       &calendar.EventDateTime{Date: instance.Start.Date, DateTime: instance.Start.DateTime, TimeZone: recurringEvent.Start.TimeZone},
+		End:         &calendar.EventDateTime{Date: instance.End.Date, DateTime: instance.End.DateTime, TimeZone: recurringEvent.End.TimeZone},
+	}
+	if gcalEvent.Summary != "" {
+		newSeries.Summary = gcalEvent.Summary
+	}
+	if gcalEvent.Description != "" {
+		newSeries.Description = gcalEvent.Description
+	}
+	if gcalEvent.Location != "" {
+		newSeries.Location = gcalEvent.Location
+	}
+	if gcalEvent.Attendees != nil {
+		newSeries.Attendees = gcalEvent.Attendees
+	}
+	if gcalEvent.Start != nil {
+		newSeries.Start = &calendar.EventDateTime{DateTime: gcalEvent.Start.DateTime, TimeZone: recurringEvent.Start.TimeZone}
+	}
+	if gcalEvent.End != nil {
+		newSeries.End = &calendar.EventDateTime{DateTime: gcalEvent.End.DateTime, TimeZone: recurringEvent.End.TimeZone}
+	}
+	_, err = calendarService.Events.Insert(calendarID, newSeries).Do()
+	return err
+}
+
+func truncateRecurringEvent(calendarService *calendar.Service, calendarID string, instance *calendar.Event, recurringEvent *calendar.Event) error {
+	if instance.OriginalStartTime == nil {
+		return errors.New("instance is missing its original start time")
+	}
+	originalStart, err := parseEventDateTime(instance.OriginalStartTime)
+	if err != nil {
+		return err
+	}
+	// UNTIL must be a date when the series is made of all day events
+	until := originalStart.Add(-time.Second).UTC().Format("20060102T150405Z")
+	if instance.OriginalStartTime.DateTime == "" {
+		until = originalStart.AddDate(0, 0, -1).Format("20060102")
+	}
+	_, err = calendarService.Events.Patch(calendarID, recurringEvent.Id, &calendar.Event{
+		Recurrence: truncateRecurrence(recurringEvent.Recurrence, until),
+	}).Do()
+	return err
+}
+
+// replaces the end of every RRULE with an UNTIL, as Google does not allow both UNTIL and COUNT
+func truncateRecurrence(recurrence []string, until string) []string {
+	truncated := []string{}
+	for _, rule := range recurrence {
+		if !strings.HasPrefix(rule, "RRULE:") {
+			truncated = append(truncated, rule)
+			continue
+		}
+		parts := []string{}
+		for _, part := range strings.Split(strings.TrimPrefix(rule, "RRULE:"), ";") {
+			if !strings.HasPrefix(part, "UNTIL=") && !strings.HasPrefix(part, "COUNT=") {
+				parts = append(parts, part)
+			}
+		}
+		parts = append(parts, "UNTIL="+until)
+		truncated = append(truncated, "RRULE:"+strings.Join(parts, ";"))
+	}
+	return truncated
+}
+
+func recurrenceHasCount(recurrence []string) bool {
+	for _, rule := range recurrence {
+		if strings.HasPrefix(rule, "RRULE:") && strings.Contains(rule, "COUNT=") {
+			return true
+		}
+	}
+	return false
+}
+
+// counts the instances, including cancelled ones, which were originally scheduled before the given time
+func countRecurringEventInstancesBefore(calendarService *calendar.Service, calendarID string, recurringEventID string, before time.Time) (int, error) {
+	count := 0
+	err := calendarService.Events.Instances(calendarID, recurringEventID).ShowDeleted(true).Pages(context.Background(), func(instances *calendar.Events) error {
+		for _, instance := range instances.Items {
+			if instance.OriginalStartTime == nil {
+				continue
+			}
+			originalStart, err := parseEventDateTime(instance.OriginalStartTime)
+			if err != nil {
+				return err
+			}
+			if originalStart.Before(before) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// takes the instances which were already used from the COUNT of every RRULE
+func reduceRecurrenceCount(recurrence []string, usedCount int) []string {
+	reduced := []string{}
+	for _, rule := range recurrence {
+		if !strings.HasPrefix(rule, "RRULE:") {
+			reduced = append(reduced, rule)
+			continue
+		}
+		parts := []string{}
+		for _, part := range strings.Split(strings.TrimPrefix(rule, "RRULE:"), ";") {
+			if strings.HasPrefix(part, "COUNT=") {
+				count, err := strconv.Atoi(strings.TrimPrefix(part, "COUNT="))
+				if err == nil && count-usedCount > 0 {
+					part = "COUNT=" + strconv.Itoa(count-usedCount)
+				}
+			}
+			parts = append(parts, part)
+		}
+		reduced = append(reduced, "RRULE:"+strings.Join(parts, ";"))
+	}
+	return reduced
+}
+
 func createConferenceCallRequest() *calendar.ConferenceData {
 	// todo - add client generated requestId
 	return &calendar.ConferenceData{
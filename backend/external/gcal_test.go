@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -643,7 +644,7 @@ func TestDeleteEvent(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarDeleteURL: &server.URL},
 			},
 		}
-		err := googleCalendar.DeleteEvent(db, userID, "exampleAccountID", gcalEventID, "")
+		err := googleCalendar.DeleteEvent(db, userID, "exampleAccountID", gcalEventID, "", "")
 		assert.Error(t, err)
 	})
 	t.Run("Success", func(t *testing.T) {
@@ -659,7 +660,7 @@ func TestDeleteEvent(t *testing.T) {
 				OverrideURLs: GoogleURLOverrides{CalendarDeleteURL: &server.URL},
 			},
 		}
-		err := googleCalendar.DeleteEvent(db, userID, accountID, gcalEventID, "")
+		err := googleCalendar.DeleteEvent(db, userID, accountID, gcalEventID, "", "")
 		assert.NoError(t, err)
 	})
 }
@@ -764,6 +765,47 @@ func TestModifyEvent(t *testing.T) {
 		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &eventModifyObj)
 		assert.NoError(t, err)
 	})
+	t.Run("SuccessWithResponseStatus", func(t *testing.T) {
+		responseStatus := "declined"
+		comment := "out sick"
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" {
+				_, err := w.Write([]byte(`{"attendees":[{"email":"organizer@example.com","organizer":true,"responseStatus":"accepted"},{"email":"me@example.com","self":true,"responseStatus":"needsAction"}]}`))
+				assert.NoError(t, err)
+				return
+			}
+			var requestEvent calendar.Event
+			err := json.NewDecoder(r.Body).Decode(&requestEvent)
+			assert.NoError(t, err)
+			assert.Equal(t, 2, len(requestEvent.Attendees))
+			assert.Equal(t, "accepted", requestEvent.Attendees[0].ResponseStatus)
+			assert.Equal(t, "declined", requestEvent.Attendees[1].ResponseStatus)
+			assert.Equal(t, "out sick", requestEvent.Attendees[1].Comment)
+			_, err = w.Write([]byte(`{}`))
+			assert.NoError(t, err)
+		}))
+		defer server.Close()
+		googleCalendar := GoogleCalendarSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{CalendarModifyURL: &server.URL}}}
+
+		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &EventModifyObject{
+			AccountID:       accountID,
+			ResponseStatus:  &responseStatus,
+			ResponseComment: &comment,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("ResponseStatusNotAttendee", func(t *testing.T) {
+		responseStatus := "accepted"
+		server := testutils.GetMockAPIServer(t, 200, `{"attendees":[{"email":"organizer@example.com","organizer":true}]}`)
+		defer server.Close()
+		googleCalendar := GoogleCalendarSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{CalendarModifyURL: &server.URL}}}
+
+		err := googleCalendar.ModifyEvent(db, userID, accountID, eventID, &EventModifyObject{
+			AccountID:      accountID,
+			ResponseStatus: &responseStatus,
+		})
+		assert.EqualError(t, err, "user is not an attendee of the event")
+	})
 	t.Run("ExternalError", func(t *testing.T) {
 		datetimeStart := testutils.CreateTimestamp("2020-04-19")
 		datetimeEnd := testutils.CreateTimestamp("2020-04-20")
@@ -779,6 +821,188 @@ func TestModifyEvent(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestModifyEventRecurrenceScope(t *testing.T) {
+	db, dbCleanup, _ := database.GetDBConnection()
+	defer dbCleanup()
+	userID := primitive.NewObjectID()
+	accountID := "duccount_id"
+	instance := `{"id":"instance","recurringEventId":"series","originalStartTime":{"dateTime":"2022-01-10T10:00:00Z"},"start":{"dateTime":"2022-01-10T10:00:00Z"},"end":{"dateTime":"2022-01-10T11:00:00Z"}}`
+	series := `{"id":"series","start":{"dateTime":"2022-01-03T10:00:00Z","timeZone":"UTC"},"end":{"dateTime":"2022-01-03T11:00:00Z","timeZone":"UTC"},"summary":"weekly sync","recurrence":["RRULE:FREQ=WEEKLY;COUNT=10"]}`
+	instances := `{"items":[{"id":"series_1","originalStartTime":{"dateTime":"2022-01-03T10:00:00Z"}},{"id":"instance","originalStartTime":{"dateTime":"2022-01-10T10:00:00Z"}},{"id":"series_3","originalStartTime":{"dateTime":"2022-01-17T10:00:00Z"}}]}`
+	getServer := func(requests *[]string, instance string, series string, handleWrite func(r *http.Request, requestEvent calendar.Event)) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requests = append(*requests, r.Method+" "+r.URL.Path)
+			if r.Method == "GET" {
+				body := instance
+				if strings.HasSuffix(r.URL.Path, "/series") {
+					body = series
+				} else if strings.HasSuffix(r.URL.Path, "/instances") {
+					body = instances
+				}
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+				return
+			}
+			var requestEvent calendar.Event
+			err := json.NewDecoder(r.Body).Decode(&requestEvent)
+			assert.NoError(t, err)
+			handleWrite(r, requestEvent)
+			_, err = w.Write([]byte(`{}`))
+			assert.NoError(t, err)
+		}))
+	}
+
+	t.Run("SuccessAll", func(t *testing.T) {
+		requests := []string{}
+		server := getServer(&requests, instance, series, func(r *http.Request, requestEvent calendar.Event) {
+			assert.Equal(t, "2022-01-03T11:00:00Z", requestEvent.Start.DateTime)
+			assert.Equal(t, "UTC", requestEvent.Start.TimeZone)
+		})
+		defer server.Close()
+		googleCalendar := GoogleCalendarSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{CalendarModifyURL: &server.URL}}}
+
+		datetimeStart := time.Date(2022, time.January, 10, 11, 0, 0, 0, time.UTC)
+		err := googleCalendar.ModifyEvent(db, userID, accountID, "instance", &EventModifyObject{
+			AccountID:       accountID,
+			DatetimeStart:   &datetimeStart,
+			RecurrenceScope: "all",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET /calendars/duccount_id/events/instance",
+			"GET /calendars/duccount_id/events/series",
+			"PATCH /calendars/duccount_id/events/series",
+		}, requests)
+	})
+	t.Run("SuccessAllMovedInstance", func(t *testing.T) {
+		movedInstance := `{"id":"instance","recurringEventId":"series","originalStartTime":{"dateTime":"2022-01-10T10:00:00Z"},"start":{"dateTime":"2022-01-10T12:00:00Z"},"end":{"dateTime":"2022-01-10T13:00:00Z"}}`
+		requests := []string{}
+		server := getServer(&requests, movedInstance, series, func(r *http.Request, requestEvent calendar.Event) {
+			// the offset is taken from the original start of the instance
+			assert.Equal(t, "2022-01-03T13:00:00Z", requestEvent.Start.DateTime)
+			assert.Equal(t, "2022-01-03T14:00:00Z", requestEvent.End.DateTime)
+			assert.Equal(t, "UTC", requestEvent.End.TimeZone)
+		})
+		defer server.Close()
+		googleCalendar := GoogleCalendarSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{CalendarModifyURL: &server.URL}}}
+
+		datetimeStart := time.Date(2022, time.January, 10, 13, 0, 0, 0, time.UTC)
+		datetimeEnd := time.Date(2022, time.January, 10, 14, 0, 0, 0, time.UTC)
+		err := googleCalendar.ModifyEvent(db, userID, accountID, "instance", &EventModifyObject{
+			AccountID:       accountID,
+			DatetimeStart:   &datetimeStart,
+			DatetimeEnd:     &datetimeEnd,
+			RecurrenceScope: "all",
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("SuccessFollowing", func(t *testing.T) {
+		requests := []string{}
+		server := getServer(&requests, instance, series, func(r *http.Request, requestEvent calendar.Event) {
+			if r.Method == "PATCH" {
+				assert.Equal(t, []string{"RRULE:FREQ=WEEKLY;UNTIL=20220110T095959Z"}, requestEvent.Recurrence)
+				return
+			}
+			assert.Equal(t, "new sync", requestEvent.Summary)
+			assert.Equal(t, "2022-01-10T10:00:00Z", requestEvent.Start.DateTime)
+			// one instance stays in the original series
+			assert.Equal(t, []string{"RRULE:FREQ=WEEKLY;COUNT=9"}, requestEvent.Recurrence)
+		})
+		defer server.Close()
+		googleCalendar := GoogleCalendarSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{CalendarModifyURL: &server.URL}}}
+
+		summary := "new sync"
+		err := googleCalendar.ModifyEvent(db, userID, accountID, "instance", &EventModifyObject{
+			AccountID:       accountID,
+			Summary:         &summary,
+			RecurrenceScope: "following",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET /calendars/duccount_id/events/instance",
+			"GET /calendars/duccount_id/events/series",
+			"GET /calendars/duccount_id/events/series/instances",
+			"PATCH /calendars/duccount_id/events/series",
+			"POST /calendars/duccount_id/events",
+		}, requests)
+	})
+	t.Run("SuccessFollowingAllDay", func(t *testing.T) {
+		allDayInstance := `{"id":"instance","recurringEventId":"series","originalStartTime":{"date":"2022-01-10"},"start":{"date":"2022-01-10"},"end":{"date":"2022-01-11"}}`
+		allDaySeries := `{"id":"series","start":{"date":"2022-01-03"},"end":{"date":"2022-01-04"},"summary":"weekly sync","recurrence":["RRULE:FREQ=WEEKLY"]}`
+		requests := []string{}
+		server := getServer(&requests, allDayInstance, allDaySeries, func(r *http.Request, requestEvent calendar.Event) {
+			if r.Method == "PATCH" {
+				assert.Equal(t, []string{"RRULE:FREQ=WEEKLY;UNTIL=20220109"}, requestEvent.Recurrence)
+				return
+			}
+			assert.Equal(t, "2022-01-10", requestEvent.Start.Date)
+			assert.Equal(t, "2022-01-11", requestEvent.End.Date)
+			assert.Equal(t, []string{"RRULE:FREQ=WEEKLY"}, requestEvent.Recurrence)
+		})
+		defer server.Close()
+		googleCalendar := GoogleCalendarSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{CalendarModifyURL: &server.URL}}}
+
+		summary := "new sync"
+		err := googleCalendar.ModifyEvent(db, userID, accountID, "instance", &EventModifyObject{
+			AccountID:       accountID,
+			Summary:         &summary,
+			RecurrenceScope: "following",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET /calendars/duccount_id/events/instance",
+			"GET /calendars/duccount_id/events/series",
+			"PATCH /calendars/duccount_id/events/series",
+			"POST /calendars/duccount_id/events",
+		}, requests)
+	})
+	t.Run("FirstAllDayInstanceModifiesSeries", func(t *testing.T) {
+		firstInstance := `{"id":"instance","recurringEventId":"series","originalStartTime":{"date":"2022-01-03"},"start":{"date":"2022-01-03"},"end":{"date":"2022-01-04"}}`
+		allDaySeries := `{"id":"series","start":{"date":"2022-01-03"},"end":{"date":"2022-01-04"},"recurrence":["RRULE:FREQ=WEEKLY"]}`
+		requests := []string{}
+		server := getServer(&requests, firstInstance, allDaySeries, func(r *http.Request, requestEvent calendar.Event) {})
+		defer server.Close()
+		googleCalendar := GoogleCalendarSource{Google: GoogleService{OverrideURLs: GoogleURLOverrides{CalendarModifyURL: &server.URL}}}
+
+		summary := "new sync"
+		err := googleCalendar.ModifyEvent(db, userID, accountID, "instance", &EventModifyObject{
+			AccountID:       accountID,
+			Summary:         &summary,
+			RecurrenceScope: "following",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"GET /calendars/duccount_id/events/instance",
+			"GET /calendars/duccount_id/events/series",
+			"PATCH /calendars/duccount_id/events/series",
+		}, requests)
+	})
+}
+
+func TestTruncateRecurrence(t *testing.T) {
+	until := "20220110T095959Z"
+	assert.Equal(t,
+		[]string{"RRULE:FREQ=WEEKLY;BYDAY=MO;UNTIL=20220110T095959Z", "EXDATE:20220103T100000Z"},
+		truncateRecurrence([]string{"RRULE:FREQ=WEEKLY;COUNT=10;BYDAY=MO", "EXDATE:20220103T100000Z"}, until),
+	)
+	assert.Equal(t,
+		[]string{"RRULE:FREQ=DAILY;UNTIL=20220110T095959Z"},
+		truncateRecurrence([]string{"RRULE:FREQ=DAILY;UNTIL=20230101T000000Z"}, until),
+	)
+}
+
+func TestReduceRecurrenceCount(t *testing.T) {
+	assert.Equal(t,
+		[]string{"RRULE:FREQ=WEEKLY;COUNT=7;BYDAY=MO", "EXDATE:20220103T100000Z"},
+		reduceRecurrenceCount([]string{"RRULE:FREQ=WEEKLY;COUNT=10;BYDAY=MO", "EXDATE:20220103T100000Z"}, 3),
+	)
+	assert.Equal(t,
+		[]string{"RRULE:FREQ=DAILY;UNTIL=20230101T000000Z"},
+		reduceRecurrenceCount([]string{"RRULE:FREQ=DAILY;UNTIL=20230101T000000Z"}, 3),
+	)
+}
+
 func assertCalendarEventsEqual(t *testing.T, a *database.CalendarEvent, b *database.CalendarEvent) {
 	assert.Equal(t, a.DatetimeStart, b.DatetimeStart)
 	assert.Equal(t, a.DatetimeEnd, b.DatetimeEnd)
@@ -794,6 +1018,7 @@ func assertCalendarEventsEqual(t *testing.T, a *database.CalendarEvent, b *datab
 	assert.Equal(t, a.CallPlatform, b.CallPlatform)
 	assert.Equal(t, a.CallURL, b.CallURL)
 	assert.Equal(t, a.AttendeeEmails, b.AttendeeEmails)
+	assert.Equal(t, a.SelfResponseStatus, b.SelfResponseStatus)
 }
 
 func assertGcalCalendarEventsEqual(t *testing.T, a *calendar.Event, b *calendar.Event) {
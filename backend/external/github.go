@@ -34,6 +34,9 @@ type GithubConfigValues struct {
 	ListRepositoriesURL         *string
 	ListUserTeamsURL            *string
 	PullRequestModifiedURL      *string
+	ListOrganizationMembersURL  *string
+	ListTeamMembersURL          *string
+	ListUserOrganizationsURL    *string
 }
 
 type GithubConfig struct {
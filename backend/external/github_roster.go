@@ -0,0 +1,209 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/google/go-github/v45/github"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/oauth2"
+)
+
+// a GitHub organization, or a team within it, which the team members can be synced from
+type GithubRoster struct {
+	AccountID    string
+	Organization string
+	// empty for the whole organization
+	TeamSlug string
+	Name     string
+}
+
+type GithubRosterMember struct {
+	Login string
+	Name  string
+	// GitHub only allows verified addresses as the public email of a profile
+	Email string
+	// false when the profile couldn't be fetched, so the name and email are unknown rather than empty
+	IsProfileFetched bool
+}
+
+// GetRosters lists the organizations the linked GitHub account can see, and the teams it belongs to
+func (githubService GithubService) GetRosters(db *mongo.Database, userID primitive.ObjectID, accountID string) ([]GithubRoster, error) {
+	extCtx, cancel := context.WithTimeout(context.Background(), constants.ExternalTimeout)
+	defer cancel()
+	token, err := githubService.getRosterToken(db, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	userTeamsResultChan := make(chan GithubUserTeamsResult)
+	go getUserTeams(extCtx, getRosterGithubClient(extCtx, token), githubService.Config.ConfigValues.ListUserTeamsURL, userTeamsResultChan)
+	// need a separate client for each call so that override url setting is threadsafe
+	organizations, err := listGithubUserOrganizations(extCtx, getRosterGithubClient(extCtx, token), githubService.Config.ConfigValues.ListUserOrganizationsURL)
+	userTeamsResult := <-userTeamsResultChan
+	if err != nil {
+		return nil, err
+	}
+	if userTeamsResult.Error != nil {
+		return nil, userTeamsResult.Error
+	}
+
+	organizationRosters := []GithubRoster{}
+	teamRosters := []GithubRoster{}
+	seenOrganizations := map[string]bool{}
+	addOrganizationRoster := func(organization string) {
+		if seenOrganizations[organization] {
+			return
+		}
+		seenOrganizations[organization] = true
+		organizationRosters = append(organizationRosters, GithubRoster{
+			AccountID:    accountID,
+			Organization: organization,
+			Name:         organization,
+		})
+	}
+	// the account can belong to an organization without being on any of its teams
+	for _, organization := range organizations {
+		if organization.GetLogin() != "" {
+			addOrganizationRoster(organization.GetLogin())
+		}
+	}
+	for _, team := range userTeamsResult.UserTeams {
+		organization := team.GetOrganization().GetLogin()
+		if organization == "" {
+			continue
+		}
+		addOrganizationRoster(organization)
+		teamRosters = append(teamRosters, GithubRoster{
+			AccountID:    accountID,
+			Organization: organization,
+			TeamSlug:     team.GetSlug(),
+			Name:         organization + "/" + team.GetName(),
+		})
+	}
+	sort.SliceStable(teamRosters, func(i, j int) bool {
+		return teamRosters[i].Name < teamRosters[j].Name
+	})
+	return append(organizationRosters, teamRosters...), nil
+}
+
+// GetRosterMembers lists the members of an organization, or of a team when the team slug is set, along with their profiles
+func (githubService GithubService) GetRosterMembers(db *mongo.Database, userID primitive.ObjectID, accountID string, organization string, teamSlug string) ([]GithubRosterMember, error) {
+	extCtx, cancel := context.WithTimeout(context.Background(), constants.ExternalTimeout)
+	defer cancel()
+	token, err := githubService.getRosterToken(db, userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	configValues := githubService.Config.ConfigValues
+	users, err := listGithubRosterUsers(extCtx, getRosterGithubClient(extCtx, token), organization, teamSlug, configValues.ListOrganizationMembersURL, configValues.ListTeamMembersURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// the member lists only include logins, so the profiles are fetched separately, a few at a time
+	members := make([]GithubRosterMember, len(users))
+	semaphore := make(chan struct{}, constants.MAX_GITHUB_ROSTER_PROFILE_CONCURRENCY)
+	var wg sync.WaitGroup
+	for index, user := range users {
+		members[index] = GithubRosterMember{Login: user.GetLogin()}
+		wg.Add(1)
+		go func(member *GithubRosterMember) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			// each profile gets its own timeout, so the ones queued behind a large organization aren't cut short
+			profileCtx, cancel := context.WithTimeout(context.Background(), constants.ExternalTimeout)
+			defer cancel()
+			userResultChan := make(chan GithubUserResult, 1)
+			// need a separate client for each async call so that override url setting is threadsafe
+			getGithubUser(profileCtx, getRosterGithubClient(profileCtx, token), member.Login, configValues.GetUserURL, userResultChan)
+			userResult := <-userResultChan
+			// the member is still part of the roster if their profile can't be fetched
+			if userResult.Error == nil && userResult.User != nil {
+				member.Name = userResult.User.GetName()
+				member.Email = userResult.User.GetEmail()
+				member.IsProfileFetched = true
+			}
+		}(&members[index])
+	}
+	wg.Wait()
+	return members, nil
+}
+
+func (githubService GithubService) getRosterToken(db *mongo.Database, userID primitive.ObjectID, accountID string) (*oauth2.Token, error) {
+	fetchToken := githubService.Config.ConfigValues.FetchExternalAPIToken
+	if fetchToken == nil || !*fetchToken {
+		return nil, nil
+	}
+	token, err := GetGithubToken(database.GetExternalTokenCollection(db), userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, errors.New("failed to fetch Github API token")
+	}
+	return token, nil
+}
+
+func getRosterGithubClient(ctx context.Context, token *oauth2.Token) *github.Client {
+	if token == nil {
+		return github.NewClient(nil)
+	}
+	return getGithubClientFromToken(ctx, token)
+}
+
+func listGithubRosterUsers(ctx context.Context, githubClient *github.Client, organization string, teamSlug string, organizationMembersURL *string, teamMembersURL *string) ([]*github.User, error) {
+	overrideURL := organizationMembersURL
+	if teamSlug != "" {
+		overrideURL = teamMembersURL
+	}
+	err := setOverrideURL(githubClient, overrideURL)
+	if err != nil {
+		return nil, err
+	}
+	users := []*github.User{}
+	listOptions := github.ListOptions{PerPage: 100}
+	for {
+		var pageUsers []*github.User
+		var response *github.Response
+		if teamSlug != "" {
+			pageUsers, response, err = githubClient.Teams.ListTeamMembersBySlug(ctx, organization, teamSlug, &github.TeamListTeamMembersOptions{ListOptions: listOptions})
+		} else {
+			pageUsers, response, err = githubClient.Organizations.ListMembers(ctx, organization, &github.ListMembersOptions{ListOptions: listOptions})
+		}
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, pageUsers...)
+		if response == nil || response.NextPage == 0 {
+			return users, nil
+		}
+		listOptions.Page = response.NextPage
+	}
+}
+
+func listGithubUserOrganizations(ctx context.Context, githubClient *github.Client, overrideURL *string) ([]*github.Organization, error) {
+	err := setOverrideURL(githubClient, overrideURL)
+	if err != nil {
+		return nil, err
+	}
+	organizations := []*github.Organization{}
+	listOptions := github.ListOptions{PerPage: 100}
+	for {
+		// an empty user lists the organizations of the authenticated user
+		pageOrganizations, response, err := githubClient.Organizations.List(ctx, "", &listOptions)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, pageOrganizations...)
+		if response == nil || response.NextPage == 0 {
+			return organizations, nil
+		}
+		listOptions.Page = response.NextPage
+	}
+}
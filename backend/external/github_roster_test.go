@@ -0,0 +1,149 @@
+package external
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetRosters(t *testing.T) {
+	fetchExternalAPIToken := false
+	t.Run("Success", func(t *testing.T) {
+		userTeamsServer := testutils.GetMockAPIServer(t, 200, `[
+			{"name": "Platform", "slug": "platform", "organization": {"login": "GeneralTask"}},
+			{"name": "Frontend", "slug": "frontend", "organization": {"login": "GeneralTask"}},
+			{"name": "Maintainers", "slug": "maintainers", "organization": {"login": "oss-org"}}
+		]`)
+		defer userTeamsServer.Close()
+		userOrganizationsServer := testutils.GetMockAPIServer(t, 200, `[{"login": "GeneralTask"}, {"login": "no-teams-org"}]`)
+		defer userOrganizationsServer.Close()
+		githubService := GithubService{Config: GithubConfig{ConfigValues: GithubConfigValues{
+			FetchExternalAPIToken:    &fetchExternalAPIToken,
+			ListUserTeamsURL:         &userTeamsServer.URL,
+			ListUserOrganizationsURL: &userOrganizationsServer.URL,
+		}}}
+
+		rosters, err := githubService.GetRosters(nil, primitive.NewObjectID(), "exampleAccountID")
+		assert.NoError(t, err)
+		assert.Equal(t, []GithubRoster{
+			{AccountID: "exampleAccountID", Organization: "GeneralTask", Name: "GeneralTask"},
+			// organizations without any of the account's teams are listed too
+			{AccountID: "exampleAccountID", Organization: "no-teams-org", Name: "no-teams-org"},
+			{AccountID: "exampleAccountID", Organization: "oss-org", Name: "oss-org"},
+			{AccountID: "exampleAccountID", Organization: "GeneralTask", TeamSlug: "frontend", Name: "GeneralTask/Frontend"},
+			{AccountID: "exampleAccountID", Organization: "GeneralTask", TeamSlug: "platform", Name: "GeneralTask/Platform"},
+			{AccountID: "exampleAccountID", Organization: "oss-org", TeamSlug: "maintainers", Name: "oss-org/Maintainers"},
+		}, rosters)
+	})
+	t.Run("BadStatusCode", func(t *testing.T) {
+		userTeamsServer := testutils.GetMockAPIServer(t, 401, "")
+		defer userTeamsServer.Close()
+		githubService := GithubService{Config: GithubConfig{ConfigValues: GithubConfigValues{
+			FetchExternalAPIToken:    &fetchExternalAPIToken,
+			ListUserTeamsURL:         &userTeamsServer.URL,
+			ListUserOrganizationsURL: &userTeamsServer.URL,
+		}}}
+
+		rosters, err := githubService.GetRosters(nil, primitive.NewObjectID(), "exampleAccountID")
+		assert.Error(t, err)
+		assert.Nil(t, rosters)
+	})
+}
+
+func TestGetRosterMembers(t *testing.T) {
+	fetchExternalAPIToken := false
+	githubServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/orgs/GeneralTask/members":
+			_, _ = w.Write([]byte(`[{"login": "jane"}, {"login": "sam"}, {"login": "alex"}]`))
+		case "/orgs/GeneralTask/teams/platform/members":
+			_, _ = w.Write([]byte(`[{"login": "jane"}]`))
+		case "/users/jane":
+			_, _ = w.Write([]byte(`{"login": "jane", "name": "Jane Doe", "email": "jane@generaltask.com"}`))
+		case "/users/sam":
+			_, _ = w.Write([]byte(`{"login": "sam", "name": "Sam"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer githubServer.Close()
+	githubService := GithubService{Config: GithubConfig{ConfigValues: GithubConfigValues{
+		FetchExternalAPIToken:      &fetchExternalAPIToken,
+		GetUserURL:                 &githubServer.URL,
+		ListOrganizationMembersURL: &githubServer.URL,
+		ListTeamMembersURL:         &githubServer.URL,
+	}}}
+
+	t.Run("Organization", func(t *testing.T) {
+		members, err := githubService.GetRosterMembers(nil, primitive.NewObjectID(), "exampleAccountID", "GeneralTask", "")
+		assert.NoError(t, err)
+		assert.Equal(t, []GithubRosterMember{
+			{Login: "jane", Name: "Jane Doe", Email: "jane@generaltask.com", IsProfileFetched: true},
+			{Login: "sam", Name: "Sam", IsProfileFetched: true},
+			// the profile couldn't be fetched
+			{Login: "alex"},
+		}, members)
+	})
+	t.Run("Team", func(t *testing.T) {
+		members, err := githubService.GetRosterMembers(nil, primitive.NewObjectID(), "exampleAccountID", "GeneralTask", "platform")
+		assert.NoError(t, err)
+		assert.Equal(t, []GithubRosterMember{{Login: "jane", Name: "Jane Doe", Email: "jane@generaltask.com", IsProfileFetched: true}}, members)
+	})
+	t.Run("UnknownOrganization", func(t *testing.T) {
+		members, err := githubService.GetRosterMembers(nil, primitive.NewObjectID(), "exampleAccountID", "other-org", "")
+		assert.Error(t, err)
+		assert.Nil(t, members)
+	})
+	t.Run("LargeOrganization", func(t *testing.T) {
+		logins := []string{}
+		for index := 0; index < 3*constants.MAX_GITHUB_ROSTER_PROFILE_CONCURRENCY; index++ {
+			logins = append(logins, fmt.Sprintf(`{"login": "user%d"}`, index))
+		}
+		var inFlight int32
+		var maxInFlight int32
+		largeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Content-Type", "application/json")
+			if r.URL.Path == "/orgs/GeneralTask/members" {
+				_, _ = w.Write([]byte("[" + strings.Join(logins, ",") + "]"))
+				return
+			}
+			current := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				previousMax := atomic.LoadInt32(&maxInFlight)
+				if current <= previousMax || atomic.CompareAndSwapInt32(&maxInFlight, previousMax, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			login := strings.TrimPrefix(r.URL.Path, "/users/")
+			_, _ = w.Write([]byte(`{"login": "` + login + `", "name": "` + login + `"}`))
+		}))
+		defer largeServer.Close()
+		largeService := GithubService{Config: GithubConfig{ConfigValues: GithubConfigValues{
+			FetchExternalAPIToken:      &fetchExternalAPIToken,
+			GetUserURL:                 &largeServer.URL,
+			ListOrganizationMembersURL: &largeServer.URL,
+		}}}
+
+		members, err := largeService.GetRosterMembers(nil, primitive.NewObjectID(), "exampleAccountID", "GeneralTask", "")
+		assert.NoError(t, err)
+		assert.Equal(t, len(logins), len(members))
+		for index, member := range members {
+			assert.Equal(t, fmt.Sprintf("user%d", index), member.Login)
+			assert.Equal(t, member.Login, member.Name)
+			assert.True(t, member.IsProfileFetched)
+		}
+		assert.LessOrEqual(t, maxInFlight, int32(constants.MAX_GITHUB_ROSTER_PROFILE_CONCURRENCY))
+	})
+}
@@ -228,8 +228,8 @@ func (Google GoogleService) HandleSignupCallback(db *mongo.Database, params Call
 
 	var user database.User
 
-	userNew := &database.User{GoogleID: userInfo.SUB, Email: userInfo.EMAIL, Name: userInfo.Name, CreatedAt: primitive.NewDateTimeFromTime(time.Now().UTC())}
-	userChangeable := &database.UserChangeable{Email: userInfo.EMAIL, Name: userInfo.Name}
+	userNew := &database.User{GoogleID: userInfo.SUB, Email: userInfo.EMAIL, Name: userInfo.Name, CreatedAt: primitive.NewDateTimeFromTime(time.Now().UTC()), EmailLowercase: strings.ToLower(userInfo.EMAIL)}
+	userChangeable := &database.UserChangeable{Email: userInfo.EMAIL, Name: userInfo.Name, EmailLowercase: strings.ToLower(userInfo.EMAIL)}
 
 	log.Debug().Msgf("userNew: %+v", userNew)
 	userCollection.FindOneAndUpdate(context.Background(),
@@ -47,6 +47,14 @@ func (generalTask GeneralTaskTaskSource) GetPullRequests(db *mongo.Database, use
 }
 
 func (generalTask GeneralTaskTaskSource) CreateNewTask(db *mongo.Database, userID primitive.ObjectID, accountID string, task TaskCreationObject) (primitive.ObjectID, error) {
+	newTask := GetNewGeneralTask(userID, accountID, task)
+	taskCollection := database.GetTaskCollection(db)
+	insertResult, err := taskCollection.InsertOne(context.Background(), newTask)
+	return insertResult.InsertedID.(primitive.ObjectID), err
+}
+
+// returns the task which CreateNewTask inserts, for callers which insert many tasks at once
+func GetNewGeneralTask(userID primitive.ObjectID, accountID string, task TaskCreationObject) database.Task {
 	taskSection := constants.IDTaskSectionDefault
 	if task.IDTaskSection != primitive.NilObjectID {
 		taskSection = task.IDTaskSection
@@ -78,17 +86,21 @@ func (generalTask GeneralTaskTaskSource) CreateNewTask(db *mongo.Database, userI
 	if task.ParentTaskID != primitive.NilObjectID {
 		newTask.ParentTaskID = task.ParentTaskID
 	}
-
-	taskCollection := database.GetTaskCollection(db)
-	insertResult, err := taskCollection.InsertOne(context.Background(), newTask)
-	return insertResult.InsertedID.(primitive.ObjectID), err
+	newTask.WorkspaceID = task.WorkspaceID
+	newTask.AssigneeID = task.AssigneeID
+	if task.AssignedBy != primitive.NilObjectID {
+		newTask.AssignedBy = task.AssignedBy
+		newTask.AssignmentStatus = task.AssignmentStatus
+		newTask.AssignedAt = newTask.CreatedAtExternal
+	}
+	return newTask
 }
 
 func (generalTask GeneralTaskTaskSource) CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error {
 	return errors.New("has not been implemented yet")
 }
 
-func (generalTask GeneralTaskTaskSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (generalTask GeneralTaskTaskSource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string, recurrenceScope string) error {
 	return errors.New("has not been implemented yet")
 }
 
@@ -100,6 +112,7 @@ func (generalTask GeneralTaskTaskSource) ModifyEvent(db *mongo.Database, userID
 	return errors.New("has not been implemented yet")
 }
 
+// comments on General Task tasks are only stored on the task itself
 func (generalTask GeneralTaskTaskSource) AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error {
-	return errors.New("has not been implemented yet")
+	return nil
 }
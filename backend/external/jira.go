@@ -554,7 +554,7 @@ func (jira JIRASource) CreateNewEvent(db *mongo.Database, userID primitive.Objec
 	return errors.New("has not been implemented yet")
 }
 
-func (jira JIRASource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error {
+func (jira JIRASource) DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string, recurrenceScope string) error {
 	return errors.New("has not been implemented yet")
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/slack-go/slack"
 
@@ -19,6 +20,9 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// returned when the linked token was granted before the chat:write scope was requested
+var ErrSlackMissingScope = errors.New("Slack account must be reconnected to grant the chat:write scope")
+
 type SlackConfigValues struct {
 	UserInfoURL      *string
 	SavedMessagesURL *string
@@ -43,7 +47,7 @@ func getSlackConfig() SlackConfig {
 			ClientID:     config.GetConfigValue("SLACK_OAUTH_CLIENT_ID"),
 			ClientSecret: config.GetConfigValue("SLACK_OAUTH_CLIENT_SECRET"),
 			RedirectURL:  config.GetConfigValue("SERVER_URL") + "link/slack/callback/",
-			Scopes:       []string{"commands", "users:read"},
+			Scopes:       []string{"commands", "users:read", "chat:write"},
 			Endpoint: oauth2.Endpoint{
 				AuthURL:  "https://slack.com/oauth/authorize",
 				TokenURL: "https://slack.com/api/oauth.access",
@@ -57,7 +61,7 @@ func GetSlackAppConfig() SlackConfig {
 			ClientID:     config.GetConfigValue("SLACK_OAUTH_CLIENT_ID"),
 			ClientSecret: config.GetConfigValue("SLACK_OAUTH_CLIENT_SECRET"),
 			RedirectURL:  config.GetConfigValue("SERVER_URL") + "link_app/slack/",
-			Scopes:       []string{"commands", "users:read"},
+			Scopes:       []string{"commands", "users:read", "chat:write"},
 			Endpoint: oauth2.Endpoint{
 				AuthURL:  "https://slack.com/oauth/authorize",
 				TokenURL: "https://slack.com/api/oauth.v2.access",
@@ -132,3 +136,27 @@ func (slackService SlackService) HandleSignupCallback(db *mongo.Database, params
 func (slackService SlackService) CreateNewTask(userID primitive.ObjectID, accountID string, task TaskCreationObject) error {
 	return errors.New("has not been implemented yet")
 }
+
+// sends a message to the Slack user who linked the given token, used for notifications
+func SendSlackDirectMessage(externalToken database.ExternalAPIToken, overrideURL *string, text string) error {
+	var oauthToken oauth2.Token
+	err := json.Unmarshal([]byte(externalToken.Token), &oauthToken)
+	if err != nil {
+		return err
+	}
+	// account IDs are generated with GenerateSlackUserID
+	accountIDParts := strings.SplitN(externalToken.AccountID, "-", 2)
+	if len(accountIDParts) != 2 || accountIDParts[1] == "" {
+		return errors.New("invalid Slack account ID")
+	}
+
+	client := slack.New(oauthToken.AccessToken)
+	if overrideURL != nil {
+		client = slack.New(oauthToken.AccessToken, slack.OptionAPIURL(*overrideURL))
+	}
+	_, _, err = client.PostMessage(accountIDParts[1], slack.MsgOptionText(text, false))
+	if err != nil && err.Error() == "missing_scope" {
+		return ErrSlackMissingScope
+	}
+	return err
+}
@@ -29,3 +29,39 @@ func TestSlackLinkCallback(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestSendSlackDirectMessage(t *testing.T) {
+	externalToken := database.ExternalAPIToken{
+		Token:     `{"access_token": "example_access_token"}`,
+		AccountID: GenerateSlackUserID("T123", "U123"),
+	}
+	t.Run("MalformedExternalToken", func(t *testing.T) {
+		err := SendSlackDirectMessage(database.ExternalAPIToken{AccountID: externalToken.AccountID}, nil, "reminder")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidAccountID", func(t *testing.T) {
+		err := SendSlackDirectMessage(database.ExternalAPIToken{Token: externalToken.Token, AccountID: "T123"}, nil, "reminder")
+		assert.EqualError(t, err, "invalid Slack account ID")
+	})
+	t.Run("SlackError", func(t *testing.T) {
+		server := testutils.GetMockAPIServer(t, http.StatusOK, `{"ok": false, "error": "channel_not_found"}`)
+		defer server.Close()
+		overrideURL := server.URL + "/"
+		err := SendSlackDirectMessage(externalToken, &overrideURL, "reminder")
+		assert.EqualError(t, err, "channel_not_found")
+	})
+	t.Run("MissingScope", func(t *testing.T) {
+		server := testutils.GetMockAPIServer(t, http.StatusOK, `{"ok": false, "error": "missing_scope", "needed": "chat:write"}`)
+		defer server.Close()
+		overrideURL := server.URL + "/"
+		err := SendSlackDirectMessage(externalToken, &overrideURL, "reminder")
+		assert.Equal(t, ErrSlackMissingScope, err)
+	})
+	t.Run("Success", func(t *testing.T) {
+		server := testutils.GetMockAPIServer(t, http.StatusOK, `{"ok": true, "channel": "D123", "ts": "1234.5678"}`)
+		defer server.Close()
+		overrideURL := server.URL + "/"
+		err := SendSlackDirectMessage(externalToken, &overrideURL, "reminder")
+		assert.NoError(t, err)
+	})
+}
@@ -17,7 +17,7 @@ type TaskSource interface {
 	ModifyTask(db *mongo.Database, userID primitive.ObjectID, accountID string, issueID string, updateFields *database.Task, task *database.Task) error
 	CreateNewEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, event EventCreateObject) error
 	ModifyEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, eventID string, updateFields *EventModifyObject) error
-	DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string) error
+	DeleteEvent(db *mongo.Database, userID primitive.ObjectID, accountID string, externalID string, calendarID string, recurrenceScope string) error
 	AddComment(db *mongo.Database, userID primitive.ObjectID, accountID string, comment database.Comment, task *database.Task) error
 }
 
@@ -29,6 +29,11 @@ type TaskCreationObject struct {
 	IDTaskSection      primitive.ObjectID
 	ParentTaskID       primitive.ObjectID
 	SlackMessageParams database.SlackMessageParams
+	// only supported for General Task tasks
+	WorkspaceID      primitive.ObjectID
+	AssigneeID       primitive.ObjectID
+	AssignedBy       primitive.ObjectID
+	AssignmentStatus string
 }
 
 type Attendee struct {
@@ -64,4 +69,9 @@ type EventModifyObject struct {
 	DatetimeEnd       *time.Time  `json:"datetime_end"`
 	Attendees         *[]Attendee `json:"attendees"`
 	AddConferenceCall *bool       `json:"add_conference_call"`
+	// see constants.RecurrenceScope*, only the event itself is modified when empty
+	RecurrenceScope string `json:"recurrence_scope"`
+	// set through the RSVP endpoint rather than when modifying an event
+	ResponseStatus  *string `json:"-"`
+	ResponseComment *string `json:"-"`
 }
@@ -53,7 +53,7 @@ func updateGithubIndustryData(logID primitive.ObjectID, endCutoff time.Time, loo
 		logger.Error().Err(err).Msg("failed to log event")
 	}
 
-	err = saveDataPointsForPullRequests(db, pullRequestIDToValue, primitive.NilObjectID, primitive.NilObjectID)
+	err = saveDataPointsForPullRequests(db, pullRequestIDToValue, primitive.NilObjectID, primitive.NilObjectID, GetDashboardDefaultLocation())
 	if err != nil {
 		return err
 	}
@@ -81,6 +81,20 @@ func UpdateGithubTeamData(userID primitive.ObjectID, endCutoff time.Time, lookba
 		logger.Error().Err(err).Msg("failed to get dashboard team")
 		return err
 	}
+	// the team data is split into days in the timezone of the team owner
+	location := GetDashboardDefaultLocation()
+	user, err := database.GetUser(db, userID)
+	if err == nil {
+		location = GetDashboardLocation(user)
+	}
+	err = SyncWorkspaceDashboardTeamMembers(db, userID, team.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to sync workspace team members")
+	}
+	err = SyncGithubRosterDashboardTeamMembers(db, team)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to sync github roster team members")
+	}
 	teamMembers, err := database.GetDashboardTeamMembers(db, team.ID)
 	if err != nil || teamMembers == nil {
 		logger.Error().Err(err).Msg("failed to get dashboard team members")
@@ -107,7 +121,7 @@ func UpdateGithubTeamData(userID primitive.ObjectID, endCutoff time.Time, lookba
 		if !exists {
 			continue
 		}
-		err = saveDataPointsForPullRequests(db, idToPullRequest, team.ID, teamMember.ID)
+		err = saveDataPointsForPullRequests(db, idToPullRequest, team.ID, teamMember.ID, location)
 		if err != nil {
 			logger.Error().Err(err).Msgf("failed to save team %s member %s data points", team.ID, teamMember.ID)
 			return err
@@ -116,7 +130,7 @@ func UpdateGithubTeamData(userID primitive.ObjectID, endCutoff time.Time, lookba
 			teamPullRequests[externalID] = pullRequest
 		}
 	}
-	err = saveDataPointsForPullRequests(db, teamPullRequests, team.ID, primitive.NilObjectID)
+	err = saveDataPointsForPullRequests(db, teamPullRequests, team.ID, primitive.NilObjectID, location)
 	if err != nil {
 		logger.Error().Err(err).Msgf("failed to save team %s data points", team.ID)
 		return err
@@ -150,7 +164,8 @@ func getPullRequestsMapAfterCutoff(db *mongo.Database, filters []bson.M, cutoffT
 	return pullRequestIDToValue, nil
 }
 
-func saveDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[string]database.PullRequest, teamID primitive.ObjectID, individualID primitive.ObjectID) error {
+// data points are dated at midnight of the day the pull requests were opened, in the given timezone
+func saveDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[string]database.PullRequest, teamID primitive.ObjectID, individualID primitive.ObjectID, location *time.Location) error {
 	logger := logging.GetSentryLogger()
 	dateToTotalResponseTime := make(map[primitive.DateTime]int)
 	dateToPRCount := make(map[primitive.DateTime]int)
@@ -167,8 +182,8 @@ func saveDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[
 			continue
 		}
 		responseTime := int(firstCommentTime.Sub(pullRequest.CreatedAtExternal.Time()).Minutes())
-		createdAt := pullRequest.CreatedAtExternal.Time()
-		pullRequestDate := primitive.NewDateTimeFromTime(time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), constants.UTC_OFFSET, 0, 0, 0, time.UTC))
+		createdAt := pullRequest.CreatedAtExternal.Time().In(location)
+		pullRequestDate := primitive.NewDateTimeFromTime(time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), 0, 0, 0, 0, location))
 		dateToTotalResponseTime[pullRequestDate] += responseTime
 		dateToPRCount[pullRequestDate] += 1
 	}
@@ -217,6 +232,20 @@ func saveDataPointsForPullRequests(db *mongo.Database, pullRequestIDToValue map[
 	return nil
 }
 
+// GetDashboardLocation returns the timezone the user's dashboard days start in
+func GetDashboardLocation(user *database.User) *time.Location {
+	return database.GetUserLocation(user, GetDashboardDefaultLocation())
+}
+
+func GetDashboardDefaultLocation() *time.Location {
+	location, err := time.LoadLocation(constants.DashboardDefaultTimezone)
+	if err != nil {
+		// Pacific standard time, if the timezone database is missing
+		return time.FixedZone("", -8*60*60)
+	}
+	return location
+}
+
 func getPullRequestCutoffTime(endCutoff time.Time, lookbackDays int) time.Time {
 	return endCutoff.Add(-time.Hour * 24 * time.Duration(lookbackDays))
 }
@@ -114,7 +114,8 @@ func TestUpdateGithubIndustryData(t *testing.T) {
 		assert.Equal(t, 1, len(dashboardDataPoints))
 		assert.Equal(t, constants.DashboardGraphTypePRResponseTime, dashboardDataPoints[0].GraphType)
 		assert.Equal(t, 90, dashboardDataPoints[0].Value)
-		expectedDateTime, _ := time.Parse(time.RFC3339, "2023-04-15T08:00:00Z")
+		// midnight Pacific daylight time
+		expectedDateTime, _ := time.Parse(time.RFC3339, "2023-04-15T07:00:00Z")
 		assert.Equal(t, primitive.NewDateTimeFromTime(expectedDateTime), dashboardDataPoints[0].Date)
 	})
 }
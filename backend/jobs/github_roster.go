@@ -0,0 +1,206 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/logging"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func githubRosterJob() {
+	_, err := EnsureJobOnlyRunsOnceToday("github_roster")
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for github roster job")
+		return
+	}
+	defer cleanup()
+	err = syncGithubRosterDashboardTeams(db)
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to run github roster job")
+		return
+	}
+}
+
+// one team failing to sync, e.g. because its GitHub token was revoked, doesn't stop the others
+func syncGithubRosterDashboardTeams(db *mongo.Database) error {
+	var teams []database.DashboardTeam
+	cursor, err := database.GetDashboardTeamCollection(db).Find(
+		context.Background(),
+		bson.M{"github_roster_organization": bson.M{"$exists": true}},
+	)
+	if err != nil {
+		return err
+	}
+	err = cursor.All(context.Background(), &teams)
+	if err != nil {
+		return err
+	}
+	for index := range teams {
+		err = SyncGithubRosterDashboardTeamMembers(db, &teams[index])
+		if err != nil {
+			logging.GetSentryLogger().Error().Err(err).Msgf("failed to sync github roster for dashboard team: %s", teams[index].ID.Hex())
+		}
+	}
+	return nil
+}
+
+// SyncGithubRosterDashboardTeamMembers refreshes the team members from the GitHub roster the team is synced from, if any
+func SyncGithubRosterDashboardTeamMembers(db *mongo.Database, team *database.DashboardTeam) error {
+	if team.GithubRosterOrganization == "" {
+		return nil
+	}
+	githubService := external.GithubService{Config: external.GetConfig().Github}
+	rosterMembers, err := githubService.GetRosterMembers(db, team.UserID, team.GithubRosterAccountID, team.GithubRosterOrganization, team.GithubRosterTeamSlug)
+	if err != nil {
+		return err
+	}
+	return SaveGithubRosterDashboardTeamMembers(db, team.ID, rosterMembers, time.Now())
+}
+
+// SaveGithubRosterDashboardTeamMembers adds the roster members to the team, adopting members who were added by hand
+// with the same GitHub ID. Members who are no longer in the roster are flagged as removed rather than deleted.
+func SaveGithubRosterDashboardTeamMembers(db *mongo.Database, teamID primitive.ObjectID, rosterMembers []external.GithubRosterMember, syncedAt time.Time) error {
+	teamMembers, err := database.GetDashboardTeamMembers(db, teamID)
+	if err != nil {
+		return err
+	}
+	githubIDToMember := map[string]database.DashboardTeamMember{}
+	workspaceUserIDs := map[primitive.ObjectID]bool{}
+	for _, teamMember := range *teamMembers {
+		if teamMember.GithubID != "" {
+			githubIDToMember[strings.ToLower(teamMember.GithubID)] = teamMember
+		}
+		if teamMember.UserID != primitive.NilObjectID {
+			workspaceUserIDs[teamMember.UserID] = true
+		}
+	}
+
+	teamMemberCollection := database.GetDashboardTeamMemberCollection(db)
+	rosterLogins := []string{}
+	for _, rosterMember := range rosterMembers {
+		rosterLogins = append(rosterLogins, rosterMember.Login)
+		existingMember, exists := githubIDToMember[strings.ToLower(rosterMember.Login)]
+		matchedUser := getGithubRosterMemberUser(db, rosterMember)
+		// workspace members are kept in sync with their workspace
+		if existingMember.UserID != primitive.NilObjectID || (matchedUser != nil && workspaceUserIDs[matchedUser.ID]) {
+			continue
+		}
+
+		setFields := bson.M{
+			"github_id":               rosterMember.Login,
+			"is_github_roster_member": true,
+		}
+		unsetFields := bson.M{"removed_from_roster_at": ""}
+		// the match, email and name of a member whose profile couldn't be fetched are kept until the next sync
+		if exists && !rosterMember.IsProfileFetched {
+			_, err = teamMemberCollection.UpdateOne(
+				context.Background(),
+				bson.M{"_id": existingMember.ID},
+				bson.M{"$set": setFields, "$unset": unsetFields},
+			)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		name := rosterMember.Name
+		if matchedUser != nil {
+			setFields["matched_user_id"] = matchedUser.ID
+			setFields["email"] = matchedUser.Email
+			if name == "" {
+				name = matchedUser.Name
+			}
+		} else {
+			unsetFields["matched_user_id"] = ""
+			if rosterMember.Email != "" {
+				setFields["email"] = rosterMember.Email
+			}
+		}
+		if name == "" {
+			name = rosterMember.Login
+		}
+		// names typed in by hand are kept
+		if !exists || existingMember.IsGithubRosterMember {
+			setFields["name"] = name
+		}
+
+		filter := bson.M{"$and": []bson.M{
+			{"team_id": teamID},
+			{"github_id": rosterMember.Login},
+		}}
+		if exists {
+			filter = bson.M{"_id": existingMember.ID}
+		}
+		_, err = teamMemberCollection.UpdateOne(
+			context.Background(),
+			filter,
+			bson.M{
+				"$set":         setFields,
+				"$unset":       unsetFields,
+				"$setOnInsert": bson.M{"created_at": primitive.NewDateTimeFromTime(syncedAt)},
+			},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = teamMemberCollection.UpdateMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"team_id": teamID},
+			{"is_github_roster_member": true},
+			{"github_id": bson.M{"$nin": rosterLogins}},
+			{"removed_from_roster_at": bson.M{"$exists": false}},
+		}},
+		bson.M{"$set": bson.M{"removed_from_roster_at": primitive.NewDateTimeFromTime(syncedAt)}},
+	)
+	if err != nil {
+		return err
+	}
+	_, err = database.GetDashboardTeamCollection(db).UpdateOne(
+		context.Background(),
+		bson.M{"_id": teamID},
+		bson.M{"$set": bson.M{"github_roster_synced_at": primitive.NewDateTimeFromTime(syncedAt)}},
+	)
+	return err
+}
+
+// GitHub only shows verified addresses as a profile's public email, so it can be matched against General Task accounts.
+// Members without a public email are matched to the user who linked their GitHub account, if any.
+func getGithubRosterMemberUser(db *mongo.Database, rosterMember external.GithubRosterMember) *database.User {
+	if rosterMember.Email != "" {
+		user, err := database.GetUserByEmail(db, rosterMember.Email)
+		if err == nil {
+			return user
+		}
+	}
+	var token database.ExternalAPIToken
+	err := database.GetExternalTokenCollection(db).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"service_id": external.TASK_SERVICE_ID_GITHUB},
+			{"display_id": rosterMember.Login},
+		}},
+	).Decode(&token)
+	if err != nil {
+		return nil
+	}
+	user, err := database.GetUser(db, token.UserID)
+	if err != nil {
+		return nil
+	}
+	return user
+}
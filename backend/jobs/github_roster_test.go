@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSaveGithubRosterDashboardTeamMembers(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	syncedAt := time.Date(2023, time.April, 20, 19, 0, 0, 0, time.UTC)
+	team, err := database.GetOrCreateDashboardTeam(db, primitive.NewObjectID())
+	assert.NoError(t, err)
+	userEmail := "roster_" + primitive.NewObjectID().Hex() + "@generaltask.com"
+	userResult, err := database.GetUserCollection(db).InsertOne(context.Background(), database.User{Email: userEmail, Name: "Jane", EmailLowercase: userEmail})
+	assert.NoError(t, err)
+	userID := userResult.InsertedID.(primitive.ObjectID)
+	_, err = database.GetDashboardTeamMemberCollection(db).InsertOne(context.Background(), database.DashboardTeamMember{
+		TeamID:   team.ID,
+		Name:     "Sam (typed in)",
+		GithubID: "SAM",
+	})
+	assert.NoError(t, err)
+	getTeamMembers := func() map[string]database.DashboardTeamMember {
+		teamMembers, err := database.GetDashboardTeamMembers(db, team.ID)
+		assert.NoError(t, err)
+		githubIDToMember := map[string]database.DashboardTeamMember{}
+		for _, teamMember := range *teamMembers {
+			githubIDToMember[teamMember.GithubID] = teamMember
+		}
+		return githubIDToMember
+	}
+
+	t.Run("Import", func(t *testing.T) {
+		err := SaveGithubRosterDashboardTeamMembers(db, team.ID, []external.GithubRosterMember{
+			{Login: "jane", Email: userEmail, IsProfileFetched: true},
+			{Login: "sam", Name: "Sam", IsProfileFetched: true},
+		}, syncedAt)
+		assert.NoError(t, err)
+
+		teamMembers := getTeamMembers()
+		assert.Equal(t, 2, len(teamMembers))
+		assert.True(t, teamMembers["jane"].IsGithubRosterMember)
+		assert.Equal(t, userID, teamMembers["jane"].MatchedUserID)
+		assert.Equal(t, "Jane", teamMembers["jane"].Name)
+		// the member added by hand is adopted rather than duplicated
+		assert.True(t, teamMembers["sam"].IsGithubRosterMember)
+		assert.Equal(t, "Sam (typed in)", teamMembers["sam"].Name)
+		assert.Equal(t, primitive.NilObjectID, teamMembers["sam"].MatchedUserID)
+
+		var updatedTeam database.DashboardTeam
+		assert.NoError(t, database.GetDashboardTeamCollection(db).FindOne(context.Background(), bson.M{"_id": team.ID}).Decode(&updatedTeam))
+		assert.Equal(t, syncedAt, updatedTeam.GithubRosterSyncedAt.Time().UTC())
+	})
+	t.Run("FlagRemoved", func(t *testing.T) {
+		err := SaveGithubRosterDashboardTeamMembers(db, team.ID, []external.GithubRosterMember{{Login: "jane", Email: userEmail, IsProfileFetched: true}}, syncedAt.Add(time.Hour))
+		assert.NoError(t, err)
+
+		teamMembers := getTeamMembers()
+		assert.Equal(t, 2, len(teamMembers))
+		assert.Equal(t, primitive.DateTime(0), teamMembers["jane"].RemovedFromRosterAt)
+		assert.Equal(t, syncedAt.Add(time.Hour), teamMembers["sam"].RemovedFromRosterAt.Time().UTC())
+	})
+	t.Run("Rejoin", func(t *testing.T) {
+		err := SaveGithubRosterDashboardTeamMembers(db, team.ID, []external.GithubRosterMember{{Login: "jane", Email: userEmail, IsProfileFetched: true}, {Login: "sam"}}, syncedAt.Add(2*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, primitive.DateTime(0), getTeamMembers()["sam"].RemovedFromRosterAt)
+	})
+	t.Run("ProfileNotFetched", func(t *testing.T) {
+		err := SaveGithubRosterDashboardTeamMembers(db, team.ID, []external.GithubRosterMember{{Login: "jane"}, {Login: "sam"}}, syncedAt.Add(3*time.Hour))
+		assert.NoError(t, err)
+
+		// the match from an earlier sync is kept rather than flapping
+		teamMembers := getTeamMembers()
+		assert.Equal(t, userID, teamMembers["jane"].MatchedUserID)
+		assert.Equal(t, userEmail, teamMembers["jane"].Email)
+		assert.Equal(t, "Jane", teamMembers["jane"].Name)
+		assert.Equal(t, primitive.DateTime(0), teamMembers["jane"].RemovedFromRosterAt)
+	})
+}
@@ -0,0 +1,148 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/logging"
+	"github.com/GeneralTask/task-manager/backend/settings"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// a way of delivering notifications to a user, each channel can be turned on or off with its setting
+type NotificationChannel interface {
+	GetName() string
+	GetSetting() settings.SettingDefinition
+	Send(db *mongo.Database, user *database.User, notification *database.Notification) error
+}
+
+func getNotificationChannels() []NotificationChannel {
+	return []NotificationChannel{
+		InAppNotificationChannel{},
+		EmailNotificationChannel{},
+		SlackNotificationChannel{},
+	}
+}
+
+// sends a notification outside of a job run (e.g. a mention in a comment) through the user's enabled channels
+func SendNotification(db *mongo.Database, notification *database.Notification) error {
+	user, err := database.GetUser(db, notification.UserID)
+	if err != nil {
+		return err
+	}
+	channels, err := getEnabledNotificationChannels(db, user.ID, getNotificationChannels())
+	if err != nil || len(channels) == 0 {
+		return err
+	}
+	return deliverNotification(db, user, notification, channels)
+}
+
+func getEnabledNotificationChannels(db *mongo.Database, userID primitive.ObjectID, channels []NotificationChannel) ([]NotificationChannel, error) {
+	enabledChannels := []NotificationChannel{}
+	for _, channel := range channels {
+		enabled, err := settings.GetUserSettingValue(db, userID, channel.GetSetting())
+		if err != nil {
+			return nil, err
+		}
+		if enabled == "true" {
+			enabledChannels = append(enabledChannels, channel)
+		}
+	}
+	return enabledChannels, nil
+}
+
+// sends the notification through each channel and stores it with the channels which succeeded
+func deliverNotification(db *mongo.Database, user *database.User, notification *database.Notification, channels []NotificationChannel) error {
+	notification.Channels = []string{}
+	for _, channel := range channels {
+		err := channel.Send(db, user, notification)
+		if err != nil {
+			logging.GetSentryLogger().Error().Err(err).Msgf("failed to send %s notification through %s", notification.Type, channel.GetName())
+			continue
+		}
+		notification.Channels = append(notification.Channels, channel.GetName())
+	}
+	_, err := database.GetNotificationCollection(db).InsertOne(context.Background(), notification)
+	return err
+}
+
+// in-app notifications are delivered by being stored, the inbox endpoint reads them back
+type InAppNotificationChannel struct{}
+
+func (channel InAppNotificationChannel) GetName() string {
+	return constants.NotificationChannelInApp
+}
+
+func (channel InAppNotificationChannel) GetSetting() settings.SettingDefinition {
+	return settings.NotificationChannelInAppSetting
+}
+
+func (channel InAppNotificationChannel) Send(db *mongo.Database, user *database.User, notification *database.Notification) error {
+	return nil
+}
+
+type EmailNotificationChannel struct{}
+
+func (channel EmailNotificationChannel) GetName() string {
+	return constants.NotificationChannelEmail
+}
+
+func (channel EmailNotificationChannel) GetSetting() settings.SettingDefinition {
+	return settings.NotificationChannelEmailSetting
+}
+
+func (channel EmailNotificationChannel) Send(db *mongo.Database, user *database.User, notification *database.Notification) error {
+	if user.Email == "" {
+		return errors.New("user has no email address")
+	}
+	return utils.SendEmail(user.Email, notification.Title, notification.Body)
+}
+
+type SlackNotificationChannel struct {
+	OverrideURL *string
+}
+
+func (channel SlackNotificationChannel) GetName() string {
+	return constants.NotificationChannelSlack
+}
+
+func (channel SlackNotificationChannel) GetSetting() settings.SettingDefinition {
+	return settings.NotificationChannelSlackSetting
+}
+
+func (channel SlackNotificationChannel) Send(db *mongo.Database, user *database.User, notification *database.Notification) error {
+	tokens, err := database.GetExternalTokens(db, user.ID, external.TASK_SERVICE_ID_SLACK)
+	if err != nil {
+		return err
+	}
+	var slackToken *database.ExternalAPIToken
+	for index := range *tokens {
+		if !(*tokens)[index].IsBadToken {
+			slackToken = &(*tokens)[index]
+			break
+		}
+	}
+	if slackToken == nil {
+		return errors.New("user has no linked Slack account which can send messages")
+	}
+	// only message the first workspace so users with several linked workspaces are not notified repeatedly
+	err = external.SendSlackDirectMessage(*slackToken, channel.OverrideURL, notification.Title+"\n"+notification.Body)
+	if err == external.ErrSlackMissingScope {
+		// accounts linked before chat:write was requested are shown as needing to be relinked
+		_, updateErr := database.GetExternalTokenCollection(db).UpdateOne(
+			context.Background(),
+			bson.M{"_id": slackToken.ID},
+			bson.M{"$set": bson.M{"is_bad_token": true}},
+		)
+		if updateErr != nil {
+			logging.GetSentryLogger().Error().Err(updateErr).Msg("failed to mark Slack token as needing to be relinked")
+		}
+	}
+	return err
+}
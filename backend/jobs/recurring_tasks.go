@@ -0,0 +1,377 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/logging"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	lock "github.com/square/mongo-lock"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func recurringTasksJob() {
+	_, err := EnsureJobOnlyRunsOncePerHour("recurring_tasks")
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for recurring tasks job")
+		return
+	}
+	defer cleanup()
+	err = generateRecurringTasks(db, bson.M{}, time.Now())
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to run recurring tasks job")
+		return
+	}
+}
+
+// GenerateRecurringTasksForUser creates any tasks which are due for the user's recurring task templates
+func GenerateRecurringTasksForUser(db *mongo.Database, userID primitive.ObjectID, currentTime time.Time) error {
+	return generateRecurringTasks(db, bson.M{"user_id": userID}, currentTime)
+}
+
+func generateRecurringTasks(db *mongo.Database, filter bson.M, currentTime time.Time) error {
+	var templates []database.RecurringTaskTemplate
+	cursor, err := database.GetRecurringTaskTemplateCollection(db).Find(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			filter,
+			{"is_deleted": false},
+			{"is_enabled": true},
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	err = cursor.All(context.Background(), &templates)
+	if err != nil {
+		return err
+	}
+
+	lockClient := lock.NewClient(database.GetJobLocksCollection(db))
+	err = lockClient.CreateIndexes(context.Background())
+	if err != nil {
+		return err
+	}
+	logger := logging.GetSentryLogger()
+	locations := make(map[primitive.ObjectID]*time.Location)
+	for _, template := range templates {
+		location, ok := locations[template.UserID]
+		if !ok {
+			user, err := database.GetUser(db, template.UserID)
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to load user for recurring task template")
+				continue
+			}
+			location = getUserLocation(db, user)
+			locations[template.UserID] = location
+		}
+		err = generateTasksForTemplateWithLock(db, lockClient, template, currentTime, location)
+		if err == lock.ErrAlreadyLocked {
+			// another job or manual trigger is already generating tasks for this template
+			continue
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to generate tasks for recurring task template")
+		}
+	}
+	return nil
+}
+
+func generateTasksForTemplateWithLock(db *mongo.Database, lockClient *lock.Client, template database.RecurringTaskTemplate, currentTime time.Time, location *time.Location) error {
+	lockID := primitive.NewObjectID().Hex()
+	err := lockClient.XLock(
+		context.Background(),
+		"recurring_task_template_"+template.ID.Hex(),
+		lockID,
+		lock.LockDetails{TTL: constants.RECURRING_TASK_TEMPLATE_LOCK_TTL_SECONDS},
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, err := lockClient.Unlock(context.Background(), lockID)
+		if err != nil {
+			logging.GetSentryLogger().Error().Err(err).Msg("failed to unlock recurring task template")
+		}
+	}()
+
+	// re-read the template now that it is locked, as another run may have just generated its tasks
+	var lockedTemplate database.RecurringTaskTemplate
+	err = database.GetRecurringTaskTemplateCollection(db).FindOne(context.Background(), bson.M{"_id": template.ID}).Decode(&lockedTemplate)
+	if err != nil {
+		return err
+	}
+	return generateTasksForTemplate(db, lockedTemplate, currentTime, location)
+}
+
+func generateTasksForTemplate(db *mongo.Database, template database.RecurringTaskTemplate, currentTime time.Time, location *time.Location) error {
+	occurrences, err := getRecurringTaskOccurrences(template, template.LastBackfillDatetime.Time(), currentTime, location)
+	if err != nil {
+		return err
+	}
+	// templates which replace existing tasks only keep a task for the latest occurrence
+	if len(occurrences) > 0 && template.ReplaceExisting != nil && *template.ReplaceExisting {
+		occurrences = occurrences[len(occurrences)-1:]
+		_, err = database.GetTaskCollection(db).UpdateMany(
+			context.Background(),
+			bson.M{"$and": []bson.M{
+				{"recurring_task_template_id": template.ID},
+				{"user_id": template.UserID},
+				{"recurring_task_occurrence_date": bson.M{"$ne": getOccurrenceDate(occurrences[0])}},
+			}},
+			bson.M{"$set": bson.M{"is_deleted": true}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	for _, occurrence := range occurrences {
+		err = upsertRecurringTask(db, template, occurrence, currentTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = database.GetRecurringTaskTemplateCollection(db).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": template.ID},
+			{"user_id": template.UserID},
+		}},
+		bson.M{"$set": bson.M{"last_backfill_datetime": primitive.NewDateTimeFromTime(currentTime)}},
+	)
+	return err
+}
+
+func getOccurrenceDate(occurrence time.Time) string {
+	return occurrence.Format(constants.YEAR_MONTH_DAY_FORMAT)
+}
+
+// tasks are keyed by template and occurrence date so that a task is only created once per occurrence
+func upsertRecurringTask(db *mongo.Database, template database.RecurringTaskTemplate, occurrence time.Time, currentTime time.Time) error {
+	if template.SourceID != "" && template.SourceID != external.TASK_SOURCE_ID_GT_TASK {
+		return createExternalRecurringTask(db, template, occurrence)
+	}
+	completed := false
+	deleted := false
+	occurrenceDate := getOccurrenceDate(occurrence)
+	task := database.Task{
+		UserID:                      template.UserID,
+		RecurringTaskTemplateID:     template.ID,
+		RecurringTaskOccurrenceDate: occurrenceDate,
+		IDExternal:                  primitive.NewObjectID().Hex(),
+		SourceID:                    external.TASK_SOURCE_ID_GT_TASK,
+		SourceAccountID:             external.GeneralTaskDefaultAccountID,
+		Title:                       template.Title,
+		Body:                        template.Body,
+		IDTaskSection:               template.IDTaskSection,
+		PriorityNormalized:          template.PriorityNormalized,
+		TimeAllocation:              template.TimeAllocation,
+		DueDate:                     getRecurringTaskDueDate(template, occurrence),
+		IsCompleted:                 &completed,
+		IsDeleted:                   &deleted,
+		CreatedAtExternal:           primitive.NewDateTimeFromTime(occurrence),
+		UpdatedAt:                   primitive.NewDateTimeFromTime(currentTime),
+	}
+	result, err := database.GetTaskCollection(db).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": template.UserID},
+			{"recurring_task_template_id": template.ID},
+			{"recurring_task_occurrence_date": occurrenceDate},
+		}},
+		bson.M{"$setOnInsert": task},
+		options.Update().SetUpsert(true),
+	)
+	// a concurrent run inserted the task first
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	// subtasks were already created with the existing task
+	if result.UpsertedID == nil {
+		return nil
+	}
+	return createRecurringSubtasks(db, template, result.UpsertedID.(primitive.ObjectID), template.Subtasks)
+}
+
+// external sources create their own tasks, so these are only created if no task exists for the occurrence yet
+func createExternalRecurringTask(db *mongo.Database, template database.RecurringTaskTemplate, occurrence time.Time) error {
+	occurrenceDate := getOccurrenceDate(occurrence)
+	count, err := database.GetTaskCollection(db).CountDocuments(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": template.UserID},
+			{"recurring_task_template_id": template.ID},
+			{"recurring_task_occurrence_date": occurrenceDate},
+		}},
+	)
+	if err != nil || count > 0 {
+		return err
+	}
+	taskSourceResult, err := external.GetConfig().GetSourceResult(template.SourceID)
+	if err != nil {
+		return err
+	}
+	if !taskSourceResult.Details.CanCreateTask {
+		return fmt.Errorf("task source %s cannot create tasks", template.SourceID)
+	}
+	taskCreationObject := external.TaskCreationObject{
+		TimeAllocation: template.TimeAllocation,
+		IDTaskSection:  template.IDTaskSection,
+	}
+	if template.Title != nil {
+		taskCreationObject.Title = *template.Title
+	}
+	if template.Body != nil {
+		taskCreationObject.Body = *template.Body
+	}
+	if dueDate := getRecurringTaskDueDate(template, occurrence); dueDate != nil {
+		dueDateTime := dueDate.Time()
+		taskCreationObject.DueDate = &dueDateTime
+	}
+	taskID, err := taskSourceResult.Source.CreateNewTask(db, template.UserID, template.SourceAccountID, taskCreationObject)
+	if err != nil {
+		return err
+	}
+	_, err = database.GetTaskCollection(db).UpdateOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"_id": taskID},
+			{"user_id": template.UserID},
+		}},
+		bson.M{"$set": bson.M{
+			"recurring_task_template_id":     template.ID,
+			"recurring_task_occurrence_date": occurrenceDate,
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	return createRecurringSubtasks(db, template, taskID, template.Subtasks)
+}
+
+func getRecurringTaskDueDate(template database.RecurringTaskTemplate, occurrence time.Time) *primitive.DateTime {
+	if template.DueDateOffsetDays == nil {
+		return nil
+	}
+	// due dates are stored as UTC midnight of the occurrence's local calendar day
+	year, month, day := occurrence.Date()
+	dueDate := primitive.NewDateTimeFromTime(time.Date(year, month, day+*template.DueDateOffsetDays, 0, 0, 0, 0, time.UTC))
+	return &dueDate
+}
+
+// subtasks are created in the same section as the generated task
+func createRecurringSubtasks(db *mongo.Database, template database.RecurringTaskTemplate, parentID primitive.ObjectID, subtasks *[]database.RecurringTaskTemplateSubtask) error {
+	if subtasks == nil {
+		return nil
+	}
+	for _, subtask := range *subtasks {
+		subtaskID, err := external.GeneralTaskTaskSource{}.CreateNewTask(db, template.UserID, external.GeneralTaskDefaultAccountID, external.TaskCreationObject{
+			Title:          subtask.Title,
+			Body:           subtask.Body,
+			TimeAllocation: subtask.TimeAllocation,
+			IDTaskSection:  template.IDTaskSection,
+			ParentTaskID:   parentID,
+		})
+		if err != nil {
+			return err
+		}
+		err = createRecurringSubtasks(db, template, subtaskID, &subtask.Subtasks)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns the times in the user's timezone at which the template should have created tasks since the last backfill
+func getRecurringTaskOccurrences(template database.RecurringTaskTemplate, lastBackfillTime time.Time, currentTime time.Time, location *time.Location) ([]time.Time, error) {
+	recurrence, err := getTemplateRecurrence(template)
+	if err != nil {
+		return nil, err
+	}
+	lastBackfillTime = lastBackfillTime.In(location)
+	currentTime = currentTime.In(location)
+	if recurrence.Start == nil {
+		// rules without a DTSTART, such as every other week, are counted from when the template was created
+		start := lastBackfillTime
+		if template.CreatedAt != 0 && template.CreatedAt.Time().Before(start) {
+			start = template.CreatedAt.Time().In(location)
+		}
+		recurrence.Start = &start
+	}
+
+	secondsOfDay := 0
+	if template.TimeOfDaySecondsToCreateTask != nil {
+		secondsOfDay = *template.TimeOfDaySecondsToCreateTask
+	}
+	occurrences := []time.Time{}
+	for _, date := range recurrence.Between(lastBackfillTime, currentTime) {
+		occurrence := time.Date(date.Year(), date.Month(), date.Day(), secondsOfDay/3600, (secondsOfDay%3600)/60, secondsOfDay%60, 0, location)
+		if !occurrence.Before(lastBackfillTime) && currentTime.After(occurrence) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences, nil
+}
+
+// templates without a recurrence rule use the equivalent rule for their recurrence rate
+func getTemplateRecurrence(template database.RecurringTaskTemplate) (*utils.Recurrence, error) {
+	if template.RecurrenceRule != nil && *template.RecurrenceRule != "" {
+		return utils.ParseRecurrence(*template.RecurrenceRule)
+	}
+	rule, err := getRecurrenceRateRule(template)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ParseRecurrence(rule)
+}
+
+func getRecurrenceRateRule(template database.RecurringTaskTemplate) (string, error) {
+	// there are certain values that must be present depending on the recurrence type
+	if template.RecurrenceRate == nil || template.TimeOfDaySecondsToCreateTask == nil {
+		return "", errors.New("invalid template value")
+	}
+	rate := *template.RecurrenceRate
+	if (rate == constants.RecurrenceRateWeekly || rate == constants.RecurrenceRateMonthly || rate == constants.RecurrenceRateAnnually) && template.DayToCreateTask == nil {
+		return "", errors.New("invalid template value")
+	}
+	if rate == constants.RecurrenceRateAnnually && template.MonthToCreateTask == nil {
+		return "", errors.New("invalid template value")
+	}
+
+	switch rate {
+	case constants.RecurrenceRateDaily:
+		return "FREQ=DAILY", nil
+	case constants.RecurrenceRateWeekDaily:
+		return "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", nil
+	case constants.RecurrenceRateWeekly:
+		// days are stored as time.Weekday values, with 7 also meaning Sunday
+		weekdays := []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+		day := *template.DayToCreateTask % 7
+		if day < 0 {
+			return "", errors.New("invalid template value")
+		}
+		return "FREQ=WEEKLY;BYDAY=" + weekdays[day], nil
+	case constants.RecurrenceRateMonthly:
+		return fmt.Sprintf("FREQ=MONTHLY;BYMONTHDAY=%d", *template.DayToCreateTask), nil
+	case constants.RecurrenceRateAnnually:
+		return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYMONTHDAY=%d", *template.MonthToCreateTask, *template.DayToCreateTask), nil
+	}
+	return "", errors.New("unrecognized recurrence rate")
+}
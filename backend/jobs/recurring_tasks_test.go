@@ -0,0 +1,214 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetRecurringTaskOccurrences(t *testing.T) {
+	// 10:10:10 on Tuesday November 15, 2022
+	currentTime := time.Date(2022, time.November, 15, 10, 10, 10, 0, time.UTC)
+	// 10:00:10
+	creationTimeSeconds := 60*60*10 + 10
+	getTemplate := func(rate int, day *int, month *int) database.RecurringTaskTemplate {
+		return database.RecurringTaskTemplate{
+			RecurrenceRate:               &rate,
+			TimeOfDaySecondsToCreateTask: &creationTimeSeconds,
+			DayToCreateTask:              day,
+			MonthToCreateTask:            month,
+		}
+	}
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 10, 0, 10, 0, time.UTC)
+	}
+
+	t.Run("Daily", func(t *testing.T) {
+		occurrences, err := getRecurringTaskOccurrences(getTemplate(constants.RecurrenceRateDaily, nil, nil), time.Date(2022, time.November, 13, 11, 0, 0, 0, time.UTC), currentTime, time.UTC)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{at(2022, time.November, 14), at(2022, time.November, 15)}, occurrences)
+	})
+	t.Run("WeekDailySkipsWeekends", func(t *testing.T) {
+		occurrences, err := getRecurringTaskOccurrences(getTemplate(constants.RecurrenceRateWeekDaily, nil, nil), time.Date(2022, time.November, 11, 11, 0, 0, 0, time.UTC), currentTime, time.UTC)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{at(2022, time.November, 14), at(2022, time.November, 15)}, occurrences)
+	})
+	t.Run("Weekly", func(t *testing.T) {
+		monday := int(time.Monday)
+		occurrences, err := getRecurringTaskOccurrences(getTemplate(constants.RecurrenceRateWeekly, &monday, nil), time.Date(2022, time.November, 6, 9, 0, 0, 0, time.UTC), currentTime, time.UTC)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{at(2022, time.November, 7), at(2022, time.November, 14)}, occurrences)
+	})
+	t.Run("Monthly", func(t *testing.T) {
+		day := 14
+		occurrences, err := getRecurringTaskOccurrences(getTemplate(constants.RecurrenceRateMonthly, &day, nil), time.Date(2022, time.October, 20, 9, 0, 0, 0, time.UTC), currentTime, time.UTC)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{at(2022, time.November, 14)}, occurrences)
+	})
+	t.Run("Annually", func(t *testing.T) {
+		day := 14
+		month := 11
+		occurrences, err := getRecurringTaskOccurrences(getTemplate(constants.RecurrenceRateAnnually, &day, &month), time.Date(2021, time.September, 12, 9, 0, 0, 0, time.UTC), currentTime, time.UTC)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{at(2021, time.November, 14), at(2022, time.November, 14)}, occurrences)
+	})
+	t.Run("RecurrenceRule", func(t *testing.T) {
+		rule := "RRULE:FREQ=DAILY;INTERVAL=2\nEXDATE:20221111"
+		template := database.RecurringTaskTemplate{
+			RecurrenceRule:               &rule,
+			TimeOfDaySecondsToCreateTask: &creationTimeSeconds,
+			CreatedAt:                    primitive.NewDateTimeFromTime(time.Date(2022, time.November, 9, 8, 0, 0, 0, time.UTC)),
+		}
+		occurrences, err := getRecurringTaskOccurrences(template, time.Date(2022, time.November, 10, 9, 0, 0, 0, time.UTC), currentTime, time.UTC)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{at(2022, time.November, 13), at(2022, time.November, 15)}, occurrences)
+	})
+	t.Run("UsesLocation", func(t *testing.T) {
+		losAngeles, err := time.LoadLocation("America/Los_Angeles")
+		assert.NoError(t, err)
+		// 10:00:10 in Los Angeles is 18:00:10 UTC, which has not happened yet
+		occurrences, err := getRecurringTaskOccurrences(getTemplate(constants.RecurrenceRateDaily, nil, nil), time.Date(2022, time.November, 14, 19, 0, 0, 0, time.UTC), currentTime, losAngeles)
+		assert.NoError(t, err)
+		assert.Equal(t, []time.Time{}, occurrences)
+	})
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		_, err := getRecurringTaskOccurrences(getTemplate(constants.RecurrenceRateWeekly, nil, nil), currentTime, currentTime, time.UTC)
+		assert.EqualError(t, err, "invalid template value")
+		_, err = getRecurringTaskOccurrences(getTemplate(7, nil, nil), currentTime, currentTime, time.UTC)
+		assert.EqualError(t, err, "unrecognized recurrence rate")
+	})
+}
+
+func TestGenerateRecurringTasks(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	currentTime := time.Date(2022, time.November, 15, 10, 10, 10, 0, time.UTC)
+	creationTimeSeconds := 60*60*10 + 10
+	insertTemplate := func(userID primitive.ObjectID, replaceExisting bool) primitive.ObjectID {
+		title := "recurring task"
+		enabled := true
+		deleted := false
+		rate := constants.RecurrenceRateDaily
+		insertResult, err := database.GetRecurringTaskTemplateCollection(db).InsertOne(context.Background(), database.RecurringTaskTemplate{
+			UserID:                       userID,
+			Title:                        &title,
+			IsEnabled:                    &enabled,
+			IsDeleted:                    &deleted,
+			ReplaceExisting:              &replaceExisting,
+			RecurrenceRate:               &rate,
+			TimeOfDaySecondsToCreateTask: &creationTimeSeconds,
+			LastBackfillDatetime:         primitive.NewDateTimeFromTime(time.Date(2022, time.November, 13, 11, 0, 0, 0, time.UTC)),
+		})
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	insertUser := func(timezone string) primitive.ObjectID {
+		insertResult, err := database.GetUserCollection(db).InsertOne(context.Background(), database.User{Email: "recurring@generaltask.com", Timezone: timezone})
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	getTemplateTasks := func(userID primitive.ObjectID, templateID primitive.ObjectID) []database.Task {
+		tasks, err := database.GetTasks(db, userID, &[]bson.M{{"recurring_task_template_id": templateID}, {"is_deleted": false}}, nil)
+		assert.NoError(t, err)
+		return *tasks
+	}
+
+	t.Run("Idempotent", func(t *testing.T) {
+		userID := insertUser("")
+		templateID := insertTemplate(userID, false)
+		assert.NoError(t, GenerateRecurringTasksForUser(db, userID, currentTime))
+		tasks := getTemplateTasks(userID, templateID)
+		assert.Equal(t, 2, len(tasks))
+		assert.Equal(t, "2022-11-14", tasks[0].RecurringTaskOccurrenceDate)
+		assert.Equal(t, "2022-11-15", tasks[1].RecurringTaskOccurrenceDate)
+
+		// reset the last backfill to simulate a second run racing the first
+		_, err := database.GetRecurringTaskTemplateCollection(db).UpdateOne(
+			context.Background(),
+			bson.M{"_id": templateID},
+			bson.M{"$set": bson.M{"last_backfill_datetime": primitive.NewDateTimeFromTime(time.Date(2022, time.November, 13, 11, 0, 0, 0, time.UTC))}},
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, GenerateRecurringTasksForUser(db, userID, currentTime))
+		assert.Equal(t, 2, len(getTemplateTasks(userID, templateID)))
+	})
+	t.Run("StoredTimezone", func(t *testing.T) {
+		userID := insertUser("America/Los_Angeles")
+		templateID := insertTemplate(userID, false)
+		assert.NoError(t, GenerateRecurringTasksForUser(db, userID, currentTime))
+		tasks := getTemplateTasks(userID, templateID)
+		// the last backfill was early on November 13 in Los Angeles, and 10:00:10 on November 15 has not happened yet
+		assert.Equal(t, 2, len(tasks))
+		assert.Equal(t, "2022-11-13", tasks[0].RecurringTaskOccurrenceDate)
+		assert.Equal(t, "2022-11-14", tasks[1].RecurringTaskOccurrenceDate)
+	})
+	t.Run("ReplaceExisting", func(t *testing.T) {
+		userID := insertUser("")
+		templateID := insertTemplate(userID, true)
+		assert.NoError(t, GenerateRecurringTasksForUser(db, userID, currentTime))
+		tasks := getTemplateTasks(userID, templateID)
+		assert.Equal(t, 1, len(tasks))
+		assert.Equal(t, "2022-11-15", tasks[0].RecurringTaskOccurrenceDate)
+
+		assert.NoError(t, GenerateRecurringTasksForUser(db, userID, currentTime.AddDate(0, 0, 1)))
+		tasks = getTemplateTasks(userID, templateID)
+		assert.Equal(t, 1, len(tasks))
+		assert.Equal(t, "2022-11-16", tasks[0].RecurringTaskOccurrenceDate)
+	})
+	t.Run("SubtasksDueDateAndTimeAllocation", func(t *testing.T) {
+		userID := insertUser("")
+		templateID := insertTemplate(userID, true)
+		offset := 2
+		timeAllocation := (30 * time.Minute).Nanoseconds()
+		subtaskTimeAllocation := (5 * time.Minute).Nanoseconds()
+		_, err := database.GetRecurringTaskTemplateCollection(db).UpdateOne(
+			context.Background(),
+			bson.M{"_id": templateID},
+			bson.M{"$set": database.RecurringTaskTemplate{
+				DueDateOffsetDays: &offset,
+				TimeAllocation:    &timeAllocation,
+				Subtasks: &[]database.RecurringTaskTemplateSubtask{
+					{Title: "tag release", TimeAllocation: &subtaskTimeAllocation},
+					{Title: "deploy", Subtasks: []database.RecurringTaskTemplateSubtask{{Title: "deploy backend"}}},
+				},
+			}},
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, GenerateRecurringTasksForUser(db, userID, currentTime))
+		tasks := getTemplateTasks(userID, templateID)
+		assert.Equal(t, 1, len(tasks))
+		assert.Equal(t, timeAllocation, *tasks[0].TimeAllocation)
+		assert.Equal(t, primitive.NewDateTimeFromTime(time.Date(2022, time.November, 17, 0, 0, 0, 0, time.UTC)), *tasks[0].DueDate)
+
+		subtasks, err := database.GetTasks(db, userID, &[]bson.M{{"parent_task_id": tasks[0].ID}}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(*subtasks))
+		assert.Equal(t, "tag release", *(*subtasks)[0].Title)
+		assert.Equal(t, subtaskTimeAllocation, *(*subtasks)[0].TimeAllocation)
+		assert.Equal(t, "deploy", *(*subtasks)[1].Title)
+		nestedSubtasks, err := database.GetTasks(db, userID, &[]bson.M{{"parent_task_id": (*subtasks)[1].ID}}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(*nestedSubtasks))
+		assert.Equal(t, "deploy backend", *(*nestedSubtasks)[0].Title)
+
+		// subtasks are not created again for an existing task
+		_, err = database.GetRecurringTaskTemplateCollection(db).UpdateOne(
+			context.Background(),
+			bson.M{"_id": templateID},
+			bson.M{"$set": bson.M{"last_backfill_datetime": primitive.NewDateTimeFromTime(time.Date(2022, time.November, 13, 11, 0, 0, 0, time.UTC))}},
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, GenerateRecurringTasksForUser(db, userID, currentTime))
+		subtasks, err = database.GetTasks(db, userID, &[]bson.M{{"parent_task_id": tasks[0].ID}}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(*subtasks))
+	})
+}
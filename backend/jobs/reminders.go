@@ -0,0 +1,209 @@
+package jobs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/logging"
+	"github.com/GeneralTask/task-manager/backend/settings"
+	"github.com/GeneralTask/task-manager/backend/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func taskRemindersJob() {
+	_, err := EnsureJobOnlyRunsOncePerHour("task_reminders")
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for task reminders job")
+		return
+	}
+	defer cleanup()
+	err = sendTaskReminders(db, time.Now(), getNotificationChannels())
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to run task reminders job")
+		return
+	}
+}
+
+// the reminder settings and delivery info for a user, loaded once per job run
+type reminderRecipient struct {
+	User         *database.User
+	Location     *time.Location
+	DefaultRules []database.ReminderRule
+	Channels     []NotificationChannel
+}
+
+func sendTaskReminders(db *mongo.Database, currentTime time.Time, channels []NotificationChannel) error {
+	logger := logging.GetSentryLogger()
+	lookback := time.Duration(constants.REMINDER_LOOKBACK_SECONDS) * time.Second
+	maxLead := time.Duration(constants.MAX_REMINDER_LEAD_SECONDS) * time.Second
+	// time of day reminders can fire up to a day after a date-only due date, depending on the user's timezone
+	tasks, err := database.GetIncompleteTasksDueBetween(
+		db,
+		currentTime.Add(-lookback-24*time.Hour),
+		currentTime.Add(maxLead+24*time.Hour),
+	)
+	if err != nil {
+		return err
+	}
+
+	recipients := make(map[primitive.ObjectID]*reminderRecipient)
+	for _, task := range *tasks {
+		if task.DueDate == nil {
+			continue
+		}
+		recipient, ok := recipients[task.UserID]
+		if !ok {
+			recipient, err = getReminderRecipient(db, task.UserID, channels)
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to load reminder settings for user")
+				continue
+			}
+			recipients[task.UserID] = recipient
+		}
+		rules := recipient.DefaultRules
+		if task.Reminders != nil {
+			rules = *task.Reminders
+		}
+		for _, rule := range rules {
+			err = sendTaskReminderIfDue(db, currentTime, task, rule, recipient)
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to send task reminder")
+			}
+		}
+	}
+	return nil
+}
+
+func getReminderRecipient(db *mongo.Database, userID primitive.ObjectID, channels []NotificationChannel) (*reminderRecipient, error) {
+	user, err := database.GetUser(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	defaultReminder, err := settings.GetUserSettingValue(db, userID, settings.DefaultTaskReminderSetting)
+	if err != nil {
+		return nil, err
+	}
+	enabledChannels, err := getEnabledNotificationChannels(db, userID, channels)
+	if err != nil {
+		return nil, err
+	}
+	return &reminderRecipient{
+		User:         user,
+		Location:     getUserLocation(db, user),
+		DefaultRules: getDefaultReminderRules(defaultReminder),
+		Channels:     enabledChannels,
+	}, nil
+}
+
+// uses the user's stored timezone, then the timezone of their calendar, falling back to UTC
+func getUserLocation(db *mongo.Database, user *database.User) *time.Location {
+	location := database.GetUserLocation(user, nil)
+	if location != nil {
+		return location
+	}
+	tokens, err := database.GetExternalTokens(db, user.ID, external.TASK_SERVICE_ID_GOOGLE)
+	if err != nil {
+		return time.UTC
+	}
+	for _, token := range *tokens {
+		if token.Timezone == "" {
+			continue
+		}
+		location, err := time.LoadLocation(token.Timezone)
+		if err == nil {
+			return location
+		}
+	}
+	return time.UTC
+}
+
+func getDefaultReminderRules(choice string) []database.ReminderRule {
+	switch choice {
+	case constants.ChoiceKeyReminderOneHourBefore:
+		return []database.ReminderRule{{MinutesBeforeDue: 60}}
+	case constants.ChoiceKeyReminderOneDayBefore:
+		return []database.ReminderRule{{MinutesBeforeDue: 24 * 60}}
+	case constants.ChoiceKeyReminderMorningOfDueDate:
+		return []database.ReminderRule{{TimeOfDay: constants.DEFAULT_REMINDER_TIME_OF_DAY}}
+	}
+	return []database.ReminderRule{}
+}
+
+func getReminderFireTime(rule database.ReminderRule, dueDate time.Time, location *time.Location) (time.Time, error) {
+	if rule.TimeOfDay == "" {
+		return dueDate.Add(-time.Duration(rule.MinutesBeforeDue) * time.Minute), nil
+	}
+	timeOfDay, err := time.Parse(constants.REMINDER_TIME_OF_DAY_FORMAT, rule.TimeOfDay)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// due dates are stored as UTC dates, so the day is taken in UTC and the time of day in the user's timezone
+	year, month, day := dueDate.UTC().Date()
+	return time.Date(year, month, day-rule.DaysBeforeDue, timeOfDay.Hour(), timeOfDay.Minute(), 0, 0, location), nil
+}
+
+// reminders relative to the due time are sent earlier if they would otherwise fire outside the user's working hours
+func getWorkingHoursReminderFireTime(rule database.ReminderRule, fireTime time.Time, recipient *reminderRecipient) time.Time {
+	if rule.TimeOfDay != "" || recipient.User.Availability == nil {
+		return fireTime
+	}
+	workingTime, ok := utils.GetLatestWorkingTime(*recipient.User.Availability, recipient.Location, fireTime)
+	if !ok {
+		return fireTime
+	}
+	return workingTime
+}
+
+// includes the due date so that a reminder fires again if the due date is changed
+func getReminderKey(rule database.ReminderRule, dueDate time.Time) string {
+	return fmt.Sprintf("%d_%d_%s_%d", rule.MinutesBeforeDue, rule.DaysBeforeDue, rule.TimeOfDay, dueDate.Unix())
+}
+
+func getReminderNotification(task database.Task, dueDate time.Time, reminderKey string, currentTime time.Time) *database.Notification {
+	title := ""
+	if task.Title != nil {
+		title = *task.Title
+	}
+	return &database.Notification{
+		UserID:      task.UserID,
+		TaskID:      task.ID,
+		Type:        constants.NotificationTypeTaskReminder,
+		ReminderKey: reminderKey,
+		Title:       "Reminder: " + title,
+		Body:        fmt.Sprintf("\"%s\" is due %s", title, dueDate.UTC().Format("Monday, January 2")),
+		CreatedAt:   primitive.NewDateTimeFromTime(currentTime),
+	}
+}
+
+func sendTaskReminderIfDue(db *mongo.Database, currentTime time.Time, task database.Task, rule database.ReminderRule, recipient *reminderRecipient) error {
+	if len(recipient.Channels) == 0 {
+		return nil
+	}
+	dueDate := task.DueDate.Time()
+	fireTime, err := getReminderFireTime(rule, dueDate, recipient.Location)
+	if err != nil {
+		return err
+	}
+	fireTime = getWorkingHoursReminderFireTime(rule, fireTime, recipient)
+	lookback := time.Duration(constants.REMINDER_LOOKBACK_SECONDS) * time.Second
+	if fireTime.After(currentTime) || fireTime.Before(currentTime.Add(-lookback)) {
+		return nil
+	}
+	reminderKey := getReminderKey(rule, dueDate)
+	sent, err := database.HasReminderBeenSent(db, task.ID, reminderKey)
+	if err != nil || sent {
+		return err
+	}
+
+	notification := getReminderNotification(task, dueDate, reminderKey, currentTime)
+	// the notification is stored even if every channel fails so the reminder is not retried every hour
+	return deliverNotification(db, recipient.User, notification, recipient.Channels)
+}
@@ -0,0 +1,224 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"github.com/GeneralTask/task-manager/backend/settings"
+	"github.com/GeneralTask/task-manager/backend/testutils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type testNotificationChannel struct {
+	name    string
+	setting settings.SettingDefinition
+	err     error
+	sent    *[]database.Notification
+}
+
+func (channel testNotificationChannel) GetName() string {
+	return channel.name
+}
+
+func (channel testNotificationChannel) GetSetting() settings.SettingDefinition {
+	return channel.setting
+}
+
+func (channel testNotificationChannel) Send(db *mongo.Database, user *database.User, notification *database.Notification) error {
+	if channel.err != nil {
+		return channel.err
+	}
+	*channel.sent = append(*channel.sent, *notification)
+	return nil
+}
+
+func TestGetReminderFireTime(t *testing.T) {
+	dueDate := time.Date(2023, time.March, 10, 0, 0, 0, 0, time.UTC)
+	losAngeles, err := time.LoadLocation("America/Los_Angeles")
+	assert.NoError(t, err)
+
+	t.Run("MinutesBeforeDue", func(t *testing.T) {
+		fireTime, err := getReminderFireTime(database.ReminderRule{MinutesBeforeDue: 90}, dueDate, losAngeles)
+		assert.NoError(t, err)
+		assert.Equal(t, dueDate.Add(-90*time.Minute), fireTime)
+	})
+	t.Run("TimeOfDayUsesUserTimezone", func(t *testing.T) {
+		fireTime, err := getReminderFireTime(database.ReminderRule{TimeOfDay: "09:00"}, dueDate, losAngeles)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2023, time.March, 10, 17, 0, 0, 0, time.UTC), fireTime.UTC())
+	})
+	t.Run("TimeOfDayDaysBeforeDue", func(t *testing.T) {
+		fireTime, err := getReminderFireTime(database.ReminderRule{TimeOfDay: "17:30", DaysBeforeDue: 2}, dueDate, time.UTC)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2023, time.March, 8, 17, 30, 0, 0, time.UTC), fireTime)
+	})
+	t.Run("InvalidTimeOfDay", func(t *testing.T) {
+		_, err := getReminderFireTime(database.ReminderRule{TimeOfDay: "9am"}, dueDate, time.UTC)
+		assert.Error(t, err)
+	})
+}
+
+func TestGetWorkingHoursReminderFireTime(t *testing.T) {
+	// Saturday March 11, 2023 at 10:00
+	fireTime := time.Date(2023, time.March, 11, 10, 0, 0, 0, time.UTC)
+	availability := database.UserAvailability{WorkingHours: []database.WorkingHours{{Weekday: 5, StartTime: "09:00", EndTime: "17:00"}}}
+
+	t.Run("NoAvailability", func(t *testing.T) {
+		recipient := &reminderRecipient{User: &database.User{}, Location: time.UTC}
+		assert.Equal(t, fireTime, getWorkingHoursReminderFireTime(database.ReminderRule{MinutesBeforeDue: 30}, fireTime, recipient))
+	})
+	t.Run("TimeOfDayUnchanged", func(t *testing.T) {
+		recipient := &reminderRecipient{User: &database.User{Availability: &availability}, Location: time.UTC}
+		assert.Equal(t, fireTime, getWorkingHoursReminderFireTime(database.ReminderRule{TimeOfDay: "10:00"}, fireTime, recipient))
+	})
+	t.Run("MovedToEndOfWorkingHours", func(t *testing.T) {
+		recipient := &reminderRecipient{User: &database.User{Availability: &availability}, Location: time.UTC}
+		assert.Equal(t, time.Date(2023, time.March, 10, 17, 0, 0, 0, time.UTC), getWorkingHoursReminderFireTime(database.ReminderRule{MinutesBeforeDue: 30}, fireTime, recipient))
+	})
+}
+
+func TestGetDefaultReminderRules(t *testing.T) {
+	assert.Equal(t, []database.ReminderRule{}, getDefaultReminderRules(constants.ChoiceKeyReminderNone))
+	assert.Equal(t, []database.ReminderRule{{MinutesBeforeDue: 60}}, getDefaultReminderRules(constants.ChoiceKeyReminderOneHourBefore))
+	assert.Equal(t, []database.ReminderRule{{MinutesBeforeDue: 1440}}, getDefaultReminderRules(constants.ChoiceKeyReminderOneDayBefore))
+	assert.Equal(t, []database.ReminderRule{{TimeOfDay: "09:00"}}, getDefaultReminderRules(constants.ChoiceKeyReminderMorningOfDueDate))
+	assert.Equal(t, []database.ReminderRule{}, getDefaultReminderRules("unknown"))
+}
+
+func TestSendTaskReminders(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	currentTime := time.Now().UTC().Truncate(time.Second)
+	insertUser := func(defaultReminder string) primitive.ObjectID {
+		insertResult, err := database.GetUserCollection(db).InsertOne(context.Background(), database.User{Email: "reminders@generaltask.com"})
+		assert.NoError(t, err)
+		userID := insertResult.InsertedID.(primitive.ObjectID)
+		assert.NoError(t, settings.UpdateUserSetting(db, userID, constants.SettingFieldDefaultTaskReminder, defaultReminder))
+		return userID
+	}
+	insertTask := func(userID primitive.ObjectID, dueDate time.Time, reminders *[]database.ReminderRule) primitive.ObjectID {
+		completed := false
+		title := "reminder task"
+		primitiveDueDate := primitive.NewDateTimeFromTime(dueDate)
+		insertResult, err := database.GetTaskCollection(db).InsertOne(context.Background(), database.Task{
+			UserID:      userID,
+			Title:       &title,
+			IsCompleted: &completed,
+			DueDate:     &primitiveDueDate,
+			Reminders:   reminders,
+		})
+		assert.NoError(t, err)
+		return insertResult.InsertedID.(primitive.ObjectID)
+	}
+	getNotifications := func(userID primitive.ObjectID) []database.Notification {
+		var notifications []database.Notification
+		err := database.FindWithCollection(database.GetNotificationCollection(db), userID, &[]bson.M{}, &notifications, nil)
+		assert.NoError(t, err)
+		return notifications
+	}
+
+	t.Run("DefaultReminder", func(t *testing.T) {
+		userID := insertUser(constants.ChoiceKeyReminderOneHourBefore)
+		dueSoonTaskID := insertTask(userID, currentTime.Add(30*time.Minute), nil)
+		insertTask(userID, currentTime.Add(3*time.Hour), nil)
+		sent := []database.Notification{}
+		channels := []NotificationChannel{
+			InAppNotificationChannel{},
+			testNotificationChannel{name: constants.NotificationChannelEmail, setting: settings.NotificationChannelEmailSetting, sent: &sent},
+		}
+
+		assert.NoError(t, sendTaskReminders(db, currentTime, channels))
+		notifications := getNotifications(userID)
+		assert.Equal(t, 1, len(notifications))
+		assert.Equal(t, dueSoonTaskID, notifications[0].TaskID)
+		assert.Equal(t, "Reminder: reminder task", notifications[0].Title)
+		// the email channel is off by default
+		assert.Equal(t, []string{constants.NotificationChannelInApp}, notifications[0].Channels)
+		assert.Equal(t, 0, len(sent))
+
+		// reminders are only sent once
+		assert.NoError(t, sendTaskReminders(db, currentTime.Add(10*time.Minute), channels))
+		assert.Equal(t, 1, len(getNotifications(userID)))
+	})
+	t.Run("TaskRemindersOverrideDefault", func(t *testing.T) {
+		userID := insertUser(constants.ChoiceKeyReminderOneHourBefore)
+		assert.NoError(t, settings.UpdateUserSetting(db, userID, constants.SettingFieldNotificationChannelEmail, "true"))
+		insertTask(userID, currentTime.Add(30*time.Minute), &[]database.ReminderRule{})
+		taskID := insertTask(userID, currentTime.Add(90*time.Minute), &[]database.ReminderRule{{MinutesBeforeDue: 120}})
+		sent := []database.Notification{}
+		channels := []NotificationChannel{
+			InAppNotificationChannel{},
+			testNotificationChannel{name: constants.NotificationChannelEmail, setting: settings.NotificationChannelEmailSetting, sent: &sent},
+		}
+
+		assert.NoError(t, sendTaskReminders(db, currentTime, channels))
+		notifications := getNotifications(userID)
+		assert.Equal(t, 1, len(notifications))
+		assert.Equal(t, taskID, notifications[0].TaskID)
+		assert.Equal(t, []string{constants.NotificationChannelInApp, constants.NotificationChannelEmail}, notifications[0].Channels)
+		assert.Equal(t, 1, len(sent))
+	})
+	t.Run("FailedChannel", func(t *testing.T) {
+		userID := insertUser(constants.ChoiceKeyReminderOneHourBefore)
+		assert.NoError(t, settings.UpdateUserSetting(db, userID, constants.SettingFieldNotificationChannelSlack, "true"))
+		insertTask(userID, currentTime.Add(30*time.Minute), nil)
+		channels := []NotificationChannel{
+			InAppNotificationChannel{},
+			testNotificationChannel{name: constants.NotificationChannelSlack, setting: settings.NotificationChannelSlackSetting, err: errors.New("slack is down")},
+		}
+
+		assert.NoError(t, sendTaskReminders(db, currentTime, channels))
+		notifications := getNotifications(userID)
+		assert.Equal(t, 1, len(notifications))
+		assert.Equal(t, []string{constants.NotificationChannelInApp}, notifications[0].Channels)
+	})
+	t.Run("NoEnabledChannels", func(t *testing.T) {
+		userID := insertUser(constants.ChoiceKeyReminderOneHourBefore)
+		assert.NoError(t, settings.UpdateUserSetting(db, userID, constants.SettingFieldNotificationChannelInApp, "false"))
+		insertTask(userID, currentTime.Add(30*time.Minute), nil)
+
+		assert.NoError(t, sendTaskReminders(db, currentTime, []NotificationChannel{InAppNotificationChannel{}}))
+		assert.Equal(t, 0, len(getNotifications(userID)))
+	})
+}
+
+func TestSlackNotificationChannelMissingScope(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+
+	userID := primitive.NewObjectID()
+	insertResult, err := database.GetExternalTokenCollection(db).InsertOne(context.Background(), database.ExternalAPIToken{
+		UserID:    userID,
+		ServiceID: external.TASK_SERVICE_ID_SLACK,
+		AccountID: external.GenerateSlackUserID("T123", "U123"),
+		Token:     `{"access_token": "example_access_token"}`,
+	})
+	assert.NoError(t, err)
+
+	server := testutils.GetMockAPIServer(t, http.StatusOK, `{"ok": false, "error": "missing_scope", "needed": "chat:write"}`)
+	defer server.Close()
+	overrideURL := server.URL + "/"
+	channel := SlackNotificationChannel{OverrideURL: &overrideURL}
+	err = channel.Send(db, &database.User{ID: userID}, &database.Notification{Title: "Reminder", Body: "task"})
+	assert.Equal(t, external.ErrSlackMissingScope, err)
+
+	var token database.ExternalAPIToken
+	assert.NoError(t, database.GetExternalTokenCollection(db).FindOne(context.Background(), bson.M{"_id": insertResult.InsertedID}).Decode(&token))
+	assert.True(t, token.IsBadToken)
+
+	// the flagged token is not used again until the user reconnects Slack
+	err = channel.Send(db, &database.User{ID: userID}, &database.Notification{Title: "Reminder", Body: "task"})
+	assert.EqualError(t, err, "user has no linked Slack account which can send messages")
+}
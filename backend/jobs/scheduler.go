@@ -20,5 +20,29 @@ func GetScheduler() (*gocron.Scheduler, error) {
 		return nil, err
 	}
 
+	// snoozed tasks are hidden until their snooze passes, this moves them back to the top of their section
+	_, err = s.Every(1).Hour().Do(resurfaceSnoozedTasksJob)
+	if err != nil {
+		return nil, err
+	}
+
+	// reminders fire within the hour of their scheduled time
+	_, err = s.Every(1).Hour().Do(taskRemindersJob)
+	if err != nil {
+		return nil, err
+	}
+
+	// recurring task templates create their tasks in the user's timezone, so this runs every hour
+	_, err = s.Every(1).Hour().Do(recurringTasksJob)
+	if err != nil {
+		return nil, err
+	}
+
+	// dashboard teams synced from a GitHub organization or team pick up roster changes daily
+	_, err = s.Every(1).Day().At("09:00").Do(githubRosterJob)
+	if err != nil {
+		return nil, err
+	}
+
 	return s, nil
 }
@@ -0,0 +1,26 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/logging"
+)
+
+func resurfaceSnoozedTasksJob() {
+	_, err := EnsureJobOnlyRunsOncePerHour("resurface_snoozed_tasks")
+	if err != nil {
+		return
+	}
+	db, cleanup, err := database.GetDBConnection()
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to connect to db for snoozed tasks job")
+		return
+	}
+	defer cleanup()
+	_, err = database.ResurfaceSnoozedTasks(db, time.Now())
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("failed to run resurface snoozed tasks job")
+		return
+	}
+}
@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/GeneralTask/task-manager/backend/external"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SyncWorkspaceDashboardTeamMembers adds the members of the user's workspaces to their dashboard team, and removes
+// the members who left. Team members added by hand are kept as they are.
+func SyncWorkspaceDashboardTeamMembers(db *mongo.Database, userID primitive.ObjectID, teamID primitive.ObjectID) error {
+	workspaceIDs, err := database.GetUserWorkspaceIDs(db, userID)
+	if err != nil {
+		return err
+	}
+	cursor, err := database.GetWorkspaceMemberCollection(db).Find(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"workspace_id": bson.M{"$in": workspaceIDs}},
+			{"user_id": bson.M{"$exists": true, "$ne": userID}},
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	var workspaceMembers []database.WorkspaceMember
+	err = cursor.All(context.Background(), &workspaceMembers)
+	if err != nil {
+		return err
+	}
+	teamMembers, err := database.GetDashboardTeamMembers(db, teamID)
+	if err != nil {
+		return err
+	}
+	manualEmails := map[string]bool{}
+	for _, teamMember := range *teamMembers {
+		if teamMember.UserID == primitive.NilObjectID {
+			manualEmails[strings.ToLower(teamMember.Email)] = true
+		}
+	}
+
+	memberUserIDs := []primitive.ObjectID{}
+	isSynced := map[primitive.ObjectID]bool{}
+	for _, workspaceMember := range workspaceMembers {
+		if isSynced[workspaceMember.UserID] || manualEmails[workspaceMember.Email] {
+			continue
+		}
+		isSynced[workspaceMember.UserID] = true
+		user, err := database.GetUser(db, workspaceMember.UserID)
+		if err != nil {
+			continue
+		}
+		memberUserIDs = append(memberUserIDs, user.ID)
+		_, err = database.GetDashboardTeamMemberCollection(db).UpdateOne(
+			context.Background(),
+			bson.M{"$and": []bson.M{
+				{"team_id": teamID},
+				{"user_id": user.ID},
+			}},
+			bson.M{
+				"$set": bson.M{
+					"name":      user.Name,
+					"email":     user.Email,
+					"github_id": getGithubLogin(db, user.ID),
+				},
+				"$setOnInsert": bson.M{"created_at": primitive.NewDateTimeFromTime(time.Now())},
+			},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = database.GetDashboardTeamMemberCollection(db).DeleteMany(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"team_id": teamID},
+			{"user_id": bson.M{"$exists": true, "$nin": memberUserIDs}},
+		}},
+	)
+	return err
+}
+
+// SyncWorkspaceDashboardTeams syncs the dashboard teams of the given users, which is needed for every member of a
+// workspace when someone joins or leaves it
+func SyncWorkspaceDashboardTeams(db *mongo.Database, userIDs []primitive.ObjectID) error {
+	for _, userID := range userIDs {
+		team, err := database.GetOrCreateDashboardTeam(db, userID)
+		if err != nil {
+			return err
+		}
+		err = SyncWorkspaceDashboardTeamMembers(db, userID, team.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns an empty string if the user has not linked a GitHub account
+func getGithubLogin(db *mongo.Database, userID primitive.ObjectID) string {
+	var token database.ExternalAPIToken
+	err := database.GetExternalTokenCollection(db).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"service_id": external.TASK_SERVICE_ID_GITHUB},
+		}},
+	).Decode(&token)
+	if err != nil {
+		return ""
+	}
+	return token.DisplayID
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestMigrate012(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	migrate, err := getMigrate("")
+	assert.NoError(t, err)
+	err = migrate.Steps(1)
+	assert.NoError(t, err)
+
+	timeEntryCollection := database.GetTimeEntryCollection(db)
+	userID := primitive.NewObjectID()
+
+	t.Run("MigrateUp", func(t *testing.T) {
+		runningID := primitive.NewObjectID()
+		_, err := timeEntryCollection.InsertOne(context.Background(), bson.M{"_id": runningID, "user_id": userID, "start_time": primitive.NewDateTimeFromTime(time.Now())})
+		assert.NoError(t, err)
+		stoppedID := primitive.NewObjectID()
+		_, err = timeEntryCollection.InsertOne(context.Background(), bson.M{"_id": stoppedID, "user_id": userID, "end_time": primitive.NewDateTimeFromTime(time.Now())})
+		assert.NoError(t, err)
+
+		err = migrate.Steps(1)
+		assert.NoError(t, err)
+
+		var result database.TimeEntry
+		assert.NoError(t, timeEntryCollection.FindOne(context.Background(), bson.M{"_id": runningID}).Decode(&result))
+		assert.True(t, result.IsRunning)
+		var stoppedResult database.TimeEntry
+		assert.NoError(t, timeEntryCollection.FindOne(context.Background(), bson.M{"_id": stoppedID}).Decode(&stoppedResult))
+		assert.False(t, stoppedResult.IsRunning)
+
+		_, err = timeEntryCollection.InsertOne(context.Background(), database.TimeEntry{UserID: userID, IsRunning: true})
+		assert.True(t, mongo.IsDuplicateKeyError(err))
+		// stopped entries are not part of the index
+		_, err = timeEntryCollection.InsertOne(context.Background(), database.TimeEntry{UserID: userID})
+		assert.NoError(t, err)
+	})
+	t.Run("MigrateDown", func(t *testing.T) {
+		err = migrate.Steps(-1)
+		assert.NoError(t, err)
+
+		_, err = timeEntryCollection.InsertOne(context.Background(), database.TimeEntry{UserID: userID, IsRunning: true})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestMigrate013(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	migrate, err := getMigrate("")
+	assert.NoError(t, err)
+	err = migrate.Steps(1)
+	assert.NoError(t, err)
+
+	rateLimitCollection := database.GetRateLimitCollection(db)
+	rateLimit := database.RateLimit{
+		Key:         "test_migrate_13",
+		WindowStart: primitive.NewDateTimeFromTime(time.Now().Truncate(time.Minute)),
+		Count:       1,
+	}
+
+	t.Run("MigrateUp", func(t *testing.T) {
+		err = migrate.Steps(1)
+		assert.NoError(t, err)
+
+		_, err := rateLimitCollection.InsertOne(context.Background(), rateLimit)
+		assert.NoError(t, err)
+		_, err = rateLimitCollection.InsertOne(context.Background(), rateLimit)
+		assert.True(t, mongo.IsDuplicateKeyError(err))
+	})
+	t.Run("MigrateDown", func(t *testing.T) {
+		err = migrate.Steps(-1)
+		assert.NoError(t, err)
+
+		_, err := rateLimitCollection.InsertOne(context.Background(), rateLimit)
+		assert.NoError(t, err)
+	})
+}
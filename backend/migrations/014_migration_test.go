@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMigrate014(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	migrate, err := getMigrate("")
+	assert.NoError(t, err)
+	err = migrate.Steps(1)
+	assert.NoError(t, err)
+
+	userCollection := database.GetUserCollection(db)
+	userID := primitive.NewObjectID()
+	_, err = userCollection.InsertOne(context.Background(), bson.M{"_id": userID, "email": "Jane.Doe@GeneralTask.com"})
+	assert.NoError(t, err)
+
+	t.Run("MigrateUp", func(t *testing.T) {
+		err = migrate.Steps(1)
+		assert.NoError(t, err)
+
+		user, err := database.GetUserByEmail(db, "jane.doe@generaltask.com")
+		assert.NoError(t, err)
+		assert.Equal(t, userID, user.ID)
+		assert.Equal(t, "Jane.Doe@GeneralTask.com", user.Email)
+	})
+	t.Run("MigrateDown", func(t *testing.T) {
+		err = migrate.Steps(-1)
+		assert.NoError(t, err)
+
+		var user database.User
+		assert.NoError(t, userCollection.FindOne(context.Background(), bson.M{"_id": userID}).Decode(&user))
+		assert.Equal(t, "", user.EmailLowercase)
+	})
+}
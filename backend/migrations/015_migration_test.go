@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestMigrate015(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	migrate, err := getMigrate("")
+	assert.NoError(t, err)
+	err = migrate.Steps(1)
+	assert.NoError(t, err)
+
+	hasSharedWithIndex := func(collection *mongo.Collection) bool {
+		specifications, err := collection.Indexes().ListSpecifications(context.Background())
+		assert.NoError(t, err)
+		for _, specification := range specifications {
+			if specification.Name == "shared_with_email" {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("MigrateUp", func(t *testing.T) {
+		err = migrate.Steps(1)
+		assert.NoError(t, err)
+
+		assert.True(t, hasSharedWithIndex(database.GetTaskCollection(db)))
+		assert.True(t, hasSharedWithIndex(database.GetNoteCollection(db)))
+	})
+	t.Run("MigrateDown", func(t *testing.T) {
+		err = migrate.Steps(-1)
+		assert.NoError(t, err)
+
+		assert.False(t, hasSharedWithIndex(database.GetTaskCollection(db)))
+		assert.False(t, hasSharedWithIndex(database.GetNoteCollection(db)))
+	})
+}
@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestMigrate016(t *testing.T) {
+	db, dbCleanup, err := database.GetDBConnection()
+	assert.NoError(t, err)
+	defer dbCleanup()
+	migrate, err := getMigrate("")
+	assert.NoError(t, err)
+	err = migrate.Steps(1)
+	assert.NoError(t, err)
+
+	taskCollection := database.GetTaskCollection(db)
+	recurringTask := database.Task{
+		UserID:                      primitive.NewObjectID(),
+		RecurringTaskTemplateID:     primitive.NewObjectID(),
+		RecurringTaskOccurrenceDate: "2022-11-14",
+	}
+
+	t.Run("MigrateUp", func(t *testing.T) {
+		err = migrate.Steps(1)
+		assert.NoError(t, err)
+
+		_, err := taskCollection.InsertOne(context.Background(), recurringTask)
+		assert.NoError(t, err)
+		_, err = taskCollection.InsertOne(context.Background(), recurringTask)
+		assert.True(t, mongo.IsDuplicateKeyError(err))
+		// other occurrences of the template and tasks without a template are not part of the index
+		nextOccurrence := recurringTask
+		nextOccurrence.RecurringTaskOccurrenceDate = "2022-11-15"
+		_, err = taskCollection.InsertOne(context.Background(), nextOccurrence)
+		assert.NoError(t, err)
+		_, err = taskCollection.InsertOne(context.Background(), database.Task{UserID: recurringTask.UserID})
+		assert.NoError(t, err)
+		_, err = taskCollection.InsertOne(context.Background(), database.Task{UserID: recurringTask.UserID})
+		assert.NoError(t, err)
+	})
+	t.Run("MigrateDown", func(t *testing.T) {
+		err = migrate.Steps(-1)
+		assert.NoError(t, err)
+
+		_, err = taskCollection.InsertOne(context.Background(), recurringTask)
+		assert.NoError(t, err)
+	})
+}
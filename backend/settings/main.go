@@ -191,6 +191,55 @@ var HasDismissedMulticalPromptSetting = SettingDefinition{
 	},
 }
 
+// applies to tasks with a due date and no reminders of their own
+var DefaultTaskReminderSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldDefaultTaskReminder,
+	DefaultChoice: constants.ChoiceKeyReminderNone,
+	Choices: []SettingChoice{
+		{Key: constants.ChoiceKeyReminderNone},
+		{Key: constants.ChoiceKeyReminderOneHourBefore},
+		{Key: constants.ChoiceKeyReminderOneDayBefore},
+		{Key: constants.ChoiceKeyReminderMorningOfDueDate},
+	},
+}
+
+var NotificationChannelEmailSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldNotificationChannelEmail,
+	DefaultChoice: "false",
+	Choices: []SettingChoice{
+		{Key: "true"},
+		{Key: "false"},
+	},
+}
+
+var NotificationChannelSlackSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldNotificationChannelSlack,
+	DefaultChoice: "false",
+	Choices: []SettingChoice{
+		{Key: "true"},
+		{Key: "false"},
+	},
+}
+
+var NotificationChannelInAppSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldNotificationChannelInApp,
+	DefaultChoice: "true",
+	Choices: []SettingChoice{
+		{Key: "true"},
+		{Key: "false"},
+	},
+}
+
+// creates a follow-up task for meeting prep tasks which are unfinished when the meeting ends
+var MeetingPrepCarryoverSetting = SettingDefinition{
+	FieldKey:      constants.SettingFieldMeetingPrepCarryover,
+	DefaultChoice: "false",
+	Choices: []SettingChoice{
+		{Key: "true"},
+		{Key: "false"},
+	},
+}
+
 var LinearTaskFilteringSetting = SettingDefinition{
 	DefaultChoice: "all_cycles",
 	Choices: []SettingChoice{
@@ -227,6 +276,13 @@ var hardcodedSettings = []SettingDefinition{
 	LabSmartPrioritizeEnabledSetting,
 	// multical settings
 	HasDismissedMulticalPromptSetting,
+	// reminder and notification settings
+	DefaultTaskReminderSetting,
+	NotificationChannelEmailSetting,
+	NotificationChannelSlackSetting,
+	NotificationChannelInAppSetting,
+	// meeting preparation settings
+	MeetingPrepCarryoverSetting,
 }
 
 func GetSettingsOptions(db *mongo.Database, userID primitive.ObjectID) (*[]SettingDefinition, error) {
@@ -398,6 +454,26 @@ func GetUserSettings(db *mongo.Database, userID primitive.ObjectID, settingsOpti
 	return settingsResponse, nil
 }
 
+// used outside of requests (e.g. in jobs) where only a few hardcoded settings are needed
+func GetUserSettingValue(db *mongo.Database, userID primitive.ObjectID, searchSetting SettingDefinition) (string, error) {
+	var userSetting database.UserSetting
+	err := database.GetUserSettingsCollection(db).FindOne(
+		context.Background(),
+		bson.M{"$and": []bson.M{
+			{"user_id": userID},
+			{"field_key": searchSetting.FieldKey},
+		}},
+	).Decode(&userSetting)
+	if err == mongo.ErrNoDocuments {
+		return searchSetting.DefaultChoice, nil
+	}
+	if err != nil {
+		logging.GetSentryLogger().Error().Err(err).Msg("unable to fetch setting")
+		return "", err
+	}
+	return userSetting.FieldValue, nil
+}
+
 func GetSettingValue(settings []database.UserSetting, searchSetting SettingDefinition) string {
 	for _, settingValue := range settings {
 		if settingValue.FieldKey == searchSetting.FieldKey {
@@ -0,0 +1,76 @@
+package templating
+
+import (
+	"bytes"
+	"html/template"
+)
+
+type NoteTemplateData struct {
+	Title       string
+	Author      string
+	CreatedAt   string
+	UpdatedAt   string
+	LinkedEvent string
+	Body        string
+}
+
+// a standalone page which can be printed or saved as a PDF from the browser
+var noteTemplate = template.Must(template.New("note").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>{{ .Title }}</title>
+    <style>
+        @page {
+            size: letter;
+            margin: 1in;
+        }
+        html, body {
+            font-size: 12pt;
+            font-family: "Gothic A1", sans-serif;
+            color: #000000;
+        }
+        body {
+            max-width: 6.5in;
+            margin: 0 auto;
+        }
+        h1 {
+            font-size: 20pt;
+            margin: 0 0 8pt;
+        }
+        .metadata {
+            color: #555555;
+            font-size: 10pt;
+            margin-bottom: 16pt;
+            padding-bottom: 8pt;
+            border-bottom: 1px solid #cccccc;
+        }
+        .body {
+            line-height: 1.5;
+            white-space: pre-wrap;
+            overflow-wrap: break-word;
+        }
+    </style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<div class="metadata">
+{{- if .Author }}
+    <div>Author: {{ .Author }}</div>
+{{- end }}
+{{- if .LinkedEvent }}
+    <div>Meeting: {{ .LinkedEvent }}</div>
+{{- end }}
+    <div>Created: {{ .CreatedAt }}</div>
+    <div>Updated: {{ .UpdatedAt }}</div>
+</div>
+<div class="body">{{ .Body }}</div>
+</body>
+</html>
+`))
+
+func FormatNoteAsHTML(data NoteTemplateData) (string, error) {
+	buffer := new(bytes.Buffer)
+	err := noteTemplate.Execute(buffer, data)
+	return buffer.String(), err
+}
@@ -21,3 +21,18 @@ func TestFormatPlainText(t *testing.T) {
 			result)
 	})
 }
+
+func TestFormatNoteAsHTML(t *testing.T) {
+	result, err := FormatNoteAsHTML(NoteTemplateData{
+		Title:     "Q4 <planning>",
+		CreatedAt: "2022-11-14T09:00:00Z",
+		UpdatedAt: "2022-11-15T09:00:00Z",
+		Body:      "# Agenda\n<script>alert(1)</script>",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, result, "<title>Q4 &lt;planning&gt;</title>")
+	assert.Contains(t, result, "@page")
+	assert.Contains(t, result, "<div>Created: 2022-11-14T09:00:00Z</div>")
+	assert.NotContains(t, result, "Author:")
+	assert.Contains(t, result, "<div class=\"body\"># Agenda\n&lt;script&gt;alert(1)&lt;/script&gt;</div>")
+}
@@ -0,0 +1,206 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/constants"
+	"github.com/GeneralTask/task-manager/backend/database"
+)
+
+// TimeSlot is a span of time, such as working hours or a calendar event
+type TimeSlot struct {
+	Start time.Time
+	End   time.Time
+}
+
+func GetDefaultAvailability() database.UserAvailability {
+	availability := database.UserAvailability{WorkingHours: []database.WorkingHours{}, Blocks: []database.AvailabilityBlock{}}
+	for _, weekday := range constants.DefaultWorkingWeekdays {
+		availability.WorkingHours = append(availability.WorkingHours, database.WorkingHours{
+			Weekday:   weekday,
+			StartTime: constants.DefaultWorkingHoursStart,
+			EndTime:   constants.DefaultWorkingHoursEnd,
+		})
+	}
+	return availability
+}
+
+// GetUserAvailability returns the user's availability, or the default working hours if they have not set any
+func GetUserAvailability(user *database.User) database.UserAvailability {
+	if user == nil || user.Availability == nil {
+		return GetDefaultAvailability()
+	}
+	return *user.Availability
+}
+
+func ValidateAvailability(availability database.UserAvailability) error {
+	weekdays := make(map[int]bool)
+	for _, workingHours := range availability.WorkingHours {
+		if !isValidWeekday(workingHours.Weekday) {
+			return errors.New("invalid working hours weekday")
+		}
+		if weekdays[workingHours.Weekday] {
+			return errors.New("working hours can only be set once per weekday")
+		}
+		weekdays[workingHours.Weekday] = true
+		err := validateTimeRange(workingHours.StartTime, workingHours.EndTime)
+		if err != nil {
+			return fmt.Errorf("invalid working hours: %s", err.Error())
+		}
+	}
+	if len(availability.Blocks) > constants.MAX_AVAILABILITY_BLOCKS {
+		return errors.New("too many availability blocks")
+	}
+	for _, block := range availability.Blocks {
+		if block.Type != constants.AvailabilityBlockTypeLunch && block.Type != constants.AvailabilityBlockTypeNoMeetings {
+			return fmt.Errorf("invalid availability block type '%s'", block.Type)
+		}
+		for _, weekday := range block.Weekdays {
+			if !isValidWeekday(weekday) {
+				return errors.New("invalid availability block weekday")
+			}
+		}
+		err := validateTimeRange(block.StartTime, block.EndTime)
+		if err != nil {
+			return fmt.Errorf("invalid availability block: %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func isValidWeekday(weekday int) bool {
+	return weekday >= int(time.Sunday) && weekday <= int(time.Saturday)
+}
+
+func validateTimeRange(startTime string, endTime string) error {
+	start, err := time.Parse(constants.WORKING_HOURS_TIME_FORMAT, startTime)
+	if err != nil {
+		return errors.New("start time must be in HH:MM format")
+	}
+	end, err := time.Parse(constants.WORKING_HOURS_TIME_FORMAT, endTime)
+	if err != nil {
+		return errors.New("end time must be in HH:MM format")
+	}
+	if !end.After(start) {
+		return errors.New("end time must be after start time")
+	}
+	return nil
+}
+
+// GetWorkingHourSlots returns the user's working hours between start and end, without their lunch blocks
+func GetWorkingHourSlots(availability database.UserAvailability, location *time.Location, start time.Time, end time.Time) []TimeSlot {
+	slots := []TimeSlot{}
+	for _, workingHours := range availability.WorkingHours {
+		slots = append(slots, getDailyTimeSlots([]int{workingHours.Weekday}, workingHours.StartTime, workingHours.EndTime, location, start, end)...)
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Start.Before(slots[j].Start)
+	})
+	return SubtractTimeSlots(slots, GetAvailabilityBlockSlots(availability, constants.AvailabilityBlockTypeLunch, location, start, end))
+}
+
+// GetAvailabilityBlockSlots returns when the user's blocks of the given type occur between start and end
+func GetAvailabilityBlockSlots(availability database.UserAvailability, blockType string, location *time.Location, start time.Time, end time.Time) []TimeSlot {
+	slots := []TimeSlot{}
+	for _, block := range availability.Blocks {
+		if block.Type == blockType {
+			slots = append(slots, getDailyTimeSlots(block.Weekdays, block.StartTime, block.EndTime, location, start, end)...)
+		}
+	}
+	return slots
+}
+
+// invalid times are skipped, as availability is validated when it is saved
+func getDailyTimeSlots(weekdays []int, startTime string, endTime string, location *time.Location, start time.Time, end time.Time) []TimeSlot {
+	startOfDay, err := time.Parse(constants.WORKING_HOURS_TIME_FORMAT, startTime)
+	if err != nil {
+		return []TimeSlot{}
+	}
+	endOfDay, err := time.Parse(constants.WORKING_HOURS_TIME_FORMAT, endTime)
+	if err != nil {
+		return []TimeSlot{}
+	}
+	isWeekday := make(map[time.Weekday]bool)
+	for _, weekday := range weekdays {
+		isWeekday[time.Weekday(weekday)] = true
+	}
+
+	slots := []TimeSlot{}
+	localStart := start.In(location)
+	for day := time.Date(localStart.Year(), localStart.Month(), localStart.Day(), 0, 0, 0, 0, location); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if !isWeekday[day.Weekday()] {
+			continue
+		}
+		slot := TimeSlot{
+			Start: time.Date(day.Year(), day.Month(), day.Day(), startOfDay.Hour(), startOfDay.Minute(), 0, 0, location),
+			End:   time.Date(day.Year(), day.Month(), day.Day(), endOfDay.Hour(), endOfDay.Minute(), 0, 0, location),
+		}
+		if slot.Start.Before(start) {
+			slot.Start = start
+		}
+		if slot.End.After(end) {
+			slot.End = end
+		}
+		if slot.End.After(slot.Start) {
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// SubtractTimeSlots removes the busy time from the free slots, keeping their order
+func SubtractTimeSlots(freeSlots []TimeSlot, busySlots []TimeSlot) []TimeSlot {
+	for _, busy := range busySlots {
+		remaining := []TimeSlot{}
+		for _, free := range freeSlots {
+			if !busy.Start.Before(free.End) || !busy.End.After(free.Start) {
+				remaining = append(remaining, free)
+				continue
+			}
+			if busy.Start.After(free.Start) {
+				remaining = append(remaining, TimeSlot{Start: free.Start, End: busy.Start})
+			}
+			if busy.End.Before(free.End) {
+				remaining = append(remaining, TimeSlot{Start: busy.End, End: free.End})
+			}
+		}
+		freeSlots = remaining
+	}
+	return freeSlots
+}
+
+// MergeTimeSlots sorts the slots and combines any which overlap or touch
+func MergeTimeSlots(slots []TimeSlot) []TimeSlot {
+	sorted := make([]TimeSlot, len(slots))
+	copy(sorted, slots)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+	merged := []TimeSlot{}
+	for _, slot := range sorted {
+		if len(merged) > 0 && !slot.Start.After(merged[len(merged)-1].End) {
+			if slot.End.After(merged[len(merged)-1].End) {
+				merged[len(merged)-1].End = slot.End
+			}
+			continue
+		}
+		merged = append(merged, slot)
+	}
+	return merged
+}
+
+// GetLatestWorkingTime returns the given time if it is during working hours, otherwise the end of the most recent working hours in the week before it
+func GetLatestWorkingTime(availability database.UserAvailability, location *time.Location, t time.Time) (time.Time, bool) {
+	slots := GetWorkingHourSlots(availability, location, t.AddDate(0, 0, -7), t.Add(time.Nanosecond))
+	if len(slots) == 0 {
+		return time.Time{}, false
+	}
+	latest := slots[len(slots)-1]
+	if latest.End.After(t) {
+		return t, true
+	}
+	return latest.End, true
+}
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GeneralTask/task-manager/backend/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWorkingHourSlots(t *testing.T) {
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2022, time.November, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		// Friday November 18 at 15:00 until Monday November 21 at 12:00
+		slots := GetWorkingHourSlots(GetDefaultAvailability(), time.UTC, at(18, 15, 0), at(21, 12, 0))
+		assert.Equal(t, []TimeSlot{
+			{Start: at(18, 15, 0), End: at(18, 17, 0)},
+			{Start: at(21, 9, 0), End: at(21, 12, 0)},
+		}, slots)
+	})
+	t.Run("LunchBlock", func(t *testing.T) {
+		availability := database.UserAvailability{
+			WorkingHours: []database.WorkingHours{{Weekday: 1, StartTime: "09:00", EndTime: "17:00"}},
+			Blocks: []database.AvailabilityBlock{
+				{Type: "lunch", Weekdays: []int{1}, StartTime: "12:00", EndTime: "13:00"},
+				{Type: "no_meetings", Weekdays: []int{1}, StartTime: "14:00", EndTime: "15:00"},
+			},
+		}
+		slots := GetWorkingHourSlots(availability, time.UTC, at(14, 0, 0), at(15, 0, 0))
+		assert.Equal(t, []TimeSlot{
+			{Start: at(14, 9, 0), End: at(14, 12, 0)},
+			{Start: at(14, 13, 0), End: at(14, 17, 0)},
+		}, slots)
+	})
+	t.Run("Location", func(t *testing.T) {
+		losAngeles, err := time.LoadLocation("America/Los_Angeles")
+		assert.NoError(t, err)
+		availability := database.UserAvailability{WorkingHours: []database.WorkingHours{{Weekday: 1, StartTime: "09:00", EndTime: "17:00"}}}
+		slots := GetWorkingHourSlots(availability, losAngeles, at(14, 0, 0), at(16, 0, 0))
+		assert.Equal(t, 1, len(slots))
+		assert.Equal(t, at(14, 17, 0), slots[0].Start.UTC())
+		assert.Equal(t, at(15, 1, 0), slots[0].End.UTC())
+	})
+}
+
+func TestSubtractTimeSlots(t *testing.T) {
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2022, time.November, 14, hour, minute, 0, 0, time.UTC)
+	}
+	slots := SubtractTimeSlots(
+		[]TimeSlot{{Start: at(9, 0), End: at(12, 0)}, {Start: at(13, 0), End: at(17, 0)}},
+		[]TimeSlot{{Start: at(10, 0), End: at(10, 30)}, {Start: at(11, 30), End: at(13, 30)}, {Start: at(16, 0), End: at(18, 0)}},
+	)
+	assert.Equal(t, []TimeSlot{
+		{Start: at(9, 0), End: at(10, 0)},
+		{Start: at(10, 30), End: at(11, 30)},
+		{Start: at(13, 30), End: at(16, 0)},
+	}, slots)
+}
+
+func TestMergeTimeSlots(t *testing.T) {
+	at := func(hour int, minute int) time.Time {
+		return time.Date(2022, time.November, 14, hour, minute, 0, 0, time.UTC)
+	}
+	slots := MergeTimeSlots([]TimeSlot{
+		{Start: at(13, 0), End: at(14, 0)},
+		{Start: at(9, 0), End: at(10, 0)},
+		{Start: at(9, 30), End: at(9, 45)},
+		{Start: at(10, 0), End: at(11, 0)},
+		{Start: at(13, 30), End: at(15, 0)},
+	})
+	assert.Equal(t, []TimeSlot{
+		{Start: at(9, 0), End: at(11, 0)},
+		{Start: at(13, 0), End: at(15, 0)},
+	}, slots)
+}
+
+func TestValidateAvailability(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		assert.NoError(t, ValidateAvailability(GetDefaultAvailability()))
+	})
+	t.Run("InvalidWeekday", func(t *testing.T) {
+		err := ValidateAvailability(database.UserAvailability{WorkingHours: []database.WorkingHours{{Weekday: 7, StartTime: "09:00", EndTime: "17:00"}}})
+		assert.EqualError(t, err, "invalid working hours weekday")
+	})
+	t.Run("DuplicateWeekday", func(t *testing.T) {
+		err := ValidateAvailability(database.UserAvailability{WorkingHours: []database.WorkingHours{
+			{Weekday: 1, StartTime: "09:00", EndTime: "12:00"},
+			{Weekday: 1, StartTime: "13:00", EndTime: "17:00"},
+		}})
+		assert.EqualError(t, err, "working hours can only be set once per weekday")
+	})
+	t.Run("InvalidTime", func(t *testing.T) {
+		err := ValidateAvailability(database.UserAvailability{WorkingHours: []database.WorkingHours{{Weekday: 1, StartTime: "9am", EndTime: "17:00"}}})
+		assert.EqualError(t, err, "invalid working hours: start time must be in HH:MM format")
+	})
+	t.Run("EndBeforeStart", func(t *testing.T) {
+		err := ValidateAvailability(database.UserAvailability{Blocks: []database.AvailabilityBlock{{Type: "lunch", StartTime: "13:00", EndTime: "12:00"}}})
+		assert.EqualError(t, err, "invalid availability block: end time must be after start time")
+	})
+	t.Run("InvalidBlockType", func(t *testing.T) {
+		err := ValidateAvailability(database.UserAvailability{Blocks: []database.AvailabilityBlock{{Type: "gym", StartTime: "12:00", EndTime: "13:00"}}})
+		assert.EqualError(t, err, "invalid availability block type 'gym'")
+	})
+}
+
+func TestGetLatestWorkingTime(t *testing.T) {
+	availability := GetDefaultAvailability()
+
+	t.Run("DuringWorkingHours", func(t *testing.T) {
+		workingTime := time.Date(2022, time.November, 14, 10, 0, 0, 0, time.UTC)
+		latest, ok := GetLatestWorkingTime(availability, time.UTC, workingTime)
+		assert.True(t, ok)
+		assert.Equal(t, workingTime, latest)
+	})
+	t.Run("Weekend", func(t *testing.T) {
+		latest, ok := GetLatestWorkingTime(availability, time.UTC, time.Date(2022, time.November, 20, 10, 0, 0, 0, time.UTC))
+		assert.True(t, ok)
+		assert.Equal(t, time.Date(2022, time.November, 18, 17, 0, 0, 0, time.UTC), latest)
+	})
+	t.Run("NoWorkingHours", func(t *testing.T) {
+		_, ok := GetLatestWorkingTime(database.UserAvailability{}, time.UTC, time.Date(2022, time.November, 20, 10, 0, 0, 0, time.UTC))
+		assert.False(t, ok)
+	})
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"regexp"
@@ -47,9 +48,45 @@ func IsEmailValid(e string) bool {
 
 const MANDRILL_SEND_URL = "https://mandrillapp.com/api/1.0/messages/send"
 
+type mandrillRecipient struct {
+	Email string `json:"email"`
+	Type  string `json:"type"`
+}
+
+type mandrillMessage struct {
+	FromEmail string              `json:"from_email"`
+	Subject   string              `json:"subject"`
+	Text      string              `json:"text"`
+	To        []mandrillRecipient `json:"to"`
+}
+
+type mandrillSendParams struct {
+	Key     string          `json:"key"`
+	Message mandrillMessage `json:"message"`
+}
+
 func TestMailchimpEmail() error {
-	testMessage := `{"key": "` + config.GetConfigValue("MANDRILL_CLIENT_SECRET") + `", "message": {"from_email": "julian@generaltask.com", "subject": "General Task Test", "text": "Testing emails from General Task!", "to": [{ "email": "julian@generaltask.com", "type": "to" }]}}`
-	req, _ := http.NewRequest("POST", MANDRILL_SEND_URL, bytes.NewBuffer([]byte(testMessage)))
+	return SendEmail("julian@generaltask.com", "General Task Test", "Testing emails from General Task!")
+}
+
+func SendEmail(toEmail string, subject string, text string) error {
+	return sendEmailWithURL(MANDRILL_SEND_URL, toEmail, subject, text)
+}
+
+func sendEmailWithURL(sendURL string, toEmail string, subject string, text string) error {
+	message, err := json.Marshal(mandrillSendParams{
+		Key: config.GetConfigValue("MANDRILL_CLIENT_SECRET"),
+		Message: mandrillMessage{
+			FromEmail: "julian@generaltask.com",
+			Subject:   subject,
+			Text:      text,
+			To:        []mandrillRecipient{{Email: toEmail, Type: "to"}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	req, _ := http.NewRequest("POST", sendURL, bytes.NewBuffer(message))
 	req.Header.Add("Content-Type", "application/json")
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
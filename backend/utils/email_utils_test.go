@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,3 +48,25 @@ func TestIsEmailValid(t *testing.T) {
 	assert.False(t, IsEmailValid("julian"))
 	assert.True(t, IsEmailValid("julian@gmail.com"))
 }
+
+func TestSendEmail(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var params mandrillSendParams
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+			assert.Equal(t, "Task due soon", params.Message.Subject)
+			assert.Equal(t, "\"quoted\" title", params.Message.Text)
+			assert.Equal(t, []mandrillRecipient{{Email: "test@generaltask.com", Type: "to"}}, params.Message.To)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		assert.NoError(t, sendEmailWithURL(server.URL, "test@generaltask.com", "Task due soon", "\"quoted\" title"))
+	})
+	t.Run("Failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		assert.EqualError(t, sendEmailWithURL(server.URL, "test@generaltask.com", "subject", "text"), "email send failed")
+	})
+}
@@ -0,0 +1,530 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Recurrence is a parsed RFC 5545 recurrence: an optional DTSTART, an RRULE and EXDATE skip dates.
+// Occurrences are calendar dates, so rules more frequent than daily are not supported.
+type Recurrence struct {
+	Start   *time.Time
+	Rule    RRule
+	ExDates map[string]bool
+}
+
+type RRule struct {
+	Frequency  string
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []RRuleWeekday
+	ByMonthDay []int
+	ByMonth    []int
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// a weekday with an optional ordinal, e.g. -1FR is the last Friday of the month or year
+type RRuleWeekday struct {
+	Weekday time.Weekday
+	N       int
+}
+
+const (
+	RRuleFrequencyDaily   = "DAILY"
+	RRuleFrequencyWeekly  = "WEEKLY"
+	RRuleFrequencyMonthly = "MONTHLY"
+	RRuleFrequencyYearly  = "YEARLY"
+)
+
+const recurrenceDateFormat = "20060102"
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRecurrence accepts either a bare RRULE value ("FREQ=WEEKLY;BYDAY=TU") or
+// newline separated DTSTART, RRULE and EXDATE properties
+func ParseRecurrence(value string) (*Recurrence, error) {
+	recurrence := &Recurrence{ExDates: make(map[string]bool)}
+	hasRule := false
+	for _, line := range strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, propertyValue := "RRULE", line
+		if colonIndex := strings.Index(line, ":"); colonIndex >= 0 {
+			// parameters such as TZID or VALUE=DATE come after the property name
+			name = strings.ToUpper(strings.Split(line[:colonIndex], ";")[0])
+			propertyValue = line[colonIndex+1:]
+		}
+		switch name {
+		case "RRULE":
+			if hasRule {
+				return nil, errors.New("only one RRULE is supported")
+			}
+			rule, err := parseRRule(propertyValue)
+			if err != nil {
+				return nil, err
+			}
+			recurrence.Rule = *rule
+			hasRule = true
+		case "DTSTART":
+			start, err := parseRecurrenceDate(propertyValue)
+			if err != nil {
+				return nil, errors.New("invalid DTSTART")
+			}
+			recurrence.Start = &start
+		case "EXDATE":
+			for _, dateValue := range strings.Split(propertyValue, ",") {
+				exDate, err := parseRecurrenceDate(dateValue)
+				if err != nil {
+					return nil, errors.New("invalid EXDATE")
+				}
+				recurrence.ExDates[exDate.Format(recurrenceDateFormat)] = true
+			}
+		default:
+			return nil, fmt.Errorf("'%s' is not supported", name)
+		}
+	}
+	if !hasRule {
+		return nil, errors.New("missing RRULE")
+	}
+	return recurrence, nil
+}
+
+// only the date is used from date-time values
+func parseRecurrenceDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < len(recurrenceDateFormat) {
+		return time.Time{}, errors.New("invalid date")
+	}
+	return time.Parse(recurrenceDateFormat, value[:len(recurrenceDateFormat)])
+}
+
+func parseRRule(value string) (*RRule, error) {
+	rule := &RRule{Interval: 1, WeekStart: time.Monday}
+	for _, part := range strings.Split(value, ";") {
+		key, partValue, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			return nil, fmt.Errorf("invalid RRULE part '%s'", part)
+		}
+		key = strings.ToUpper(key)
+		partValue = strings.ToUpper(partValue)
+		var err error
+		switch key {
+		case "FREQ":
+			switch partValue {
+			case RRuleFrequencyDaily, RRuleFrequencyWeekly, RRuleFrequencyMonthly, RRuleFrequencyYearly:
+				rule.Frequency = partValue
+			default:
+				return nil, fmt.Errorf("FREQ '%s' is not supported", partValue)
+			}
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(partValue)
+			if err != nil || rule.Interval < 1 {
+				return nil, errors.New("invalid INTERVAL")
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(partValue)
+			if err != nil || rule.Count < 1 {
+				return nil, errors.New("invalid COUNT")
+			}
+		case "UNTIL":
+			until, err := parseRecurrenceDate(partValue)
+			if err != nil {
+				return nil, errors.New("invalid UNTIL")
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, dayValue := range strings.Split(partValue, ",") {
+				weekday, err := parseRRuleWeekday(dayValue)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseRRuleInts(partValue, 31)
+			if err != nil {
+				return nil, errors.New("invalid BYMONTHDAY")
+			}
+		case "BYMONTH":
+			rule.ByMonth, err = parseRRuleInts(partValue, 12)
+			if err != nil {
+				return nil, errors.New("invalid BYMONTH")
+			}
+			for _, month := range rule.ByMonth {
+				if month < 1 {
+					return nil, errors.New("invalid BYMONTH")
+				}
+			}
+		case "BYSETPOS":
+			rule.BySetPos, err = parseRRuleInts(partValue, 366)
+			if err != nil {
+				return nil, errors.New("invalid BYSETPOS")
+			}
+		case "WKST":
+			weekStart, exists := rruleWeekdays[partValue]
+			if !exists {
+				return nil, errors.New("invalid WKST")
+			}
+			rule.WeekStart = weekStart
+		default:
+			return nil, fmt.Errorf("'%s' is not supported", key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return nil, errors.New("missing FREQ")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, errors.New("COUNT and UNTIL cannot both be set")
+	}
+	if rule.Frequency == RRuleFrequencyWeekly && len(rule.ByMonthDay) > 0 {
+		return nil, errors.New("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	for _, weekday := range rule.ByDay {
+		if weekday.N == 0 {
+			continue
+		}
+		maxOrdinal := 53
+		if rule.Frequency == RRuleFrequencyMonthly || (rule.Frequency == RRuleFrequencyYearly && len(rule.ByMonth) > 0) {
+			maxOrdinal = 5
+		}
+		if (rule.Frequency != RRuleFrequencyMonthly && rule.Frequency != RRuleFrequencyYearly) || weekday.N > maxOrdinal || weekday.N < -maxOrdinal {
+			return nil, errors.New("invalid BYDAY")
+		}
+	}
+	return rule, nil
+}
+
+func parseRRuleWeekday(value string) (RRuleWeekday, error) {
+	if len(value) < 2 {
+		return RRuleWeekday{}, errors.New("invalid BYDAY")
+	}
+	weekday, exists := rruleWeekdays[value[len(value)-2:]]
+	if !exists {
+		return RRuleWeekday{}, errors.New("invalid BYDAY")
+	}
+	n := 0
+	if len(value) > 2 {
+		var err error
+		n, err = strconv.Atoi(value[:len(value)-2])
+		if err != nil || n == 0 {
+			return RRuleWeekday{}, errors.New("invalid BYDAY")
+		}
+	}
+	return RRuleWeekday{Weekday: weekday, N: n}, nil
+}
+
+// values can be negative to count from the end, but never zero
+func parseRRuleInts(value string, max int) ([]int, error) {
+	values := []int{}
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n == 0 || n > max || n < -max {
+			return nil, errors.New("invalid value")
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// Between returns the occurrence dates from start to end (inclusive), as UTC midnights.
+// Start must be set, and COUNT is counted from Start.
+func (recurrence *Recurrence) Between(start time.Time, end time.Time) []time.Time {
+	rule := recurrence.Rule
+	ruleStart := toDate(*recurrence.Start)
+	start = toDate(start)
+	end = toDate(end)
+	if rule.Until != nil && rule.Until.Before(end) {
+		end = toDate(*rule.Until)
+	}
+
+	results := []time.Time{}
+	period := 0
+	// without COUNT, periods before the start can't affect the results
+	if rule.Count == 0 {
+		period = rule.getPeriodsBefore(ruleStart, start)
+	}
+	count := 0
+	for ; ; period++ {
+		periodStart := rule.getPeriodStart(ruleStart, period)
+		if periodStart.After(end) {
+			return results
+		}
+		for _, date := range rule.getPeriodDates(ruleStart, periodStart) {
+			if date.Before(ruleStart) {
+				continue
+			}
+			count++
+			if date.After(end) || (rule.Count > 0 && count > rule.Count) {
+				return results
+			}
+			if !date.Before(start) && !recurrence.ExDates[date.Format(recurrenceDateFormat)] {
+				results = append(results, date)
+			}
+		}
+	}
+}
+
+func toDate(value time.Time) time.Time {
+	year, month, day := value.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func getDaysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func (rule RRule) getPeriodStart(ruleStart time.Time, period int) time.Time {
+	switch rule.Frequency {
+	case RRuleFrequencyDaily:
+		return ruleStart.AddDate(0, 0, period*rule.Interval)
+	case RRuleFrequencyWeekly:
+		daysSinceWeekStart := (int(ruleStart.Weekday()) - int(rule.WeekStart) + 7) % 7
+		return ruleStart.AddDate(0, 0, period*rule.Interval*7-daysSinceWeekStart)
+	case RRuleFrequencyMonthly:
+		return time.Date(ruleStart.Year(), ruleStart.Month()+time.Month(period*rule.Interval), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(ruleStart.Year()+period*rule.Interval, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// a lower bound on the number of whole periods between the rule start and the date
+func (rule RRule) getPeriodsBefore(ruleStart time.Time, date time.Time) int {
+	if !date.After(ruleStart) {
+		return 0
+	}
+	var units int
+	switch rule.Frequency {
+	case RRuleFrequencyDaily:
+		units = int(date.Sub(ruleStart).Hours() / 24)
+	case RRuleFrequencyWeekly:
+		units = int(date.Sub(ruleStart).Hours()/24) / 7
+	case RRuleFrequencyMonthly:
+		units = (date.Year()-ruleStart.Year())*12 + int(date.Month()) - int(ruleStart.Month())
+	case RRuleFrequencyYearly:
+		units = date.Year() - ruleStart.Year()
+	}
+	periods := units/rule.Interval - 1
+	if periods < 0 {
+		return 0
+	}
+	return periods
+}
+
+func (rule RRule) getPeriodDates(ruleStart time.Time, periodStart time.Time) []time.Time {
+	dates := []time.Time{}
+	switch rule.Frequency {
+	case RRuleFrequencyDaily:
+		if rule.matchesDaily(periodStart) {
+			dates = append(dates, periodStart)
+		}
+	case RRuleFrequencyWeekly:
+		byDay := rule.ByDay
+		if len(byDay) == 0 {
+			byDay = []RRuleWeekday{{Weekday: ruleStart.Weekday()}}
+		}
+		for i := 0; i < 7; i++ {
+			date := periodStart.AddDate(0, 0, i)
+			if containsInt(rule.ByMonth, int(date.Month())) && matchesWeekdays(byDay, date) {
+				dates = append(dates, date)
+			}
+		}
+	case RRuleFrequencyMonthly:
+		if containsInt(rule.ByMonth, int(periodStart.Month())) {
+			dates = rule.getMonthDates(periodStart.Year(), periodStart.Month(), ruleStart.Day())
+		}
+	case RRuleFrequencyYearly:
+		dates = rule.getYearDates(periodStart.Year(), ruleStart)
+	}
+	return rule.applySetPos(dates)
+}
+
+func (rule RRule) matchesDaily(date time.Time) bool {
+	if !containsInt(rule.ByMonth, int(date.Month())) || !matchesWeekdays(rule.ByDay, date) {
+		return false
+	}
+	if len(rule.ByMonthDay) == 0 {
+		return true
+	}
+	daysInMonth := getDaysInMonth(date.Year(), date.Month())
+	for _, monthDay := range rule.ByMonthDay {
+		if resolveMonthDay(monthDay, daysInMonth) == date.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule RRule) getMonthDates(year int, month time.Month, defaultDay int) []time.Time {
+	daysInMonth := getDaysInMonth(year, month)
+	monthDates := []time.Time{}
+	for day := 1; day <= daysInMonth; day++ {
+		monthDates = append(monthDates, time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+	}
+	if len(rule.ByMonthDay) == 0 && len(rule.ByDay) == 0 {
+		// months without the start day (e.g. the 31st) are skipped
+		if defaultDay > daysInMonth {
+			return []time.Time{}
+		}
+		return []time.Time{monthDates[defaultDay-1]}
+	}
+
+	dates := monthDates
+	if len(rule.ByDay) > 0 {
+		dates = filterByWeekdays(rule.ByDay, dates)
+	}
+	if len(rule.ByMonthDay) > 0 {
+		days := make(map[int]bool)
+		for _, monthDay := range rule.ByMonthDay {
+			days[resolveMonthDay(monthDay, daysInMonth)] = true
+		}
+		filtered := []time.Time{}
+		for _, date := range dates {
+			if days[date.Day()] {
+				filtered = append(filtered, date)
+			}
+		}
+		dates = filtered
+	}
+	return dates
+}
+
+func (rule RRule) getYearDates(year int, ruleStart time.Time) []time.Time {
+	if len(rule.ByMonth) > 0 {
+		dates := []time.Time{}
+		for _, month := range rule.ByMonth {
+			dates = append(dates, rule.getMonthDates(year, time.Month(month), ruleStart.Day())...)
+		}
+		sortDates(dates)
+		return dates
+	}
+	if len(rule.ByMonthDay) > 0 {
+		dates := []time.Time{}
+		for month := time.January; month <= time.December; month++ {
+			dates = append(dates, rule.getMonthDates(year, month, ruleStart.Day())...)
+		}
+		return dates
+	}
+	if len(rule.ByDay) > 0 {
+		// without BYMONTH, ordinals count weekdays through the whole year
+		yearDates := []time.Time{}
+		for date := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); date.Year() == year; date = date.AddDate(0, 0, 1) {
+			yearDates = append(yearDates, date)
+		}
+		return filterByWeekdays(rule.ByDay, yearDates)
+	}
+	// February 29th only occurs in leap years
+	if ruleStart.Day() > getDaysInMonth(year, ruleStart.Month()) {
+		return []time.Time{}
+	}
+	return []time.Time{time.Date(year, ruleStart.Month(), ruleStart.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+func (rule RRule) applySetPos(dates []time.Time) []time.Time {
+	if len(rule.BySetPos) == 0 {
+		return dates
+	}
+	selected := []time.Time{}
+	for _, position := range rule.BySetPos {
+		index := position - 1
+		if position < 0 {
+			index = len(dates) + position
+		}
+		if index >= 0 && index < len(dates) {
+			selected = append(selected, dates[index])
+		}
+	}
+	sortDates(selected)
+	unique := []time.Time{}
+	for _, date := range selected {
+		if len(unique) == 0 || !unique[len(unique)-1].Equal(date) {
+			unique = append(unique, date)
+		}
+	}
+	return unique
+}
+
+func filterByWeekdays(weekdays []RRuleWeekday, dates []time.Time) []time.Time {
+	selected := make(map[int]bool)
+	for _, weekday := range weekdays {
+		matching := []int{}
+		for index, date := range dates {
+			if date.Weekday() == weekday.Weekday {
+				matching = append(matching, index)
+			}
+		}
+		if weekday.N == 0 {
+			for _, index := range matching {
+				selected[index] = true
+			}
+			continue
+		}
+		position := weekday.N - 1
+		if weekday.N < 0 {
+			position = len(matching) + weekday.N
+		}
+		if position >= 0 && position < len(matching) {
+			selected[matching[position]] = true
+		}
+	}
+	filtered := []time.Time{}
+	for index, date := range dates {
+		if selected[index] {
+			filtered = append(filtered, date)
+		}
+	}
+	return filtered
+}
+
+func matchesWeekdays(weekdays []RRuleWeekday, date time.Time) bool {
+	if len(weekdays) == 0 {
+		return true
+	}
+	for _, weekday := range weekdays {
+		if weekday.Weekday == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveMonthDay(monthDay int, daysInMonth int) int {
+	if monthDay < 0 {
+		return daysInMonth + monthDay + 1
+	}
+	return monthDay
+}
+
+// an empty list matches everything
+func containsInt(values []int, value int) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortDates(dates []time.Time) {
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	t.Run("BareRule", func(t *testing.T) {
+		recurrence, err := ParseRecurrence("FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;WKST=SU")
+		assert.NoError(t, err)
+		assert.Nil(t, recurrence.Start)
+		assert.Equal(t, RRule{
+			Frequency: RRuleFrequencyWeekly,
+			Interval:  2,
+			ByDay:     []RRuleWeekday{{Weekday: time.Tuesday}, {Weekday: time.Thursday}},
+			WeekStart: time.Sunday,
+		}, recurrence.Rule)
+	})
+	t.Run("Properties", func(t *testing.T) {
+		recurrence, err := ParseRecurrence("DTSTART;TZID=America/New_York:20230103T090000\nRRULE:FREQ=MONTHLY;BYDAY=-1FR;COUNT=3\nEXDATE;VALUE=DATE:20230127,20230224")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2023, time.January, 3, 0, 0, 0, 0, time.UTC), *recurrence.Start)
+		assert.Equal(t, []RRuleWeekday{{Weekday: time.Friday, N: -1}}, recurrence.Rule.ByDay)
+		assert.Equal(t, 3, recurrence.Rule.Count)
+		assert.Equal(t, map[string]bool{"20230127": true, "20230224": true}, recurrence.ExDates)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for value, expectedError := range map[string]string{
+			"":                                   "missing RRULE",
+			"INTERVAL=2":                         "missing FREQ",
+			"FREQ=HOURLY":                        "FREQ 'HOURLY' is not supported",
+			"FREQ=DAILY;BYHOUR=9":                "'BYHOUR' is not supported",
+			"FREQ=DAILY;INTERVAL=0":              "invalid INTERVAL",
+			"FREQ=DAILY;COUNT=2;UNTIL=20230101":  "COUNT and UNTIL cannot both be set",
+			"FREQ=WEEKLY;BYDAY=1MO":              "invalid BYDAY",
+			"FREQ=MONTHLY;BYDAY=6MO":             "invalid BYDAY",
+			"FREQ=MONTHLY;BYDAY=XX":              "invalid BYDAY",
+			"FREQ=MONTHLY;BYMONTHDAY=0":          "invalid BYMONTHDAY",
+			"FREQ=WEEKLY;BYMONTHDAY=1":           "BYMONTHDAY cannot be used with FREQ=WEEKLY",
+			"FREQ=YEARLY;BYMONTH=13":             "invalid BYMONTH",
+			"RRULE:FREQ=DAILY\nEXDATE:tomorrow":  "invalid EXDATE",
+			"RRULE:FREQ=DAILY\nRDATE:20230101":   "'RDATE' is not supported",
+			"RRULE:FREQ=DAILY\nRRULE:FREQ=DAILY": "only one RRULE is supported",
+		} {
+			_, err := ParseRecurrence(value)
+			assert.EqualError(t, err, expectedError, value)
+		}
+	})
+}
+
+func TestRecurrenceBetween(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2023, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	// Sunday January 1, 2023
+	start := date(time.January, 1)
+	getDates := func(value string, from time.Time, to time.Time) []time.Time {
+		recurrence, err := ParseRecurrence(value)
+		assert.NoError(t, err)
+		if recurrence.Start == nil {
+			recurrence.Start = &start
+		}
+		return recurrence.Between(from, to)
+	}
+
+	t.Run("EveryThreeDays", func(t *testing.T) {
+		assert.Equal(t, []time.Time{date(time.January, 10), date(time.January, 13)}, getDates("FREQ=DAILY;INTERVAL=3", date(time.January, 8), date(time.January, 14)))
+	})
+	t.Run("EveryOtherTuesday", func(t *testing.T) {
+		// weeks start on Monday, so the start date is in the week before January 3
+		assert.Equal(t, []time.Time{date(time.January, 10), date(time.January, 24), date(time.February, 7)}, getDates("FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", start, date(time.February, 7)))
+		assert.Equal(t, []time.Time{date(time.January, 3), date(time.January, 17)}, getDates("FREQ=WEEKLY;INTERVAL=2;BYDAY=TU;WKST=SU", start, date(time.January, 30)))
+	})
+	t.Run("Weekdays", func(t *testing.T) {
+		assert.Equal(t, []time.Time{date(time.January, 6), date(time.January, 9)}, getDates("FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", date(time.January, 6), date(time.January, 9)))
+	})
+	t.Run("LastFridayOfTheMonth", func(t *testing.T) {
+		assert.Equal(t, []time.Time{date(time.January, 27), date(time.February, 24), date(time.March, 31)}, getDates("FREQ=MONTHLY;BYDAY=-1FR", start, date(time.April, 1)))
+	})
+	t.Run("FirstAndFifteenth", func(t *testing.T) {
+		assert.Equal(t, []time.Time{date(time.January, 15), date(time.February, 1), date(time.February, 15)}, getDates("FREQ=MONTHLY;BYMONTHDAY=1,15", date(time.January, 2), date(time.February, 20)))
+	})
+	t.Run("LastWorkdayOfTheMonth", func(t *testing.T) {
+		assert.Equal(t, []time.Time{date(time.January, 31), date(time.February, 28), date(time.March, 31), date(time.April, 28)}, getDates("FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", start, date(time.April, 30)))
+	})
+	t.Run("SkipsShortMonths", func(t *testing.T) {
+		januaryThirtyFirst := date(time.January, 31)
+		recurrence, err := ParseRecurrence("FREQ=MONTHLY")
+		assert.NoError(t, err)
+		recurrence.Start = &januaryThirtyFirst
+		assert.Equal(t, []time.Time{date(time.January, 31), date(time.March, 31)}, recurrence.Between(start, date(time.April, 30)))
+	})
+	t.Run("Yearly", func(t *testing.T) {
+		assert.Equal(t, []time.Time{date(time.November, 23), time.Date(2024, time.November, 28, 0, 0, 0, 0, time.UTC)}, getDates("FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", start, time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)))
+	})
+	t.Run("CountAndExDates", func(t *testing.T) {
+		// COUNT includes excluded dates and dates before the range
+		assert.Equal(t, []time.Time{date(time.January, 4)}, getDates("RRULE:FREQ=DAILY;COUNT=4\nEXDATE:20230103", date(time.January, 3), date(time.January, 31)))
+	})
+	t.Run("Until", func(t *testing.T) {
+		assert.Equal(t, []time.Time{date(time.January, 2), date(time.January, 9)}, getDates("FREQ=WEEKLY;BYDAY=MO;UNTIL=20230109T235959Z", start, date(time.January, 31)))
+	})
+	t.Run("DTSTART", func(t *testing.T) {
+		assert.Equal(t, []time.Time{date(time.January, 10), date(time.January, 24)}, getDates("DTSTART:20230110\nRRULE:FREQ=WEEKLY;INTERVAL=2", start, date(time.January, 31)))
+	})
+}